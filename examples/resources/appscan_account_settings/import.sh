@@ -0,0 +1,2 @@
+# The account settings are imported by tenant ID.
+terraform import appscan_account_settings.example 00000000-0000-0000-0000-000000000000
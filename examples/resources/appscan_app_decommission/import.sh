@@ -0,0 +1,2 @@
+# Decommissionings are imported by application ID.
+terraform import appscan_app_decommission.example 00000000-0000-0000-0000-000000000000
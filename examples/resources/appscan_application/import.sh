@@ -0,0 +1,2 @@
+# Applications are imported by ID.
+terraform import appscan_application.example 00000000-0000-0000-0000-000000000000
@@ -0,0 +1,2 @@
+# Attribute definitions are imported by name.
+terraform import appscan_attribute_definition.example owner
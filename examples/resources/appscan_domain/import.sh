@@ -0,0 +1,2 @@
+# Domains are imported by their numeric ID.
+terraform import appscan_domain.example 42
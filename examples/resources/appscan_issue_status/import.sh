@@ -0,0 +1,4 @@
+# Issue statuses are imported by issue ID, or by application_id:issue_id to
+# also check the application the issue belongs to.
+terraform import appscan_issue_status.example 00000000-0000-0000-0000-000000000000
+terraform import appscan_issue_status.example 11111111-1111-1111-1111-111111111111:00000000-0000-0000-0000-000000000000
@@ -0,0 +1,2 @@
+# Notification settings are imported by scan ID.
+terraform import appscan_notification_settings.example 00000000-0000-0000-0000-000000000000
@@ -0,0 +1,3 @@
+# Reports are imported by scope:scope_id:report_id, as the API does not
+# return what a report covers.
+terraform import appscan_report.example Application:11111111-1111-1111-1111-111111111111:00000000-0000-0000-0000-000000000000
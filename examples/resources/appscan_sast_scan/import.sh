@@ -0,0 +1,4 @@
+# Scans are imported by ID, or by application_id:scan_id to also check the
+# application the scan belongs to.
+terraform import appscan_sast_scan.example 00000000-0000-0000-0000-000000000000
+terraform import appscan_sast_scan.example 11111111-1111-1111-1111-111111111111:00000000-0000-0000-0000-000000000000
@@ -0,0 +1,2 @@
+# Webhooks are imported by ID.
+terraform import appscan_webhook.example 00000000-0000-0000-0000-000000000000
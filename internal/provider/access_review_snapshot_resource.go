@@ -0,0 +1,210 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// appscan_access_review_snapshot writes the users, roles and asset groups of
+// the tenant to a local JSON file, as evidence for access reviews. The API
+// does not expose which asset groups a user has access to, only the number
+// of users of each asset group.
+
+func resourceAppScanAccessReviewSnapshot() *schema.Resource {
+	return &schema.Resource{
+		CreateWithoutTimeout: withContext(resourceAppScanAccessReviewSnapshotCreate),
+		Read:                 resourceAppScanAccessReviewSnapshotRead,
+		UpdateWithoutTimeout: withContext(resourceAppScanAccessReviewSnapshotUpdate),
+		Delete:               resourceAppScanAccessReviewSnapshotDelete,
+		CustomizeDiff:        customizeDiffAccessReviewSnapshot,
+		Schema: map[string]*schema.Schema{
+			"output_path": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The local path the snapshot is written to. The snapshot is taken again if the file goes missing.",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary values that, when changed, take a new snapshot, e.g. the quarter under review.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"capture_on_apply": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If true, a new snapshot is taken on every apply, so plans always show a change.",
+			},
+			"captured_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the snapshot was taken (RFC 3339).",
+			},
+			"user_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of users in the snapshot.",
+			},
+			"role_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of roles in the snapshot.",
+			},
+			"asset_group_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of asset groups in the snapshot.",
+			},
+			"sha256": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA-256 checksum of the file, to prove the evidence was not altered.",
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A random identifier of the snapshot.",
+			},
+		},
+	}
+}
+
+// accessReviewSnapshot is the document written to output_path. Entries are
+// kept as returned by the API.
+type accessReviewSnapshot struct {
+	CapturedAt  string                   `json:"captured_at"`
+	ApiEndpoint string                   `json:"api_endpoint"`
+	Users       []map[string]interface{} `json:"users"`
+	Roles       []map[string]interface{} `json:"roles"`
+	AssetGroups []map[string]interface{} `json:"asset_groups"`
+}
+
+func customizeDiffAccessReviewSnapshot(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" && d.Get("capture_on_apply").(bool) {
+		for _, k := range []string{"captured_at", "user_count", "role_count", "asset_group_count", "sha256"} {
+			if err := d.SetNewComputed(k); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func resourceAppScanAccessReviewSnapshotCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return err
+	}
+	if err := captureAccessReviewSnapshot(ctx, d, m); err != nil {
+		return err
+	}
+	d.SetId(id)
+	return nil
+}
+
+func resourceAppScanAccessReviewSnapshotRead(d *schema.ResourceData, m interface{}) error {
+	body, err := os.ReadFile(d.Get("output_path").(string))
+	if os.IsNotExist(err) {
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(body)
+	d.Set("sha256", hex.EncodeToString(sum[:]))
+	return nil
+}
+
+func resourceAppScanAccessReviewSnapshotUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if d.Get("capture_on_apply").(bool) {
+		return captureAccessReviewSnapshot(ctx, d, m)
+	}
+	return nil
+}
+
+// resourceAppScanAccessReviewSnapshotDelete leaves the file in place: it is
+// audit evidence.
+func resourceAppScanAccessReviewSnapshotDelete(d *schema.ResourceData, m interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+// captureAccessReviewSnapshot lists the users, roles and asset groups and
+// writes them to output_path.
+func captureAccessReviewSnapshot(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	snapshot := accessReviewSnapshot{
+		CapturedAt:  time.Now().UTC().Format(time.RFC3339),
+		ApiEndpoint: client.ApiEndpoint,
+	}
+	var err error
+	if snapshot.Users, err = listODataCollection(ctx, client, "User"); err != nil {
+		return err
+	}
+	if snapshot.Roles, err = listODataCollection(ctx, client, "Roles"); err != nil {
+		return err
+	}
+	if snapshot.AssetGroups, err = listODataCollection(ctx, client, "AssetGroups"); err != nil {
+		return err
+	}
+
+	body, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	path := d.Get("output_path").(string)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	// The snapshot holds user names and emails.
+	if err := os.WriteFile(path, body, 0o600); err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256(body)
+	d.Set("captured_at", snapshot.CapturedAt)
+	d.Set("user_count", len(snapshot.Users))
+	d.Set("role_count", len(snapshot.Roles))
+	d.Set("asset_group_count", len(snapshot.AssetGroups))
+	d.Set("sha256", hex.EncodeToString(sum[:]))
+	client.Summary.record("access_review_snapshot_captured", "appscan_access_review_snapshot", path, map[string]string{
+		"users": strconv.Itoa(len(snapshot.Users)),
+	})
+	return nil
+}
+
+// listODataCollection returns every entry of an OData collection, as
+// returned by the API.
+func listODataCollection(ctx context.Context, client *AppScanClient, collection string) ([]map[string]interface{}, error) {
+	entries := []map[string]interface{}{}
+	for skip := 0; ; skip += catalogPageSize {
+		query := url.Values{}
+		query.Set("$top", strconv.Itoa(catalogPageSize))
+		query.Set("$skip", strconv.Itoa(skip))
+
+		var result struct {
+			Items []map[string]interface{} `json:"Items"`
+		}
+		if err := getODataPage(ctx, client, collection, query, &result); err != nil {
+			return nil, err
+		}
+		entries = append(entries, result.Items...)
+		if len(result.Items) < catalogPageSize {
+			return entries, nil
+		}
+	}
+}
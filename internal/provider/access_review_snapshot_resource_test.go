@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccAccessReviewSnapshotResource(t *testing.T) {
+	m := newMockServer(t)
+	m.add("Users", mockEntity{"UserName": "alice@example.com", "RoleName": "Administrator", "IsAdmin": true})
+	m.add("Users", mockEntity{"UserName": "bob@example.com", "RoleName": "Developer", "IsAdmin": false})
+	m.add("Roles", mockEntity{"Name": "Developer", "Capabilities": map[string]bool{"CanScan": true}})
+	path := filepath.Join(t.TempDir(), "review", "q3.json")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccessReviewSnapshotConfig(m, path, "2026-Q3"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_access_review_snapshot.test", "user_count", "2"),
+					resource.TestCheckResourceAttr("appscan_access_review_snapshot.test", "role_count", "1"),
+					resource.TestCheckResourceAttr("appscan_access_review_snapshot.test", "asset_group_count", "2"),
+					resource.TestCheckResourceAttrSet("appscan_access_review_snapshot.test", "sha256"),
+					func(*terraform.State) error {
+						body, err := os.ReadFile(path)
+						if err != nil {
+							return err
+						}
+						var snapshot accessReviewSnapshot
+						if err := json.Unmarshal(body, &snapshot); err != nil {
+							return err
+						}
+						if len(snapshot.Users) != 2 || snapshot.Users[1]["RoleName"] != "Developer" {
+							return fmt.Errorf("unexpected users: %v", snapshot.Users)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// A missing file is written again.
+				PreConfig: func() {
+					os.Remove(path)
+				},
+				Config: testAccAccessReviewSnapshotConfig(m, path, "2026-Q3"),
+				Check: func(*terraform.State) error {
+					_, err := os.Stat(path)
+					return err
+				},
+			},
+		},
+	})
+}
+
+func testAccAccessReviewSnapshotConfig(m *mockServer, path, quarter string) string {
+	return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_access_review_snapshot" "test" {
+  output_path = %q
+  triggers = {
+    quarter = %q
+  }
+}
+`, path, quarter)
+}
@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The account settings are those of the TenantInfo the API lets an
+// administrator update. The session timeout is read only, and the API has no
+// IP allowlist nor default scan options: they are managed in the console.
+// Only the configured settings are sent, so that the others keep the value
+// set in the console, and drift is reported on the configured ones alone.
+
+func resourceAppScanAccountSettings() *schema.Resource {
+	return &schema.Resource{
+		CreateWithoutTimeout: withContext(resourceAppScanAccountSettingsCreate),
+		ReadWithoutTimeout:   withContext(resourceAppScanAccountSettingsRead),
+		UpdateWithoutTimeout: withContext(resourceAppScanAccountSettingsUpdate),
+		Delete:               resourceAppScanAccountSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			State: importGUID,
+		},
+		Description: "Manages the settings of the AppScan account (tenant) of the provider credentials, so that security baselines are enforced by code. " +
+			"The account has a single set of settings: declare one such resource per account. Destroying it leaves the settings as they are.",
+		Schema: map[string]*schema.Schema{
+			"tenant_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The name of the account.",
+			},
+			"contact_email": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "The email address of the contact of the account.",
+			},
+			"auto_delete_exceeded_scans": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether the oldest scans of an application are deleted when it exceeds max_scans_per_app, rather than new scans being refused.",
+			},
+			"allow_app_manager_override_auto_delete": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether application managers may override auto_delete_exceeded_scans for their applications.",
+			},
+			"issues_auto_close": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether issues no longer found by a rescan are closed automatically.",
+			},
+			"allow_override_issues_auto_close": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether issues_auto_close may be overridden per application.",
+			},
+			"show_non_compliant_issues_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Computed:    true,
+				Description: "Whether only the issues violating a compliance policy are shown.",
+			},
+			"idle_time_for_signout": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The idle time after which users are signed out of the console, as reported by the API. It cannot be changed through the API.",
+			},
+			"max_scans_per_app": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The maximum number of scans per application.",
+			},
+			"domain_verification_required": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the domains of DAST scans must be verified before they are scanned.",
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the account (tenant).",
+			},
+		},
+	}
+}
+
+// appScanTenantSettings is the TenantInfoModel payload updating the account
+// settings. Null fields are left unchanged.
+type appScanTenantSettings struct {
+	TenantName                                *string `json:"TenantName,omitempty"`
+	ContactEmail                              *string `json:"ContactEmail,omitempty"`
+	AutoDeleteExceededScansPerApp             *bool   `json:"AutoDeleteExceededScansPerApp,omitempty"`
+	AllowAppManagerOverrideAutoDeleteSettings *bool   `json:"AllowAppManagerOverrideAutoDeleteSettings,omitempty"`
+	EnableIssuesAutoClose                     *bool   `json:"EnableIssuesAutoClose,omitempty"`
+	EnableOverrideIssuesAutoClose             *bool   `json:"EnableOverrideIssuesAutoClose,omitempty"`
+	ShowNonCompliantIssuesOnly                *bool   `json:"ShowNonCompliantIssuesOnly,omitempty"`
+}
+
+func resourceAppScanAccountSettingsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if err := updateAccountSettings(ctx, d, m); err != nil {
+		return err
+	}
+	return resourceAppScanAccountSettingsRead(ctx, d, m)
+}
+
+func resourceAppScanAccountSettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	tenant, _, message := checkTenant(ctx, client)
+	if tenant == nil {
+		return fmt.Errorf("cannot read the account settings: %s", message)
+	}
+	if d.Id() != "" && !strings.EqualFold(d.Id(), tenant.TenantId) {
+		return fmt.Errorf("the credentials belong to tenant %s (%s), not to the account %s of appscan_account_settings", tenant.TenantId, tenant.TenantName, d.Id())
+	}
+	d.SetId(tenant.TenantId)
+	d.Set("tenant_name", tenant.TenantName)
+	d.Set("contact_email", tenant.ContactEmail)
+	d.Set("auto_delete_exceeded_scans", tenant.AutoDeleteExceededScansPerApp)
+	d.Set("allow_app_manager_override_auto_delete", tenant.AllowAppManagerOverrideAutoDeleteSettings)
+	d.Set("issues_auto_close", tenant.EnableIssuesAutoClose)
+	d.Set("allow_override_issues_auto_close", tenant.EnableOverrideIssuesAutoClose)
+	d.Set("show_non_compliant_issues_only", tenant.ShowNonCompliantIssuesOnly)
+	d.Set("idle_time_for_signout", tenant.IdleTimeForSignout)
+	d.Set("max_scans_per_app", tenant.MaxScansPerApp)
+	d.Set("domain_verification_required", tenant.DomainVerificationRequired)
+	return nil
+}
+
+func resourceAppScanAccountSettingsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if err := updateAccountSettings(ctx, d, m); err != nil {
+		return err
+	}
+	return resourceAppScanAccountSettingsRead(ctx, d, m)
+}
+
+// resourceAppScanAccountSettingsDelete only forgets the settings: an
+// account always has them, so the last applied ones are left in place.
+func resourceAppScanAccountSettingsDelete(d *schema.ResourceData, m interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+// updateAccountSettings sends the configured settings to the API.
+func updateAccountSettings(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	config := d.GetRawConfig()
+	configured := func(attr string) bool {
+		return !config.IsNull() && !config.GetAttr(attr).IsNull()
+	}
+	stringSetting := func(attr string) *string {
+		if !configured(attr) {
+			return nil
+		}
+		v := d.Get(attr).(string)
+		return &v
+	}
+	boolSetting := func(attr string) *bool {
+		if !configured(attr) {
+			return nil
+		}
+		v := d.Get(attr).(bool)
+		return &v
+	}
+	settings := appScanTenantSettings{
+		TenantName:                    stringSetting("tenant_name"),
+		ContactEmail:                  stringSetting("contact_email"),
+		AutoDeleteExceededScansPerApp: boolSetting("auto_delete_exceeded_scans"),
+		AllowAppManagerOverrideAutoDeleteSettings: boolSetting("allow_app_manager_override_auto_delete"),
+		EnableIssuesAutoClose:                     boolSetting("issues_auto_close"),
+		EnableOverrideIssuesAutoClose:             boolSetting("allow_override_issues_auto_close"),
+		ShowNonCompliantIssuesOnly:                boolSetting("show_non_compliant_issues_only"),
+	}
+	body, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	if string(body) == "{}" {
+		// Nothing is managed: the resource only reads the settings.
+		return nil
+	}
+
+	urlStr := fmt.Sprintf("%s/Account/TenantInfo", client.ApiBase)
+	req, err := client.newRequest(ctx, "PUT", urlStr, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return appscan.NewAPIError("update account settings", resp)
+	}
+	client.Summary.record("account_settings_updated", "appscan_account_settings", d.Id(), nil)
+	return nil
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccAccountSettingsResource(t *testing.T) {
+	m := newMockServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAccountSettingsConfig(m, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_account_settings.test", "id", mockTenantID),
+					resource.TestCheckResourceAttr("appscan_account_settings.test", "issues_auto_close", "true"),
+					resource.TestCheckResourceAttr("appscan_account_settings.test", "contact_email", "appsec@example.com"),
+					// Settings left out of the configuration are read, not reset.
+					resource.TestCheckResourceAttr("appscan_account_settings.test", "tenant_name", "Mock Tenant"),
+					resource.TestCheckResourceAttr("appscan_account_settings.test", "idle_time_for_signout", "30"),
+					resource.TestCheckResourceAttr("appscan_account_settings.test", "max_scans_per_app", "100"),
+					func(*terraform.State) error {
+						m.mu.Lock()
+						defer m.mu.Unlock()
+						tenant := m.collections["Tenants"][0]
+						if tenant["EnableIssuesAutoClose"] != true || tenant["ContactEmail"] != "appsec@example.com" || tenant["TenantName"] != "Mock Tenant" {
+							return fmt.Errorf("tenant settings = %v", tenant)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccAccountSettingsConfig(m, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_account_settings.test", "issues_auto_close", "false"),
+					func(*terraform.State) error {
+						m.mu.Lock()
+						defer m.mu.Unlock()
+						if v := m.collections["Tenants"][0]["EnableIssuesAutoClose"]; v != false {
+							return fmt.Errorf("EnableIssuesAutoClose = %v, want false", v)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				// Drift on a managed setting is planned back.
+				PreConfig: func() {
+					m.mu.Lock()
+					defer m.mu.Unlock()
+					m.collections["Tenants"][0]["EnableIssuesAutoClose"] = true
+				},
+				Config:             testAccAccountSettingsConfig(m, false),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccAccountSettingsConfig(m, false),
+			},
+			{
+				ResourceName:      "appscan_account_settings.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccAccountSettingsConfig(m *mockServer, autoClose bool) string {
+	return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_account_settings" "test" {
+  contact_email     = "appsec@example.com"
+  issues_auto_close = %t
+}
+`, autoClose)
+}
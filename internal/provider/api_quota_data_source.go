@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ----------------------------------------------------------------
+// Data Source: appscan_api_quota (rate-limit headroom of the API)
+// ----------------------------------------------------------------
+
+// The API has no quota endpoint; the headroom is read from the rate-limit
+// headers of a cheap request, in either the X-RateLimit-* or the
+// RateLimit-* form. Not every deployment sends them, hence "reported".
+
+func dataSourceApiQuota() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: withContext(dataSourceApiQuotaRead),
+		Schema: map[string]*schema.Schema{
+			"reported": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the API reported its rate limit. When false, the other attributes are 0.",
+			},
+			"limit": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of requests allowed in the current window.",
+			},
+			"remaining": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of requests left in the current window.",
+			},
+			"remaining_percent": {
+				Type:        schema.TypeFloat,
+				Computed:    true,
+				Description: "remaining as a percentage of limit.",
+			},
+			"reset_in_seconds": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of seconds until the window resets.",
+			},
+		},
+	}
+}
+
+func dataSourceApiQuotaRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	urlStr := fmt.Sprintf("%s/Account/TenantInfo", client.ApiBase)
+	req, err := client.newRequest(ctx, "GET", urlStr, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return appscan.NewAPIError("read API quota", resp)
+	}
+
+	quota, reported := parseRateLimitHeaders(resp.Header, time.Now())
+	d.Set("reported", reported)
+	d.Set("limit", quota.limit)
+	d.Set("remaining", quota.remaining)
+	percent := 0.0
+	if quota.limit > 0 {
+		percent = float64(quota.remaining) * 100 / float64(quota.limit)
+	}
+	d.Set("remaining_percent", percent)
+	d.Set("reset_in_seconds", quota.resetIn)
+
+	d.SetId(client.ApiEndpoint)
+	return nil
+}
+
+// rateLimitQuota is the rate-limit state reported by the API.
+type rateLimitQuota struct {
+	limit     int
+	remaining int
+	resetIn   int
+}
+
+// parseRateLimitHeaders reads the rate-limit headers of a response. The reset
+// is accepted both as a number of seconds and as a Unix time, which some
+// gateways send instead. It returns false when limit and remaining are not
+// both reported.
+func parseRateLimitHeaders(h http.Header, now time.Time) (rateLimitQuota, bool) {
+	get := func(name string) (int, bool) {
+		for _, prefix := range []string{"X-RateLimit-", "RateLimit-"} {
+			if v := strings.TrimSpace(h.Get(prefix + name)); v != "" {
+				n, err := strconv.ParseInt(v, 10, 64)
+				return int(n), err == nil
+			}
+		}
+		return 0, false
+	}
+
+	var q rateLimitQuota
+	limit, ok := get("Limit")
+	if !ok {
+		return q, false
+	}
+	remaining, ok := get("Remaining")
+	if !ok {
+		return q, false
+	}
+	q.limit, q.remaining = limit, remaining
+	if reset, ok := get("Reset"); ok {
+		// No window lasts anywhere near 2001-09-09, the Unix time 1e9.
+		if reset >= 1e9 {
+			reset = int(time.Unix(int64(reset), 0).Sub(now).Seconds())
+		}
+		q.resetIn = max(reset, 0)
+	}
+	return q, true
+}
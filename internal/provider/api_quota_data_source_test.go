@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccApiQuotaDataSource(t *testing.T) {
+	m := newMockServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + `
+data "appscan_api_quota" "test" {}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_api_quota.test", "reported", "false"),
+					resource.TestCheckResourceAttr("data.appscan_api_quota.test", "limit", "0"),
+				),
+			},
+			{
+				PreConfig: func() {
+					h := http.Header{}
+					h.Set("X-RateLimit-Limit", "200")
+					h.Set("X-RateLimit-Remaining", "50")
+					h.Set("X-RateLimit-Reset", "30")
+					m.mu.Lock()
+					m.headers = h
+					m.mu.Unlock()
+				},
+				Config: testAccProviderConfig(m) + `
+data "appscan_api_quota" "test" {}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_api_quota.test", "reported", "true"),
+					resource.TestCheckResourceAttr("data.appscan_api_quota.test", "limit", "200"),
+					resource.TestCheckResourceAttr("data.appscan_api_quota.test", "remaining", "50"),
+					resource.TestCheckResourceAttr("data.appscan_api_quota.test", "remaining_percent", "25"),
+					resource.TestCheckResourceAttr("data.appscan_api_quota.test", "reset_in_seconds", "30"),
+				),
+			},
+		},
+	})
+}
+
+func TestParseRateLimitHeaders(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	cases := []struct {
+		name     string
+		headers  map[string]string
+		want     rateLimitQuota
+		reported bool
+	}{
+		{"none", nil, rateLimitQuota{}, false},
+		{"limit only", map[string]string{"X-RateLimit-Limit": "10"}, rateLimitQuota{}, false},
+		{"draft headers", map[string]string{"RateLimit-Limit": "10", "RateLimit-Remaining": "3", "RateLimit-Reset": "5"}, rateLimitQuota{10, 3, 5}, true},
+		{"unix reset", map[string]string{"X-RateLimit-Limit": "10", "X-RateLimit-Remaining": "0", "X-RateLimit-Reset": "1700000042"}, rateLimitQuota{10, 0, 42}, true},
+		{"past reset", map[string]string{"X-RateLimit-Limit": "10", "X-RateLimit-Remaining": "10", "X-RateLimit-Reset": "1600000000"}, rateLimitQuota{10, 10, 0}, true},
+		{"invalid", map[string]string{"X-RateLimit-Limit": "ten", "X-RateLimit-Remaining": "3"}, rateLimitQuota{}, false},
+	}
+	for _, c := range cases {
+		h := http.Header{}
+		for k, v := range c.headers {
+			h.Set(k, v)
+		}
+		got, reported := parseRateLimitHeaders(h, now)
+		if got != c.want || reported != c.reported {
+			t.Errorf("%s: got %+v, %v, want %+v, %v", c.name, got, reported, c.want, c.reported)
+		}
+	}
+}
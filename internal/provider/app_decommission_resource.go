@@ -0,0 +1,236 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// The API has no archived state for applications, and access to an
+// application follows its asset group. appscan_app_decommission therefore
+// "archives" an application by moving it to a restricted asset group, which
+// revokes the access granted through its former group.
+
+func resourceAppScanAppDecommission() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"application_id": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			Description:  "The ID of the application to decommission.",
+			ValidateFunc: validateGUID,
+		},
+		"report_directory": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "If provided, a final security report of the application is exported to this directory first. The other steps only run once the report is saved.",
+		},
+		"report_file_type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Default:      "Pdf",
+			Description:  "The format of the final report. Allowed values: Pdf, Html, Xml, Csv, Sarif.",
+			ValidateFunc: validation.StringInSlice([]string{"Pdf", "Html", "Xml", "Csv", "Sarif"}, false),
+		},
+		"archive_asset_group_id": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Description:  "If provided, the application is moved to this asset group, revoking the access users had through its current asset group. Use a group only administrators can access.",
+			ValidateFunc: validation.Any(validation.StringIsEmpty, validateGUID),
+		},
+		"delete_application": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			ForceNew:    true,
+			Default:     false,
+			Description: "If true, the application is deleted once the other steps succeeded. Remove the corresponding appscan_application resource from the configuration in the same change.",
+		},
+		"report_file": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The path of the exported final report.",
+		},
+		"decommissioned_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The date the decommissioning completed (RFC 3339).",
+		},
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the decommissioned application.",
+		},
+	}
+	for k, v := range waitSchema() {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		CreateWithoutTimeout: withContext(resourceAppScanAppDecommissionCreate),
+		ReadWithoutTimeout:   withContext(resourceAppScanAppDecommissionRead),
+		UpdateWithoutTimeout: withContext(resourceAppScanAppDecommissionUpdate),
+		Delete:               resourceAppScanAppDecommissionDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceAppScanAppDecommissionImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: s,
+	}
+}
+
+func resourceAppScanAppDecommissionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	appID := d.Get("application_id").(string)
+
+	app, err := getApplication(ctx, client, appID)
+	if err != nil {
+		return err
+	}
+	if app == nil {
+		return fmt.Errorf("application %s not found", appID)
+	}
+
+	// 1. Export the final report.
+	if dir, ok := d.GetOk("report_directory"); ok {
+		fileType := d.Get("report_file_type").(string)
+		if err := os.MkdirAll(dir.(string), 0o755); err != nil {
+			return err
+		}
+		report, err := generateReport(ctx, client, "Application", appID, "", reportConfiguration(fileType), client.waitSettingsFor(d, reportPollInterval))
+		if report != nil {
+			// The report is saved to report_directory: it is not left in
+			// the tenant.
+			defer func() {
+				if err := deleteReport(ctx, client, report.Id); err != nil {
+					log.Printf("[WARN] unable to delete the final report %s of application %s: %s", report.Id, appID, err)
+				}
+			}()
+		}
+		if err != nil {
+			return err
+		}
+		path := filepath.Join(dir.(string), fmt.Sprintf("final-report-%s.%s", appID, strings.ToLower(fileType)))
+		if err := downloadReport(ctx, client, report.Id, path); err != nil {
+			return err
+		}
+		d.Set("report_file", path)
+	}
+
+	// 2. Archive the application and revoke access to it.
+	if group, ok := d.GetOk("archive_asset_group_id"); ok {
+		if err := moveApplication(ctx, client, appID, app.Name, group.(string)); err != nil {
+			return err
+		}
+	}
+
+	// 3. Delete the application.
+	if d.Get("delete_application").(bool) {
+		if err := deleteApplication(ctx, client, appID); err != nil {
+			return err
+		}
+	}
+
+	d.SetId(appID)
+	d.Set("decommissioned_at", time.Now().UTC().Format(time.RFC3339))
+	client.Summary.record("application_decommissioned", "appscan_app_decommission", appID, map[string]string{
+		"report_file":            d.Get("report_file").(string),
+		"archive_asset_group_id": d.Get("archive_asset_group_id").(string),
+		"deleted":                fmt.Sprint(d.Get("delete_application").(bool)),
+	})
+	return nil
+}
+
+// resourceAppScanAppDecommissionRead forgets the decommissioning when the
+// application was deleted by other means, so that it is not reported as done.
+func resourceAppScanAppDecommissionRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if d.Get("delete_application").(bool) {
+		return nil
+	}
+	app, err := getApplication(ctx, m.(*AppScanClient), d.Id())
+	if err != nil {
+		return err
+	}
+	if app == nil {
+		d.SetId("")
+	}
+	return nil
+}
+
+// resourceAppScanAppDecommissionUpdate only handles poll_interval and
+// max_wait: the decommissioning steps have already run.
+func resourceAppScanAppDecommissionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	return resourceAppScanAppDecommissionRead(ctx, d, m)
+}
+
+// resourceAppScanAppDecommissionDelete only removes the resource from the
+// state: a decommissioning cannot be undone.
+func resourceAppScanAppDecommissionDelete(d *schema.ResourceData, m interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+// resourceAppScanAppDecommissionImport imports the decommissioning of an
+// application, e.g. one done before the resource was adopted. Only
+// application_id can be read back; the other arguments get their defaults.
+func resourceAppScanAppDecommissionImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := validateImportGUID("application_id", d.Id()); err != nil {
+		return nil, err
+	}
+	d.Set("application_id", d.Id())
+	d.Set("report_file_type", "Pdf")
+	d.Set("delete_application", false)
+	return []*schema.ResourceData{d}, nil
+}
+
+// appScanApplicationMove is the ApplicationModel payload moving an
+// application to another asset group. Its other fields are left unchanged.
+type appScanApplicationMove struct {
+	Name         string `json:"Name"`
+	AssetGroupId string `json:"AssetGroupId"`
+}
+
+// moveApplication moves an application to another asset group.
+func moveApplication(ctx context.Context, client *AppScanClient, id, name, assetGroupID string) error {
+	body, err := json.Marshal(appScanApplicationMove{
+		Name:         name,
+		AssetGroupId: assetGroupID,
+	})
+	if err != nil {
+		return err
+	}
+
+	urlStr := fmt.Sprintf("%s/Apps/%s", client.ApiBase, url.PathEscape(id))
+	req, err := client.newRequest(ctx, "PUT", urlStr, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return appscan.NewAPIError("move application to asset group "+assetGroupID, resp)
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccAppDecommissionResource(t *testing.T) {
+	m := newMockServer(t)
+	app := m.add("Apps", mockEntity{"Name": "legacy", "AssetGroupId": mockAssetGroupID})
+	archiveGroup := "22222222-2222-2222-2222-222222222222"
+	dir := t.TempDir()
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_app_decommission" "test" {
+  application_id         = %q
+  report_directory       = %q
+  archive_asset_group_id = %q
+}
+`, app["Id"], dir, archiveGroup),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_app_decommission.test", "report_file", filepath.Join(dir, fmt.Sprintf("final-report-%s.pdf", app["Id"]))),
+					resource.TestCheckResourceAttrSet("appscan_app_decommission.test", "decommissioned_at"),
+					func(*terraform.State) error {
+						if _, err := os.Stat(filepath.Join(dir, fmt.Sprintf("final-report-%s.pdf", app["Id"]))); err != nil {
+							return fmt.Errorf("final report was not exported: %w", err)
+						}
+						m.mu.Lock()
+						defer m.mu.Unlock()
+						if app["AssetGroupId"] != archiveGroup {
+							return fmt.Errorf("application is in asset group %v, want %s", app["AssetGroupId"], archiveGroup)
+						}
+						if reports := len(m.collections["Reports"]); reports != 0 {
+							return fmt.Errorf("%d final reports were left in the tenant", reports)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_app_decommission" "test" {
+  application_id     = %q
+  delete_application = true
+}
+`, app["Id"]),
+				Check: func(*terraform.State) error {
+					m.mu.Lock()
+					defer m.mu.Unlock()
+					if m.find("Apps", app["Id"].(string)) != nil {
+						return fmt.Errorf("application was not deleted")
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
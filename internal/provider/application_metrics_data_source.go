@@ -0,0 +1,262 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ----------------------------------------------------------------
+// Data Source: appscan_application_metrics (issue and scan summary of an application, for dashboards)
+// ----------------------------------------------------------------
+
+// The API keeps no history of the issue counts of an application: the
+// series the data source returns is made of the latest execution of each
+// scan of the application, the counts of which are those found by that
+// execution.
+
+// severityCountsSchema returns the schema of a count of issues per severity,
+// described by what, e.g. "open issues".
+func severityCountsSchema(what string) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"total": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: fmt.Sprintf("The total number of %s.", what),
+		},
+	}
+	for _, severity := range []string{"critical", "high", "medium", "low", "informational"} {
+		s[severity] = &schema.Schema{
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: fmt.Sprintf("The number of %s %s.", severity, what),
+		}
+	}
+	return s
+}
+
+// severityCounts returns a count of issues per severity as the attributes of
+// severityCountsSchema.
+func severityCounts(critical, high, medium, low, informational int) map[string]interface{} {
+	return map[string]interface{}{
+		"total":         critical + high + medium + low + informational,
+		"critical":      critical,
+		"high":          high,
+		"medium":        medium,
+		"low":           low,
+		"informational": informational,
+	}
+}
+
+func dataSourceApplicationMetrics() *schema.Resource {
+	scan := map[string]*schema.Schema{
+		"scan_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the scan.",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The name of the scan.",
+		},
+		"technology": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The technology of the scan, e.g. DynamicAnalyzer or StaticAnalyzer.",
+		},
+		"execution_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the latest execution of the scan.",
+		},
+		"status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The status of the execution.",
+		},
+		"created_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The date the execution was requested.",
+		},
+		"scan_end_time": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The date the execution ended, empty until it does.",
+		},
+	}
+	for k, v := range severityCountsSchema("issues found by the execution") {
+		scan[k] = v
+	}
+
+	return &schema.Resource{
+		ReadWithoutTimeout: withContext(dataSourceApplicationMetricsRead),
+		Description: "Summarizes the issues and scans of an application: its open issues per severity, the issues fixed, " +
+			"the date of its last scan and the issues found by the latest execution of each scan, so that outputs feed dashboards and policy checks.",
+		Schema: map[string]*schema.Schema{
+			"application_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The ID of the application.",
+				ValidateFunc: validateGUID,
+			},
+			"open_issues": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The number of open issues of the application, per severity.",
+				Elem:        &schema.Resource{Schema: severityCountsSchema("open issues")},
+			},
+			"issues_in_progress": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of issues of the application being fixed.",
+			},
+			"fixed_issues": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of issues of the application whose status is Fixed.",
+			},
+			"total_issues": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of issues of the application, whatever their status.",
+			},
+			"risk_rating": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The risk rating of the application.",
+			},
+			"max_severity": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The highest severity of the open issues of the application.",
+			},
+			"total_scans": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of scans of the application.",
+			},
+			"last_scan_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the most recent execution of a scan of the application was requested, empty if it was never scanned.",
+			},
+			"scans": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The latest execution of each scan of the application, the oldest first. The API keeps no history of the issue counts: this is the closest series it offers.",
+				Elem:        &schema.Resource{Schema: scan},
+			},
+			"as_of": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the metrics were read (RFC 3339).",
+			},
+		},
+	}
+}
+
+func dataSourceApplicationMetricsRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	appID := d.Get("application_id").(string)
+	now := time.Now().UTC()
+
+	app, err := getApplication(ctx, client, appID)
+	if err != nil {
+		return err
+	}
+	if app == nil {
+		return fmt.Errorf("application %s not found", appID)
+	}
+
+	fixedFilter, err := appscan.ODataEqString("Status", "Fixed")
+	if err != nil {
+		return err
+	}
+	fixed, err := countOData(ctx, client, "Issues/Application/"+appID, fixedFilter)
+	if err != nil {
+		return err
+	}
+
+	filter, err := client.odataEqID(ctx, "AppId", appID)
+	if err != nil {
+		return err
+	}
+	query := url.Values{}
+	query.Set("$filter", filter)
+	query.Set("$orderby", "LatestExecution/CreatedAt")
+	var scans []appScanScan
+	for skip := 0; ; skip += catalogPageSize {
+		query.Set("$top", strconv.Itoa(catalogPageSize))
+		query.Set("$skip", strconv.Itoa(skip))
+
+		var result struct {
+			Items []appScanScan `json:"Items"`
+		}
+		if err := getODataPage(ctx, client, "Scans", query, &result); err != nil {
+			return err
+		}
+		scans = append(scans, result.Items...)
+		if len(result.Items) < catalogPageSize {
+			break
+		}
+	}
+
+	var list []interface{}
+	lastScanDate := ""
+	for _, scan := range scans {
+		exec := scan.LatestExecution
+		if exec == nil {
+			continue
+		}
+		item := severityCounts(exec.NCriticalIssues, exec.NHighIssues, exec.NMediumIssues, exec.NLowIssues, exec.NInfoIssues)
+		item["scan_id"] = scan.Id
+		item["name"] = scan.Name
+		item["technology"] = scan.Technology
+		item["execution_id"] = exec.Id
+		item["status"] = exec.Status
+		item["created_at"] = exec.CreatedAt
+		item["scan_end_time"] = exec.ScanEndTime
+		list = append(list, item)
+		if laterDate(exec.CreatedAt, lastScanDate) {
+			lastScanDate = exec.CreatedAt
+		}
+	}
+
+	if err := d.Set("open_issues", []interface{}{
+		severityCounts(app.CriticalIssues, app.HighIssues, app.MediumIssues, app.LowIssues, app.InformationalIssues),
+	}); err != nil {
+		return err
+	}
+	d.Set("issues_in_progress", app.IssuesInProgress)
+	d.Set("fixed_issues", fixed)
+	d.Set("total_issues", app.TotalIssues)
+	d.Set("risk_rating", app.RiskRating)
+	d.Set("max_severity", app.MaxSeverity)
+	d.Set("total_scans", len(scans))
+	d.Set("last_scan_date", lastScanDate)
+	if err := d.Set("scans", list); err != nil {
+		return err
+	}
+	d.Set("as_of", now.Format(time.RFC3339))
+
+	d.SetId(appID)
+	return nil
+}
+
+// laterDate tells whether the RFC 3339 date a is later than b. Dates that do
+// not parse are never later, and any date is later than "".
+func laterDate(a, b string) bool {
+	ta, err := time.Parse(time.RFC3339, a)
+	if err != nil {
+		return false
+	}
+	tb, err := time.Parse(time.RFC3339, b)
+	return err != nil || ta.After(tb)
+}
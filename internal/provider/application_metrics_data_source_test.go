@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccApplicationMetricsDataSource(t *testing.T) {
+	m := newMockServer(t)
+	app := m.add("Apps", mockEntity{"Name": "payments", "RiskRating": "High", "MaxSeverity": "Critical",
+		"CriticalIssues": 1, "HighIssues": 2, "LowIssues": 4, "IssuesInProgress": 1, "TotalIssues": 10})
+	appID := app["Id"].(string)
+	m.add("Issues", mockEntity{"ApplicationId": appID, "Severity": "High", "Status": "Fixed"})
+	m.add("Issues", mockEntity{"ApplicationId": appID, "Severity": "Low", "Status": "Fixed"})
+	m.add("Issues", mockEntity{"ApplicationId": appID, "Severity": "High", "Status": "Open"})
+	m.add("Issues", mockEntity{"ApplicationId": mockApplicationID, "Severity": "High", "Status": "Fixed"})
+	nightly := m.add("Scans", mockEntity{"Name": "nightly", "AppId": appID, "Technology": "DynamicAnalyzer",
+		"LatestExecution": mockEntity{"Id": "99999999-9999-9999-9999-999999999991", "Status": "Ready", "CreatedAt": "2026-03-02T00:00:00Z",
+			"NCriticalIssues": 1, "NHighIssues": 2, "NInfoIssues": 3}})
+	m.add("Scans", mockEntity{"Name": "weekly", "AppId": appID, "Technology": "StaticAnalyzer",
+		"LatestExecution": mockEntity{"Status": "Ready", "CreatedAt": "2026-02-01T00:00:00Z", "NLowIssues": 4}})
+	m.add("Scans", mockEntity{"Name": "other", "AppId": mockApplicationID, "LatestExecution": mockEntity{"Status": "Ready", "CreatedAt": "2026-04-01T00:00:00Z"}})
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApplicationMetricsConfig(m, appID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_application_metrics.test", "open_issues.0.total", "7"),
+					resource.TestCheckResourceAttr("data.appscan_application_metrics.test", "open_issues.0.critical", "1"),
+					resource.TestCheckResourceAttr("data.appscan_application_metrics.test", "open_issues.0.low", "4"),
+					resource.TestCheckResourceAttr("data.appscan_application_metrics.test", "issues_in_progress", "1"),
+					resource.TestCheckResourceAttr("data.appscan_application_metrics.test", "fixed_issues", "2"),
+					resource.TestCheckResourceAttr("data.appscan_application_metrics.test", "total_issues", "10"),
+					resource.TestCheckResourceAttr("data.appscan_application_metrics.test", "risk_rating", "High"),
+					resource.TestCheckResourceAttr("data.appscan_application_metrics.test", "total_scans", "2"),
+					resource.TestCheckResourceAttr("data.appscan_application_metrics.test", "last_scan_date", "2026-03-02T00:00:00Z"),
+					// The oldest first.
+					resource.TestCheckResourceAttr("data.appscan_application_metrics.test", "scans.#", "2"),
+					resource.TestCheckResourceAttr("data.appscan_application_metrics.test", "scans.0.name", "weekly"),
+					resource.TestCheckResourceAttr("data.appscan_application_metrics.test", "scans.0.total", "4"),
+					resource.TestCheckResourceAttr("data.appscan_application_metrics.test", "scans.1.scan_id", nightly["Id"].(string)),
+					resource.TestCheckResourceAttr("data.appscan_application_metrics.test", "scans.1.execution_id", "99999999-9999-9999-9999-999999999991"),
+					resource.TestCheckResourceAttr("data.appscan_application_metrics.test", "scans.1.total", "6"),
+					resource.TestCheckResourceAttr("data.appscan_application_metrics.test", "scans.1.informational", "3"),
+				),
+			},
+			{
+				Config:      testAccApplicationMetricsConfig(m, "44444444-4444-4444-4444-444444444445"),
+				ExpectError: regexp.MustCompile("application 44444444-4444-4444-4444-444444444445 not found"),
+			},
+		},
+	})
+}
+
+func testAccApplicationMetricsConfig(m *mockServer, appID string) string {
+	return testAccProviderConfig(m) + fmt.Sprintf(`
+data "appscan_application_metrics" "test" {
+  application_id = %q
+}
+`, appID)
+}
+
+func TestLaterDate(t *testing.T) {
+	for _, tc := range []struct {
+		a, b string
+		want bool
+	}{
+		{"2026-03-02T00:00:00Z", "", true},
+		{"2026-03-02T00:00:00Z", "2026-03-01T23:00:00-02:00", false},
+		{"2026-03-02T00:00:00Z", "2026-03-01T23:00:00Z", true},
+		{"", "2026-03-01T23:00:00Z", false},
+		{"", "", false},
+	} {
+		if got := laterDate(tc.a, tc.b); got != tc.want {
+			t.Errorf("laterDate(%q, %q) = %t, want %t", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
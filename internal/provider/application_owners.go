@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// The contacts of an application (BusinessOwner, Tester and
+// DevelopmentContact) are free text for the API. appscan_application sets
+// them from user IDs, writing the email address of the user, and reads them
+// back by looking the user up by that address: a contact edited in the
+// console to someone who is not a user of the organization reads as no user.
+
+// applicationOwners maps the owner attributes of appscan_application to the
+// contact fields of the ApplicationModel.
+var applicationOwners = []struct{ attr, field, role string }{
+	{"business_owner_id", "BusinessOwner", "business owner"},
+	{"security_owner_id", "Tester", "security owner (the Tester contact)"},
+	{"development_contact_id", "DevelopmentContact", "development contact"},
+}
+
+// applicationOwnerSchema returns the owner attributes of appscan_application.
+func applicationOwnerSchema() map[string]*schema.Schema {
+	s := map[string]*schema.Schema{}
+	for _, o := range applicationOwners {
+		s[o.attr] = &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  fmt.Sprintf("The ID of the user who is the %s of the application. Their email address is set as the %s contact of the application; the user must exist at plan time. Omitting it clears the contact.", o.role, o.field),
+			ValidateFunc: validation.Any(validation.StringIsEmpty, validateGUID),
+		}
+	}
+	return s
+}
+
+// contact returns the value the contact fields of an application hold for
+// the user.
+func (u *appScanUser) contact() string {
+	if u.Email != "" {
+		return u.Email
+	}
+	return u.UserName
+}
+
+// setApplicationContacts sets the contacts of payload from the owner
+// attributes of d that changed, removed ones as "". The others are left out
+// of the payload, which leaves them unchanged.
+func setApplicationContacts(ctx context.Context, client *AppScanClient, d *schema.ResourceData, payload *appScanApplicationRequest) error {
+	fields := map[string]**string{
+		"BusinessOwner":      &payload.BusinessOwner,
+		"Tester":             &payload.Tester,
+		"DevelopmentContact": &payload.DevelopmentContact,
+	}
+	for _, o := range applicationOwners {
+		if !d.HasChange(o.attr) {
+			continue
+		}
+		id := d.Get(o.attr).(string)
+		contact := ""
+		if id != "" {
+			user, err := getUser(ctx, client, id)
+			if err != nil {
+				return err
+			}
+			if user == nil {
+				return fmt.Errorf("%s %q does not match any user", o.attr, id)
+			}
+			contact = user.contact()
+		}
+		*fields[o.field] = &contact
+	}
+	return nil
+}
+
+// readApplicationOwners sets the owner attributes from the contacts of app.
+// The user in the state is kept while their address matches, so that a
+// lookup is only needed when the contact changed.
+func readApplicationOwners(ctx context.Context, client *AppScanClient, d *schema.ResourceData, app *appScanApplication) error {
+	contacts := map[string]string{
+		"BusinessOwner":      app.BusinessOwner,
+		"Tester":             app.Tester,
+		"DevelopmentContact": app.DevelopmentContact,
+	}
+	for _, o := range applicationOwners {
+		contact := strings.TrimSpace(contacts[o.field])
+		id := ""
+		if contact != "" {
+			current := d.Get(o.attr).(string)
+			user, err := findUserByContact(ctx, client, current, contact)
+			if err != nil {
+				return err
+			}
+			if user != nil {
+				id = user.Id
+			}
+		}
+		d.Set(o.attr, id)
+	}
+	return nil
+}
+
+// findUserByContact returns the user whose email address or user name is
+// contact, preferring the user with ID current, or nil if there is none.
+func findUserByContact(ctx context.Context, client *AppScanClient, current, contact string) (*appScanUser, error) {
+	if current != "" {
+		user, err := getUser(ctx, client, current)
+		if err != nil {
+			return nil, err
+		}
+		if user != nil && (strings.EqualFold(user.Email, contact) || strings.EqualFold(user.UserName, contact)) {
+			return user, nil
+		}
+	}
+	var exprs []string
+	for _, field := range []string{"Email", "UserName"} {
+		expr, err := appscan.ODataEqString(field, contact)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, expr)
+	}
+	query := url.Values{}
+	query.Set("$filter", appscan.ODataOr(exprs))
+	query.Set("$top", "1")
+
+	var result struct {
+		Items []appScanUser `json:"Items"`
+	}
+	if err := getODataPage(ctx, client, "User", query, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Items) == 0 {
+		return nil, nil
+	}
+	return &result.Items[0], nil
+}
+
+// customizeDiffValidateOwners checks at plan time that the users set as
+// owners exist, when the values are known and changing.
+func customizeDiffValidateOwners(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	client, ok := m.(*AppScanClient)
+	if !ok || client == nil {
+		return nil
+	}
+	for _, o := range applicationOwners {
+		if !d.NewValueKnown(o.attr) || !d.HasChange(o.attr) {
+			continue
+		}
+		id, _ := d.Get(o.attr).(string)
+		if id == "" {
+			continue
+		}
+		user, err := getUser(ctx, client, id)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return fmt.Errorf("%s %q does not match any user", o.attr, id)
+		}
+	}
+	return nil
+}
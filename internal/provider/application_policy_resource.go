@@ -0,0 +1,259 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ----------------------------------------------------------------
+// Resource: appscan_application_policy (policy of an application, with its parameters)
+// ----------------------------------------------------------------
+
+// Remediation rules, such as the number of days allowed to fix the issues of
+// each severity, are policies associated with an application, configured by
+// the name/value parameters of the association. The API associates policies
+// with applications only, not with asset groups.
+
+func resourceAppScanApplicationPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateWithoutTimeout: withContext(resourceAppScanApplicationPolicyCreate),
+		ReadWithoutTimeout:   withContext(resourceAppScanApplicationPolicyRead),
+		UpdateWithoutTimeout: withContext(resourceAppScanApplicationPolicyUpdate),
+		DeleteWithoutTimeout: withContext(resourceAppScanApplicationPolicyDelete),
+		Importer: &schema.ResourceImporter{
+			State: resourceAppScanApplicationPolicyImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"application_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The ID of the application.",
+				ValidateFunc: validateGUID,
+			},
+			"policy_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The ID of the predefined or custom policy associated with the application.",
+				ValidateFunc: validateGUID,
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the policy is evaluated for the application. Defaults to true.",
+			},
+			"parameters": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Computed:    true,
+				Description: "The parameters of the policy for the application, keyed by name, e.g. the days allowed to fix the issues of each severity. If omitted, the parameters set by AppScan are kept.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the policy.",
+			},
+			"category": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The category of the policy: Custom, Security, Regulation or IndustryStandard.",
+			},
+		},
+	}
+}
+
+// appScanPolicyAssociation holds the PolicyAssociationModel fields the
+// provider relies on.
+type appScanPolicyAssociation struct {
+	Id         string             `json:"Id"`
+	Name       string             `json:"Name"`
+	Category   string             `json:"Category"`
+	Enabled    bool               `json:"Enabled"`
+	Parameters []appScanNameValue `json:"Parameters"`
+}
+
+// appScanPolicyConfiguration is the PolicyConfigurationModel payload
+// updating an association.
+type appScanPolicyConfiguration struct {
+	Enabled    bool               `json:"Enabled"`
+	Parameters []appScanNameValue `json:"Parameters"`
+}
+
+// appScanNameValue is a NameValuePair.
+type appScanNameValue struct {
+	Name  string `json:"Name"`
+	Value string `json:"Value"`
+}
+
+func resourceAppScanApplicationPolicyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	appID, policyID := d.Get("application_id").(string), d.Get("policy_id").(string)
+
+	if err := sendApplicationPolicy(ctx, client, "POST", appID, policyID, policyParameters(d)); err != nil {
+		return err
+	}
+	d.SetId(appID + ":" + policyID)
+	client.Summary.record("application_policy_associated", "appscan_application_policy", d.Id(), nil)
+
+	// Associated policies are enabled.
+	if !d.Get("enabled").(bool) {
+		payload := appScanPolicyConfiguration{Enabled: false, Parameters: policyParameters(d)}
+		if err := sendApplicationPolicy(ctx, client, "PUT", appID, policyID, payload); err != nil {
+			return err
+		}
+	}
+	return resourceAppScanApplicationPolicyRead(ctx, d, m)
+}
+
+func resourceAppScanApplicationPolicyRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	association, err := getApplicationPolicy(ctx, client, d.Get("application_id").(string), d.Get("policy_id").(string))
+	if err != nil {
+		return err
+	}
+	if association == nil {
+		d.SetId("")
+		return nil
+	}
+	parameters := make(map[string]interface{}, len(association.Parameters))
+	for _, p := range association.Parameters {
+		parameters[p.Name] = p.Value
+	}
+	d.Set("enabled", association.Enabled)
+	d.Set("parameters", parameters)
+	d.Set("name", association.Name)
+	d.Set("category", association.Category)
+	return nil
+}
+
+func resourceAppScanApplicationPolicyUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	payload := appScanPolicyConfiguration{
+		Enabled:    d.Get("enabled").(bool),
+		Parameters: policyParameters(d),
+	}
+	if err := sendApplicationPolicy(ctx, client, "PUT", d.Get("application_id").(string), d.Get("policy_id").(string), payload); err != nil {
+		return err
+	}
+	client.Summary.record("application_policy_updated", "appscan_application_policy", d.Id(), map[string]string{
+		"enabled": fmt.Sprint(d.Get("enabled").(bool)),
+	})
+	return resourceAppScanApplicationPolicyRead(ctx, d, m)
+}
+
+func resourceAppScanApplicationPolicyDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	urlStr := fmt.Sprintf("%s/Apps/%s/Policy/%s", client.ApiBase, url.PathEscape(d.Get("application_id").(string)), url.PathEscape(d.Get("policy_id").(string)))
+	req, err := client.newRequest(ctx, "DELETE", urlStr, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return appscan.NewAPIError("disassociate policy", resp)
+	}
+	client.Summary.record("application_policy_disassociated", "appscan_application_policy", d.Id(), nil)
+	d.SetId("")
+	return nil
+}
+
+// resourceAppScanApplicationPolicyImport imports an association by
+// application_id:policy_id.
+func resourceAppScanApplicationPolicyImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts, err := parseImportID(d.Id(), "application_id:policy_id", 2)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateImportGUID("application_id", parts[0]); err != nil {
+		return nil, err
+	}
+	if err := validateImportGUID("policy_id", parts[1]); err != nil {
+		return nil, err
+	}
+	d.Set("application_id", parts[0])
+	d.Set("policy_id", parts[1])
+	return []*schema.ResourceData{d}, nil
+}
+
+// policyParameters returns the configured parameters as NameValuePairs,
+// sorted by name.
+func policyParameters(d *schema.ResourceData) []appScanNameValue {
+	parameters := []appScanNameValue{}
+	for k, v := range d.Get("parameters").(map[string]interface{}) {
+		parameters = append(parameters, appScanNameValue{Name: k, Value: v.(string)})
+	}
+	sort.Slice(parameters, func(i, j int) bool { return parameters[i].Name < parameters[j].Name })
+	return parameters
+}
+
+// sendApplicationPolicy associates a policy with an application (POST, whose
+// body is the parameters) or updates the association (PUT, whose body is a
+// PolicyConfigurationModel).
+func sendApplicationPolicy(ctx context.Context, client *AppScanClient, method, appID, policyID string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	urlStr := fmt.Sprintf("%s/Apps/%s/Policy/%s", client.ApiBase, url.PathEscape(appID), url.PathEscape(policyID))
+	req, err := client.newRequest(ctx, method, urlStr, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return appscan.NewAPIError("associate policy", resp)
+	}
+	return nil
+}
+
+// getApplicationPolicy returns the association of a policy with an
+// application, or nil when the policy is not associated with it or the
+// application does not exist.
+func getApplicationPolicy(ctx context.Context, client *AppScanClient, appID, policyID string) (*appScanPolicyAssociation, error) {
+	var result struct {
+		Items []appScanPolicyAssociation `json:"Items"`
+	}
+	err := getODataPage(ctx, client, fmt.Sprintf("Apps/%s/Policy", url.PathEscape(appID)), url.Values{}, &result)
+	var apiErr *appscan.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for i, a := range result.Items {
+		if strings.EqualFold(a.Id, policyID) {
+			return &result.Items[i], nil
+		}
+	}
+	return nil, nil
+}
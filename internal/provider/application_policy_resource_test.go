@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const mockPolicyID = "88888888-8888-8888-8888-888888888888"
+
+func TestAccApplicationPolicyResource(t *testing.T) {
+	m := newMockServer(t)
+	app := m.add("Apps", mockEntity{"Id": mockApplicationID, "Name": "payments", "AssetGroupId": mockAssetGroupID})
+	m.add("Policies", mockEntity{"Id": mockPolicyID, "Name": "Remediation SLA", "Category": "Custom"})
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApplicationPolicyConfig(m, true, 7),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_application_policy.test", "id", app["Id"].(string)+":"+mockPolicyID),
+					resource.TestCheckResourceAttr("appscan_application_policy.test", "name", "Remediation SLA"),
+					resource.TestCheckResourceAttr("appscan_application_policy.test", "category", "Custom"),
+					resource.TestCheckResourceAttr("appscan_application_policy.test", "enabled", "true"),
+					resource.TestCheckResourceAttr("appscan_application_policy.test", "parameters.High", "7"),
+				),
+			},
+			{
+				Config: testAccApplicationPolicyConfig(m, false, 14),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_application_policy.test", "enabled", "false"),
+					resource.TestCheckResourceAttr("appscan_application_policy.test", "parameters.High", "14"),
+				),
+			},
+			{
+				ResourceName:      "appscan_application_policy.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "appscan_application_policy.test",
+				ImportState:   true,
+				ImportStateId: mockPolicyID,
+				ExpectError:   regexp.MustCompile(`expected application_id:policy_id`),
+			},
+			{
+				// A policy disassociated outside Terraform is associated again.
+				PreConfig: func() {
+					m.mu.Lock()
+					defer m.mu.Unlock()
+					m.collections["PolicyAssociations"] = nil
+				},
+				Config:             testAccApplicationPolicyConfig(m, false, 14),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccApplicationPolicyConfig(m *mockServer, enabled bool, days int) string {
+	return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_application_policy" "test" {
+  application_id = %q
+  policy_id      = %q
+  enabled        = %t
+  parameters = {
+    Critical = "3"
+    High     = "%d"
+  }
+}
+`, mockApplicationID, mockPolicyID, enabled, days)
+}
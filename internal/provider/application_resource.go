@@ -2,53 +2,156 @@ package provider
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io/ioutil"
+	"io"
+	"log"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceAppScanApplication() *schema.Resource {
-	return &schema.Resource{
-		Create: resourceAppScanApplicationCreate,
-		Read:   resourceAppScanApplicationRead,
-		Update: resourceAppScanApplicationUpdate,
-		Delete: resourceAppScanApplicationDelete,
+	r := &schema.Resource{
+		CreateWithoutTimeout: withContext(resourceAppScanApplicationCreate),
+		ReadContext:          resourceAppScanApplicationRead,
+		UpdateWithoutTimeout: withContext(resourceAppScanApplicationUpdate),
+		DeleteWithoutTimeout: withContext(resourceAppScanApplicationDelete),
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importApplication,
 		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffProviderDefault("asset_group_id", "default_asset_group_id", true, func(c *AppScanClient) string { return c.DefaultAssetGroupId }),
+			customizeDiffProviderDefault("business_unit_id", "default_business_unit_id", false, func(c *AppScanClient) string { return c.DefaultBusinessUnitId }),
+			customizeDiffValidateReferences,
+			customizeDiffValidateOwners,
+			customizeDiffNameExists,
+		),
+		SchemaVersion:  1,
+		StateUpgraders: applicationStateUpgraders(),
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The name of the application.",
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The name of the application. Leading and trailing whitespace, which the API trims, is ignored.",
+				DiffSuppressFunc: suppressSurroundingSpace,
 			},
 			"description": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "A description of the application.",
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "A description of the application. Leading and trailing whitespace, which the API trims, is ignored.",
+				DiffSuppressFunc: suppressSurroundingSpace,
 			},
 			"asset_group_id": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "The asset group ID to which this application belongs.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The asset group ID to which this application belongs. Required unless the provider sets default_asset_group_id, which applies when it is omitted. Changing it moves the application to the new asset group, keeping its scans and issues. A move the API rejects fails the apply rather than recreating the application: replace the resource to recreate it in the new asset group.",
+				ValidateFunc: validateGUID,
 			},
 			"business_unit_id": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "The Business Unit ID associated with this application.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The Business Unit ID associated with this application. Defaults to the provider's default_business_unit_id; when neither is set, the business unit assigned by AppScan is kept.",
+				ValidateFunc: validation.Any(validation.StringIsEmpty, validateGUID),
 			},
 			"business_impact": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "Unspecified",
+				Description:      "The business impact of the application: Unspecified, Low, Medium, High or Critical, in any case. Other values are sent as is, with a warning.",
+				ValidateDiagFunc: validateEnum(businessImpacts),
+				DiffSuppressFunc: suppressEqualFold,
+			},
+			"attributes": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Values of custom attributes, keyed by attribute name. Each attribute must be defined, e.g. with appscan_attribute_definition. Attributes not listed are left alone; removing one empties its value.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"deletion_protection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If true, destroying the application, or replacing it, fails: set it to false and apply before destroying. Deleting an application deletes its scans and issues. Defaults to false.",
+			},
+			"fail_if_name_exists": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "If true, creating the application fails when one of the same name already exists in its asset group, e.g. because the state was lost, rather than creating a duplicate: the error gives the ID of the application to import. It is checked at plan time, and again before the creation. Defaults to false.",
+			},
+			"delete_issues_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "If false, destroying the application fails while it has issues, since the API deletes them along with it. Defaults to true.",
+			},
+			"archive_asset_group_id": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				Default:      "Unspecified",
-				Description:  "The business impact of the application. Allowed values: Unspecified, Low, Medium, High, Critical.",
-				ValidateFunc: validation.StringInSlice([]string{"Unspecified", "Low", "Medium", "High", "Critical"}, false),
+				Description:  "If set, destroying the application moves it to this asset group, e.g. a restricted archive group, instead of deleting it: its scans and issues are kept, and the access granted through its current group is revoked, as with appscan_app_decommission. The API has no other way to archive an application.",
+				ValidateFunc: validation.Any(validation.StringIsEmpty, validateGUID),
+			},
+			"risk_rating": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The risk rating of the application, computed by AppScan from its open issues: Unknown, Low, Medium, High or Critical.",
+			},
+			"max_severity": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The highest severity of the open issues of the application: Undetermined, Informational, Low, Medium, High or Critical.",
+			},
+			"critical_issues": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of open critical issues of the application.",
+			},
+			"high_issues": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of open high severity issues of the application.",
+			},
+			"medium_issues": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of open medium severity issues of the application.",
+			},
+			"low_issues": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of open low severity issues of the application.",
+			},
+			"informational_issues": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of open informational issues of the application.",
+			},
+			"open_issues": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of open issues of the application.",
+			},
+			"issues_in_progress": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of issues of the application being fixed.",
+			},
+			"total_issues": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of issues of the application, whatever their status.",
 			},
 			"id": {
 				Type:        schema.TypeString,
@@ -57,149 +160,512 @@ func resourceAppScanApplication() *schema.Resource {
 			},
 		},
 	}
+	for k, v := range applicationOwnerSchema() {
+		r.Schema[k] = v
+	}
+	return r
 }
 
-func resourceAppScanApplicationCreate(d *schema.ResourceData, m interface{}) error {
+func resourceAppScanApplicationCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	client := m.(*AppScanClient)
 	assetGroupID := d.Get("asset_group_id").(string)
-	payload := map[string]interface{}{
-		"Name":         d.Get("name").(string),
-		"Description":  d.Get("description").(string),
-		"AssetGroupId": assetGroupID,
+	payload := appScanApplicationRequest{
+		Name:           d.Get("name").(string),
+		Description:    d.Get("description").(string),
+		AssetGroupId:   assetGroupID,
+		BusinessUnitId: d.Get("business_unit_id").(string),
+		// Always included, "Unspecified" unless set.
+		BusinessImpact: d.Get("business_impact").(string),
+	}
+	if err := setApplicationContacts(ctx, client, d, &payload); err != nil {
+		return err
 	}
-	// Include BusinessUnitId if provided.
-	if bu, ok := d.GetOk("business_unit_id"); ok {
-		payload["BusinessUnitId"] = bu.(string)
+	if d.Get("fail_if_name_exists").(bool) {
+		if err := checkApplicationNameFree(ctx, client, payload.Name, assetGroupID); err != nil {
+			return err
+		}
 	}
-	// Always include BusinessImpact (defaulted to "Unspecified" if not set)
-	payload["BusinessImpact"] = d.Get("business_impact").(string)
 
-	body, err := json.Marshal(payload)
+	id, err := createApplication(ctx, client, payload)
 	if err != nil {
 		return err
 	}
-	url := fmt.Sprintf("%s/api/v4/Apps", client.ApiEndpoint)
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(body))
-	if err != nil {
+	d.SetId(id)
+	if err := client.awaitListed(ctx, "application "+id, func() (bool, error) {
+		app, err := getApplication(ctx, client, id)
+		return app != nil, err
+	}); err != nil {
 		return err
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", client.ApiToken))
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := client.Client.Do(req)
-	if err != nil {
+	client.Summary.record("application_created", "appscan_application", id, map[string]string{
+		"name":           d.Get("name").(string),
+		"asset_group_id": assetGroupID,
+	})
+	if err := updateApplicationAttributes(ctx, client, d, nil); err != nil {
 		return err
 	}
-	defer resp.Body.Close()
+	if err := setAutoTags(ctx, client, d); err != nil {
+		return err
+	}
+	return refreshApplication(ctx, d, m)
+}
 
-	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to create application, status: %s", resp.Status)
+func resourceAppScanApplicationRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	diags, err := readApplication(ctx, d, m)
+	if err != nil {
+		return append(diags, diag.FromErr(err)...)
 	}
+	return diags
+}
 
-	respBody, err := ioutil.ReadAll(resp.Body)
+// refreshApplication reads the application back after a create or update,
+// logging the warnings about its payload.
+func refreshApplication(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	diags, err := readApplication(ctx, d, m)
 	if err != nil {
 		return err
 	}
-	var result map[string]interface{}
-	if err := json.Unmarshal(respBody, &result); err != nil {
-		return err
+	return diagnosticsError(diags)
+}
+
+// readApplication sets the attributes from the API, null fields included so
+// drift is not masked, and returns warnings about the payload.
+func readApplication(ctx context.Context, d *schema.ResourceData, m interface{}) (diag.Diagnostics, error) {
+	client := m.(*AppScanClient)
+
+	app, err := getApplication(ctx, client, d.Id())
+	if err != nil {
+		return nil, err
+	}
+	if app == nil {
+		d.SetId("")
+		return nil, nil
 	}
+	d.Set("name", app.Name)
+	d.Set("description", app.Description)
+	d.Set("asset_group_id", app.AssetGroupId)
+	d.Set("business_unit_id", app.BusinessUnitId)
+	d.Set("business_impact", app.BusinessImpact)
 
-	id, ok := result["Id"].(string)
-	if !ok || id == "" {
-		return fmt.Errorf("failed to retrieve application ID from API response")
+	// The risk rating and issue counts are updated by AppScan as scans run;
+	// they are read so that other modules can use them.
+	d.Set("risk_rating", app.RiskRating)
+	d.Set("max_severity", app.MaxSeverity)
+	d.Set("critical_issues", app.CriticalIssues)
+	d.Set("high_issues", app.HighIssues)
+	d.Set("medium_issues", app.MediumIssues)
+	d.Set("low_issues", app.LowIssues)
+	d.Set("informational_issues", app.InformationalIssues)
+	d.Set("open_issues", app.OpenIssues)
+	d.Set("issues_in_progress", app.IssuesInProgress)
+	d.Set("total_issues", app.TotalIssues)
+	if err := readApplicationOwners(ctx, client, d, app); err != nil {
+		return nil, err
 	}
-	d.SetId(id)
-	return resourceAppScanApplicationRead(d, m)
+
+	// Only the attributes the configuration manages are read back, so that
+	// the values set elsewhere or defaulted do not show as drift.
+	if managed := d.Get("attributes").(map[string]interface{}); len(managed) > 0 {
+		values, err := getApplicationAttributes(ctx, client, d.Id())
+		if err != nil {
+			return nil, err
+		}
+		attributes := make(map[string]interface{}, len(managed))
+		for k := range managed {
+			if v, ok := values[k]; ok {
+				attributes[k] = v
+			}
+		}
+		if err := d.Set("attributes", attributes); err != nil {
+			return nil, err
+		}
+	}
+	what := "application " + d.Id()
+	diags := payloadWarnings(what, app.payload, applicationModelFields, jsonFields(appScanApplication{}))
+	diags = append(diags, client.checkEnum(what, "BusinessImpact", app.BusinessImpact, businessImpacts)...)
+	diags = append(diags, client.checkEnum(what, "RiskRating", app.RiskRating, riskRatings)...)
+	diags = append(diags, client.checkEnum(what, "MaxSeverity", app.MaxSeverity, maxSeverities)...)
+	return diags, nil
 }
 
-func resourceAppScanApplicationRead(d *schema.ResourceData, m interface{}) error {
+func resourceAppScanApplicationUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
 	client := m.(*AppScanClient)
 	id := d.Id()
 
-	query := url.Values{}
-	query.Set("$filter", fmt.Sprintf("Id eq %s", id))
-	urlStr := fmt.Sprintf("%s/api/v4/Apps?%s", client.ApiEndpoint, query.Encode())
+	if d.HasChange("asset_group_id") {
+		if err := moveApplicationGroup(ctx, client, d); err != nil {
+			// The state keeps the asset group the application is still in.
+			d.Partial(true)
+			return err
+		}
+	}
 
-	req, err := http.NewRequest("GET", urlStr, nil)
-	if err != nil {
+	if !d.HasChangesExcept("deletion_protection", "delete_issues_on_destroy", "archive_asset_group_id", "fail_if_name_exists") {
+		// Only the provider-side create or delete behavior changed.
+		return nil
+	}
+
+	payload := appScanApplicationRequest{
+		Name:           d.Get("name").(string),
+		Description:    d.Get("description").(string),
+		BusinessUnitId: d.Get("business_unit_id").(string),
+		BusinessImpact: d.Get("business_impact").(string),
+	}
+	if err := setApplicationContacts(ctx, client, d, &payload); err != nil {
 		return err
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", client.ApiToken))
 
-	resp, err := client.Client.Do(req)
-	if err != nil {
+	if err := updateApplication(ctx, client, id, payload); err != nil {
 		return err
 	}
-	defer resp.Body.Close()
+	client.Summary.record("application_updated", "appscan_application", id, map[string]string{
+		"name": d.Get("name").(string),
+	})
+	if d.HasChange("attributes") {
+		previous, _ := d.GetChange("attributes")
+		if err := updateApplicationAttributes(ctx, client, d, previous.(map[string]interface{})); err != nil {
+			return err
+		}
+	}
+	return refreshApplication(ctx, d, m)
+}
 
-	if resp.StatusCode == http.StatusNotFound {
+// updateApplicationAttributes sets the configured attributes that differ
+// from previous, and empties the ones removed from the configuration. A
+// nil previous sets them all, e.g. for a new application.
+func updateApplicationAttributes(ctx context.Context, client *AppScanClient, d *schema.ResourceData, previous map[string]interface{}) error {
+	attributes := d.Get("attributes").(map[string]interface{})
+	for k, v := range attributes {
+		if p, ok := previous[k]; ok && p == v {
+			continue
+		}
+		if err := setApplicationAttribute(ctx, client, d.Id(), k, v.(string)); err != nil {
+			return err
+		}
+	}
+	for k := range previous {
+		if _, ok := attributes[k]; !ok {
+			if err := setApplicationAttribute(ctx, client, d.Id(), k, ""); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// setAutoTags sets the auto_tags of the provider on a new application,
+// except those its attributes override. They are not read back.
+func setAutoTags(ctx context.Context, client *AppScanClient, d *schema.ResourceData) error {
+	attributes := d.Get("attributes").(map[string]interface{})
+	for k, v := range client.AutoTags {
+		if _, ok := attributes[k]; ok {
+			continue
+		}
+		if err := setApplicationAttribute(ctx, client, d.Id(), k, v); err != nil {
+			return fmt.Errorf("failed to set auto tag: %w", err)
+		}
+	}
+	return nil
+}
+
+// isMoveRejected reports whether a move failed because the API refused to
+// move the application, rather than because of a transient error.
+func isMoveRejected(statusCode int) bool {
+	return statusCode == http.StatusBadRequest || statusCode == http.StatusForbidden || statusCode == http.StatusConflict
+}
+
+// moveApplicationGroup moves the application d to its new asset_group_id.
+// The move is sent on its own, so that its rejections are told apart from
+// those of the other fields. A rejected move fails the update rather than
+// recreating the application, which would lose its scans and issues where
+// the plan showed an update.
+func moveApplicationGroup(ctx context.Context, client *AppScanClient, d *schema.ResourceData) error {
+	group := d.Get("asset_group_id").(string)
+	err := moveApplication(ctx, client, d.Id(), d.Get("name").(string), group)
+	var apiErr *appscan.APIError
+	if errors.As(err, &apiErr) && isMoveRejected(apiErr.StatusCode) {
+		return fmt.Errorf("application %s cannot be moved to asset group %s: %w; "+
+			"to recreate it there, losing its scans and issues, replace the resource, e.g. with terraform apply -replace", d.Id(), group, err)
+	}
+	return err
+}
+
+func resourceAppScanApplicationDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	id := d.Id()
+
+	if err := checkApplicationDeletable(ctx, client, d); err != nil {
+		return err
+	}
+	if group, ok := d.GetOk("archive_asset_group_id"); ok {
+		if err := archiveApplication(ctx, client, id, group.(string)); err != nil {
+			return err
+		}
 		d.SetId("")
 		return nil
 	}
-	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to read application, status: %s", resp.Status)
+	if err := deleteApplication(ctx, client, id); err != nil {
+		return err
 	}
+	client.Summary.record("application_deleted", "appscan_application", id, nil)
+	d.SetId("")
+	return nil
+}
 
-	respBody, err := ioutil.ReadAll(resp.Body)
+// archiveApplication moves an application to the archive asset group rather
+// than deleting it.
+func archiveApplication(ctx context.Context, client *AppScanClient, id, archiveGroupID string) error {
+	app, err := getApplication(ctx, client, id)
 	if err != nil {
 		return err
 	}
+	if app == nil {
+		return nil
+	}
+	if err := moveApplication(ctx, client, id, app.Name, archiveGroupID); err != nil {
+		return err
+	}
+	client.Summary.record("application_archived", "appscan_application", id, map[string]string{
+		"asset_group_id": archiveGroupID,
+	})
+	return nil
+}
 
-	var result struct {
-		Items []map[string]interface{} `json:"Items"`
+// checkApplicationDeletable enforces deletion_protection and
+// delete_issues_on_destroy before the application is destroyed. Archived
+// applications keep their issues.
+func checkApplicationDeletable(ctx context.Context, client *AppScanClient, d *schema.ResourceData) error {
+	if d.Get("deletion_protection").(bool) {
+		return fmt.Errorf("cannot destroy application %s: deletion_protection is set, set it to false and apply first", d.Id())
+	}
+	if d.Get("delete_issues_on_destroy").(bool) || d.Get("archive_asset_group_id").(string) != "" {
+		return nil
 	}
-	if err := json.Unmarshal(respBody, &result); err != nil {
+	app, err := getApplication(ctx, client, d.Id())
+	if err != nil {
 		return err
 	}
-	if len(result.Items) == 0 {
-		d.SetId("")
+	if app == nil {
 		return nil
 	}
-	app := result.Items[0]
-	if v, ok := app["Name"].(string); ok {
-		d.Set("name", v)
+	if app.TotalIssues > 0 {
+		return fmt.Errorf("cannot destroy application %s: it has %d issues and delete_issues_on_destroy is false", d.Id(), app.TotalIssues)
 	}
-	if v, ok := app["Description"].(string); ok {
-		d.Set("description", v)
+	return nil
+}
+
+// importApplication imports an application by its GUID, with the default
+// delete behavior, which is not stored by the API.
+func importApplication(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := validateImportGUID("ID", d.Id()); err != nil {
+		return nil, err
+	}
+	d.Set("deletion_protection", false)
+	d.Set("delete_issues_on_destroy", true)
+	d.Set("fail_if_name_exists", false)
+	return []*schema.ResourceData{d}, nil
+}
+
+// appScanApplicationRequest is the ApplicationModel payload of the create
+// and update requests. The asset group and business unit are omitted when
+// empty, so that an update leaves them unchanged.
+type appScanApplicationRequest struct {
+	Name           string `json:"Name"`
+	Description    string `json:"Description"`
+	AssetGroupId   string `json:"AssetGroupId,omitempty"`
+	BusinessUnitId string `json:"BusinessUnitId,omitempty"`
+	BusinessImpact string `json:"BusinessImpact"`
+
+	// The contacts are left unchanged when nil.
+	BusinessOwner      *string `json:"BusinessOwner,omitempty"`
+	Tester             *string `json:"Tester,omitempty"`
+	DevelopmentContact *string `json:"DevelopmentContact,omitempty"`
+}
+
+// appScanApplication holds the ApplicationModel fields appscan_application
+// reads. Null fields are read as their zero value.
+type appScanApplication struct {
+	Id                  string `json:"Id"`
+	Name                string `json:"Name"`
+	Description         string `json:"Description"`
+	AssetGroupId        string `json:"AssetGroupId"`
+	BusinessUnitId      string `json:"BusinessUnitId"`
+	BusinessImpact      string `json:"BusinessImpact"`
+	RiskRating          string `json:"RiskRating"`
+	MaxSeverity         string `json:"MaxSeverity"`
+	CriticalIssues      int    `json:"CriticalIssues"`
+	HighIssues          int    `json:"HighIssues"`
+	MediumIssues        int    `json:"MediumIssues"`
+	LowIssues           int    `json:"LowIssues"`
+	InformationalIssues int    `json:"InformationalIssues"`
+	OpenIssues          int    `json:"OpenIssues"`
+	IssuesInProgress    int    `json:"IssuesInProgress"`
+	TotalIssues         int    `json:"TotalIssues"`
+	BusinessOwner       string `json:"BusinessOwner"`
+	Tester              string `json:"Tester"`
+	DevelopmentContact  string `json:"DevelopmentContact"`
+
+	// payload is the application as returned, to check its fields against
+	// the model with payloadWarnings.
+	payload map[string]interface{}
+}
+
+// createApplication creates an application and returns its ID.
+func createApplication(ctx context.Context, client *AppScanClient, payload appScanApplicationRequest) (string, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
 	}
-	if v, ok := app["AssetGroupId"].(string); ok {
-		d.Set("asset_group_id", v)
+	url := fmt.Sprintf("%s/Apps", client.ApiBase)
+
+	// Allow for the clock of the API being behind ours.
+	since := time.Now().Add(-createClockSkew)
+	var resp *http.Response
+	for attempt := 0; ; attempt++ {
+		req, err := client.newRequest(ctx, "POST", url, bytes.NewBuffer(body))
+		if err != nil {
+			return "", err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err = client.Client.Do(req)
+		if err == nil {
+			break
+		}
+		if !isTransientNetworkError(err) {
+			return "", err
+		}
+		// The application may have been created although the response was
+		// lost: look for it rather than create a duplicate.
+		id, findErr := findCreatedApplication(ctx, client, payload, since)
+		if findErr != nil {
+			return "", fmt.Errorf("%w (unable to check whether the application was created: %v)", err, findErr)
+		}
+		if id != "" {
+			log.Printf("[WARN] creating application %v failed (%s), but it was created as %s", payload.Name, err, id)
+			return id, nil
+		}
+		if attempt >= client.MaxRetries {
+			return "", err
+		}
+		delay := retryDelay(attempt, nil)
+		log.Printf("[WARN] creating application %v failed (%s), retrying in %s (%d/%d)", payload.Name, err, delay, attempt+1, client.MaxRetries)
+		time.Sleep(delay)
 	}
-	if v, ok := app["BusinessUnitId"].(string); ok {
-		d.Set("business_unit_id", v)
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return "", appscan.NewAPIError("create application", resp)
 	}
-	if v, ok := app["BusinessImpact"].(string); ok {
-		d.Set("business_impact", v)
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
 	}
-	return nil
+	var result struct {
+		Id string `json:"Id"`
+	}
+	if err := appscan.DecodeJSON(resp, respBody, &result); err != nil {
+		return "", err
+	}
+
+	id := result.Id
+	if id == "" {
+		return "", fmt.Errorf("failed to retrieve application ID from API response")
+	}
+	return id, nil
 }
 
-func resourceAppScanApplicationUpdate(d *schema.ResourceData, m interface{}) error {
-	client := m.(*AppScanClient)
-	id := d.Id()
+// createClockSkew is how far behind ours the clock of the API may be when
+// looking for an application created by a request whose response was lost.
+const createClockSkew = 5 * time.Minute
+
+// findCreatedApplication returns the ID of the application created from
+// payload since the given time, or "" if there is none. More than one match
+// is an error: the application cannot be told apart.
+func findCreatedApplication(ctx context.Context, client *AppScanClient, payload appScanApplicationRequest, since time.Time) (string, error) {
+	name := payload.Name
+	filter, err := appscan.ODataEqString("Name", name)
+	if err != nil {
+		return "", err
+	}
+	filters := []string{filter, "DateCreated ge " + since.UTC().Format(time.RFC3339)}
+	if assetGroupID := payload.AssetGroupId; assetGroupID != "" {
+		filter, err := client.odataEqID(ctx, "AssetGroupId", assetGroupID)
+		if err != nil {
+			return "", err
+		}
+		filters = append(filters, filter)
+	}
+	apps, err := listApplications(ctx, client, appscan.ODataAnd(filters...))
+	if err != nil {
+		return "", err
+	}
+	switch len(apps) {
+	case 0:
+		return "", nil
+	case 1:
+		return apps[0].Id, nil
+	default:
+		return "", fmt.Errorf("%d applications named %s were created recently", len(apps), name)
+	}
+}
 
-	// asset_group_id is ForceNew so it is not updated.
-	payload := map[string]interface{}{
-		"Name":        d.Get("name").(string),
-		"Description": d.Get("description").(string),
+// checkApplicationNameFree fails when an application named name exists in
+// the asset group assetGroupID, or in the organization when it is empty.
+func checkApplicationNameFree(ctx context.Context, client *AppScanClient, name, assetGroupID string) error {
+	// The API trims names.
+	name = strings.TrimSpace(name)
+	filter, err := appscan.ODataEqString("Name", name)
+	if err != nil {
+		return err
+	}
+	filters := []string{filter}
+	scope := "the organization"
+	if assetGroupID != "" {
+		filter, err := client.odataEqID(ctx, "AssetGroupId", assetGroupID)
+		if err != nil {
+			return err
+		}
+		filters = append(filters, filter)
+		scope = "asset group " + assetGroupID
+	}
+	apps, err := listApplications(ctx, client, appscan.ODataAnd(filters...))
+	if err != nil {
+		return err
 	}
-	if bu, ok := d.GetOk("business_unit_id"); ok {
-		payload["BusinessUnitId"] = bu.(string)
+	if len(apps) > 0 {
+		return fmt.Errorf("an application named %q already exists in %s (ID %s) and fail_if_name_exists is set: import it, e.g. with terraform import, rather than create a duplicate", name, scope, apps[0].Id)
 	}
-	payload["BusinessImpact"] = d.Get("business_impact").(string)
+	return nil
+}
 
+// customizeDiffNameExists applies fail_if_name_exists at plan time, when the
+// name and asset group of the application to create are known.
+func customizeDiffNameExists(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	client, ok := m.(*AppScanClient)
+	if !ok || client == nil || d.Id() != "" || !d.Get("fail_if_name_exists").(bool) {
+		return nil
+	}
+	if !d.NewValueKnown("name") || !d.NewValueKnown("asset_group_id") {
+		return nil
+	}
+	return checkApplicationNameFree(ctx, client, d.Get("name").(string), d.Get("asset_group_id").(string))
+}
+
+// updateApplication updates an application. A rejected update is returned
+// as an *appscan.APIError.
+func updateApplication(ctx context.Context, client *AppScanClient, id string, payload appScanApplicationRequest) error {
 	body, err := json.Marshal(payload)
 	if err != nil {
 		return err
 	}
 
-	url := fmt.Sprintf("%s/api/v4/Apps/%s", client.ApiEndpoint, id)
-	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(body))
+	url := fmt.Sprintf("%s/Apps/%s", client.ApiBase, id)
+	req, err := client.newRequest(ctx, "PUT", url, bytes.NewBuffer(body))
 	if err != nil {
 		return err
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", client.ApiToken))
 	req.Header.Set("Content-Type", "application/json")
 
 	resp, err := client.Client.Do(req)
@@ -209,21 +675,18 @@ func resourceAppScanApplicationUpdate(d *schema.ResourceData, m interface{}) err
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to update application, status: %s", resp.Status)
+		return appscan.NewAPIError("update application", resp)
 	}
-	return resourceAppScanApplicationRead(d, m)
+	return nil
 }
 
-func resourceAppScanApplicationDelete(d *schema.ResourceData, m interface{}) error {
-	client := m.(*AppScanClient)
-	id := d.Id()
-
-	url := fmt.Sprintf("%s/api/v4/Apps/%s", client.ApiEndpoint, id)
-	req, err := http.NewRequest("DELETE", url, nil)
+// deleteApplication deletes an application along with its scans and issues.
+func deleteApplication(ctx context.Context, client *AppScanClient, id string) error {
+	url := fmt.Sprintf("%s/Apps/%s", client.ApiBase, id)
+	req, err := client.newRequest(ctx, "DELETE", url, nil)
 	if err != nil {
 		return err
 	}
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", client.ApiToken))
 
 	resp, err := client.Client.Do(req)
 	if err != nil {
@@ -232,8 +695,60 @@ func resourceAppScanApplicationDelete(d *schema.ResourceData, m interface{}) err
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to delete application, status: %s", resp.Status)
+		return appscan.NewAPIError("delete application", resp)
 	}
-	d.SetId("")
 	return nil
 }
+
+// getApplication fetches an application, returning nil when it does not
+// exist.
+func getApplication(ctx context.Context, client *AppScanClient, id string) (*appScanApplication, error) {
+	filterQuery, err := client.odataEqID(ctx, "Id", id)
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	query.Set("$filter", filterQuery)
+	urlStr := fmt.Sprintf("%s/Apps?%s", client.ApiBase, query.Encode())
+
+	req, err := client.newRequest(ctx, "GET", urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, appscan.NewAPIError("read application", resp)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Items []json.RawMessage `json:"Items"`
+	}
+	if err := appscan.DecodeODataPage(resp, respBody, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Items) == 0 {
+		return nil, nil
+	}
+	var app appScanApplication
+	if err := appscan.DecodeJSON(resp, result.Items[0], &app); err != nil {
+		return nil, err
+	}
+	if err := appscan.DecodeJSON(resp, result.Items[0], &app.payload); err != nil {
+		return nil, err
+	}
+	return &app, nil
+}
@@ -0,0 +1,686 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccApplicationResource(t *testing.T) {
+	m := newMockServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApplicationConfig(m, "payments", "High"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("appscan_application.test", "id"),
+					resource.TestCheckResourceAttr("appscan_application.test", "name", "payments"),
+					resource.TestCheckResourceAttr("appscan_application.test", "asset_group_id", mockAssetGroupID),
+					resource.TestCheckResourceAttr("appscan_application.test", "business_impact", "High"),
+				),
+			},
+			{
+				Config: testAccApplicationConfig(m, "payments-api", "Critical"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_application.test", "name", "payments-api"),
+					resource.TestCheckResourceAttr("appscan_application.test", "business_impact", "Critical"),
+					resource.TestCheckResourceAttr("appscan_application.test", "risk_rating", "Unknown"),
+					resource.TestCheckResourceAttr("appscan_application.test", "total_issues", "0"),
+				),
+			},
+			{
+				// Scans update the risk rating and issue counts.
+				PreConfig: func() {
+					m.mu.Lock()
+					defer m.mu.Unlock()
+					for _, app := range m.collections["Apps"] {
+						app["RiskRating"] = "High"
+						app["MaxSeverity"] = "High"
+						app["HighIssues"] = 3
+						app["LowIssues"] = 2
+						app["OpenIssues"] = 5
+						app["TotalIssues"] = 7
+					}
+				},
+				Config: testAccApplicationConfig(m, "payments-api", "Critical"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_application.test", "risk_rating", "High"),
+					resource.TestCheckResourceAttr("appscan_application.test", "max_severity", "High"),
+					resource.TestCheckResourceAttr("appscan_application.test", "critical_issues", "0"),
+					resource.TestCheckResourceAttr("appscan_application.test", "high_issues", "3"),
+					resource.TestCheckResourceAttr("appscan_application.test", "low_issues", "2"),
+					resource.TestCheckResourceAttr("appscan_application.test", "open_issues", "5"),
+					resource.TestCheckResourceAttr("appscan_application.test", "total_issues", "7"),
+				),
+			},
+			{
+				ResourceName:      "appscan_application.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// A description cleared outside Terraform shows as drift.
+				PreConfig: func() {
+					m.mu.Lock()
+					defer m.mu.Unlock()
+					for _, app := range m.collections["Apps"] {
+						app["Description"] = nil
+					}
+				},
+				Config:             testAccApplicationConfig(m, "payments-api", "Critical"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccApplicationResource_normalizedValues(t *testing.T) {
+	m := newMockServer(t)
+	config := func(name, description, impact string) string {
+		return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_application" "test" {
+  name            = %q
+  description     = %q
+  asset_group_id  = %q
+  business_impact = %q
+}
+`, name, description, mockAssetGroupID, impact)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// The API trims and title-cases the values, which must not
+				// show as a change in the plan that follows.
+				Config: config("payments ", "  Managed by Terraform\n", "high"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_application.test", "name", "payments"),
+					resource.TestCheckResourceAttr("appscan_application.test", "description", "Managed by Terraform"),
+					resource.TestCheckResourceAttr("appscan_application.test", "business_impact", "High"),
+				),
+			},
+			{
+				Config:   config("payments", "Managed by Terraform", "HIGH"),
+				PlanOnly: true,
+			},
+			{
+				Config: config("payments", "Managed by Terraform", "Low"),
+				Check:  resource.TestCheckResourceAttr("appscan_application.test", "business_impact", "Low"),
+			},
+		},
+	})
+}
+
+// A business impact the provider does not know is left for the API to
+// accept or reject.
+func TestAccApplicationResource_unknownBusinessImpact(t *testing.T) {
+	m := newMockServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_application" "test" {
+  name            = "payments"
+  asset_group_id  = %q
+  business_impact = "Severe"
+}
+`, mockAssetGroupID),
+				Check: resource.TestCheckResourceAttr("appscan_application.test", "business_impact", "Severe"),
+			},
+		},
+	})
+}
+
+// A new application missing from the list endpoint for a while is waited
+// for rather than taken for deleted.
+func TestAccApplicationResource_eventualConsistency(t *testing.T) {
+	m := newMockServer(t)
+	m.listingDelay = 2
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_application" "test" {
+  name           = "payments"
+  asset_group_id = %q
+}
+`, mockAssetGroupID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("appscan_application.test", "id"),
+					resource.TestCheckResourceAttr("appscan_application.test", "name", "payments"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccApplicationResource_quotedGUIDs(t *testing.T) {
+	m := newMockServer(t)
+	m.quotedGUIDs = true
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApplicationConfig(m, "payments", "High"),
+				Check:  resource.TestCheckResourceAttr("appscan_application.test", "name", "payments"),
+			},
+			{
+				PreConfig: func() {
+					m.mu.Lock()
+					defer m.mu.Unlock()
+					m.collections["Apps"] = nil
+				},
+				// The application deleted outside Terraform is created again.
+				Config: testAccApplicationConfig(m, "payments", "High"),
+				Check: func(*terraform.State) error {
+					m.mu.Lock()
+					defer m.mu.Unlock()
+					if n := len(m.collections["Apps"]); n != 1 {
+						return fmt.Errorf("%d applications, expected 1", n)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccApplicationResource_failIfNameExists(t *testing.T) {
+	m := newMockServer(t)
+	app := m.add("Apps", mockEntity{"Name": "payments", "AssetGroupId": mockAssetGroupID})
+	config := func(name string) string {
+		return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_application" "test" {
+  name                = %q
+  asset_group_id      = %q
+  fail_if_name_exists = true
+}
+`, name, mockAssetGroupID)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config("payments "),
+				ExpectError: regexp.MustCompile(fmt.Sprintf(`an application named "payments" already exists in asset group %s \(ID %s\)`, mockAssetGroupID, app["Id"])),
+			},
+			{
+				Config: config("payments-v2"),
+				Check:  resource.TestCheckResourceAttr("appscan_application.test", "name", "payments-v2"),
+			},
+		},
+	})
+}
+
+func TestAccApplicationResource_moveAssetGroup(t *testing.T) {
+	m := newMockServer(t)
+	otherGroup := "22222222-2222-2222-2222-222222222222"
+	var appID string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApplicationInGroupConfig(m, mockAssetGroupID),
+				Check:  testAccSaveID("appscan_application.test", &appID),
+			},
+			{
+				Config: testAccApplicationInGroupConfig(m, otherGroup),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_application.test", "asset_group_id", otherGroup),
+					testAccCheckID("appscan_application.test", &appID, true),
+				),
+			},
+			{
+				// Moves the API rejects fail the apply rather than
+				// replacing the application.
+				PreConfig: func() {
+					m.mu.Lock()
+					defer m.mu.Unlock()
+					m.rejectMoves = true
+				},
+				Config:      testAccApplicationInGroupConfig(m, mockAssetGroupID),
+				ExpectError: regexp.MustCompile(`cannot be moved to asset group(.|\n)*replace the resource`),
+			},
+			{
+				// The state keeps the asset group of the application.
+				Config:   testAccApplicationInGroupConfig(m, otherGroup),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccApplicationInGroupConfig(m, otherGroup),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_application.test", "asset_group_id", otherGroup),
+					testAccCheckID("appscan_application.test", &appID, true),
+				),
+			},
+		},
+	})
+}
+
+func TestAccApplicationResource_deletionProtection(t *testing.T) {
+	m := newMockServer(t)
+	config := func(protected bool) string {
+		return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_application" "test" {
+  name                     = "payments"
+  asset_group_id           = %q
+  deletion_protection      = %t
+  delete_issues_on_destroy = false
+}
+`, mockAssetGroupID, protected)
+	}
+	setIssues := func(n int) func() {
+		return func() {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			for _, app := range m.collections["Apps"] {
+				app["TotalIssues"] = n
+			}
+		}
+	}
+	var appID string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccSaveID("appscan_application.test", &appID),
+					resource.TestCheckResourceAttr("appscan_application.test", "deletion_protection", "true"),
+				),
+			},
+			{
+				Config:      testAccProviderConfig(m),
+				ExpectError: regexp.MustCompile("deletion_protection is set"),
+			},
+			{
+				// Lifting the protection does not touch the application.
+				PreConfig: setIssues(2),
+				Config:    config(false),
+				Check:     testAccCheckID("appscan_application.test", &appID, true),
+			},
+			{
+				Config:      testAccProviderConfig(m),
+				ExpectError: regexp.MustCompile("it has 2 issues and delete_issues_on_destroy is false"),
+			},
+			{
+				PreConfig: setIssues(0),
+				Config:    config(false),
+				Check:     testAccCheckID("appscan_application.test", &appID, true),
+			},
+			{
+				ResourceName:            "appscan_application.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"delete_issues_on_destroy"},
+			},
+		},
+	})
+}
+
+func testAccApplicationInGroupConfig(m *mockServer, assetGroupID string) string {
+	return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_application" "test" {
+  name           = "payments"
+  asset_group_id = %q
+}
+`, assetGroupID)
+}
+
+func TestAccApplicationResource_archiveOnDestroy(t *testing.T) {
+	m := newMockServer(t)
+	archiveGroup := "22222222-2222-2222-2222-222222222222"
+	var appID string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			app := m.find("Apps", appID)
+			if app == nil {
+				return fmt.Errorf("application %s was deleted rather than archived", appID)
+			}
+			if app["AssetGroupId"] != archiveGroup {
+				return fmt.Errorf("application %s is in asset group %v, want %s", appID, app["AssetGroupId"], archiveGroup)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_application" "test" {
+  name                     = "payments"
+  asset_group_id           = %q
+  delete_issues_on_destroy = false
+  archive_asset_group_id   = %q
+}
+`, mockAssetGroupID, archiveGroup),
+				Check: resource.ComposeTestCheckFunc(
+					testAccSaveID("appscan_application.test", &appID),
+					func(*terraform.State) error {
+						// Archived applications keep their issues.
+						m.mu.Lock()
+						defer m.mu.Unlock()
+						m.find("Apps", appID)["TotalIssues"] = 2
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccApplicationResource_owners(t *testing.T) {
+	m := newMockServer(t)
+	alice := m.add("Users", mockEntity{"UserName": "alice", "Email": "alice@example.com"})["Id"].(string)
+	bob := m.add("Users", mockEntity{"UserName": "bob@example.com"})["Id"].(string)
+	var appID string
+	checkContacts := func(want map[string]interface{}) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			app := m.find("Apps", appID)
+			for field, v := range want {
+				if app[field] != v {
+					return fmt.Errorf("%s = %v, want %v", field, app[field], v)
+				}
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApplicationOwnersConfig(m, fmt.Sprintf("business_owner_id = %q\n  security_owner_id = %q", alice, bob)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccSaveID("appscan_application.test", &appID),
+					resource.TestCheckResourceAttr("appscan_application.test", "business_owner_id", alice),
+					resource.TestCheckResourceAttr("appscan_application.test", "security_owner_id", bob),
+					resource.TestCheckResourceAttr("appscan_application.test", "development_contact_id", ""),
+					checkContacts(map[string]interface{}{"BusinessOwner": "alice@example.com", "Tester": "bob@example.com", "DevelopmentContact": nil}),
+				),
+			},
+			{
+				// A contact set in the console is reported as drift.
+				PreConfig: func() {
+					m.mu.Lock()
+					defer m.mu.Unlock()
+					m.find("Apps", appID)["DevelopmentContact"] = "ALICE@example.com"
+				},
+				Config:             testAccApplicationOwnersConfig(m, fmt.Sprintf("business_owner_id = %q\n  security_owner_id = %q", alice, bob)),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// It is read back as its user, whatever the case.
+				Config:   testAccApplicationOwnersConfig(m, fmt.Sprintf("business_owner_id = %q\n  security_owner_id = %q\n  development_contact_id = %q", alice, bob, alice)),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccApplicationOwnersConfig(m, fmt.Sprintf("business_owner_id = %q", bob)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_application.test", "business_owner_id", bob),
+					resource.TestCheckResourceAttr("appscan_application.test", "security_owner_id", ""),
+					checkContacts(map[string]interface{}{"BusinessOwner": "bob@example.com", "Tester": "", "DevelopmentContact": ""}),
+				),
+			},
+			{
+				Config:      testAccApplicationOwnersConfig(m, `business_owner_id = "88888888-8888-8888-8888-888888888888"`),
+				ExpectError: regexp.MustCompile(`business_owner_id "88888888-8888-8888-8888-888888888888" does not match any user`),
+			},
+			{
+				ResourceName:            "appscan_application.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"delete_issues_on_destroy"},
+			},
+		},
+	})
+}
+
+func testAccApplicationOwnersConfig(m *mockServer, owners string) string {
+	return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_application" "test" {
+  name           = "payments"
+  asset_group_id = %q
+  %s
+}
+`, mockAssetGroupID, owners)
+}
+
+func TestAccApplicationResource_unknownAssetGroup(t *testing.T) {
+	m := newMockServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + `
+resource "appscan_application" "test" {
+  name           = "payments"
+  asset_group_id = "Default Asset Group"
+}
+`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`"asset_group_id" must be a GUID`),
+			},
+			{
+				Config: testAccProviderConfig(m) + `
+resource "appscan_application" "test" {
+  name           = "payments"
+  asset_group_id = "11111111-1111-1111-1111-11111111111f"
+}
+`,
+				ExpectError: regexp.MustCompile(`did you mean "` + mockAssetGroupID + `"`),
+			},
+		},
+	})
+}
+
+func testAccApplicationConfig(m *mockServer, name, impact string) string {
+	return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_application" "test" {
+  name             = %q
+  description      = "Managed by Terraform"
+  asset_group_id   = %q
+  business_unit_id = %q
+  business_impact  = %q
+}
+`, name, mockAssetGroupID, mockBusinessUnitID, impact)
+}
+
+func TestAccApplicationResource_providerDefaults(t *testing.T) {
+	m := newMockServer(t)
+	otherGroup := "22222222-2222-2222-2222-222222222222"
+	config := func(defaults bool, assetGroupID string) string {
+		provider := testAccProviderConfig(m)
+		if defaults {
+			provider = fmt.Sprintf(`
+provider "appscan" {
+  api_endpoint             = %q
+  key_id                   = %q
+  key_secret               = %q
+  default_asset_group_id   = %q
+  default_business_unit_id = %q
+}
+`, m.URL, mockKeyID, mockKeySecret, otherGroup, mockBusinessUnitID)
+		}
+		group := ""
+		if assetGroupID != "" {
+			group = fmt.Sprintf("asset_group_id = %q", assetGroupID)
+		}
+		return provider + fmt.Sprintf(`
+resource "appscan_application" "test" {
+  name = "payments"
+  %s
+}
+`, group)
+	}
+	var appID string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config(false, ""),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("asset_group_id is required when the provider sets no default_asset_group_id"),
+			},
+			{
+				Config: config(true, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccSaveID("appscan_application.test", &appID),
+					resource.TestCheckResourceAttr("appscan_application.test", "asset_group_id", otherGroup),
+					resource.TestCheckResourceAttr("appscan_application.test", "business_unit_id", mockBusinessUnitID),
+				),
+			},
+			{
+				Config: config(true, mockAssetGroupID),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckID("appscan_application.test", &appID, true),
+					resource.TestCheckResourceAttr("appscan_application.test", "asset_group_id", mockAssetGroupID),
+				),
+			},
+			{
+				Config: config(true, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckID("appscan_application.test", &appID, true),
+					resource.TestCheckResourceAttr("appscan_application.test", "asset_group_id", otherGroup),
+				),
+			},
+		},
+	})
+}
+
+func TestAccApplicationResource_autoTags(t *testing.T) {
+	m := newMockServer(t)
+	m.add("CustomFields", mockEntity{"Id": "workspace", "ColumnName": "workspace", "ValueType": "String", "DefaultValue": ""})
+	m.add("CustomFields", mockEntity{"Id": "owner", "ColumnName": "owner", "ValueType": "String", "DefaultValue": ""})
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "appscan" {
+  api_endpoint = %q
+  key_id       = %q
+  key_secret   = %q
+  auto_tags = {
+    workspace = "prod"
+    owner     = "platform"
+  }
+}
+
+resource "appscan_application" "test" {
+  name           = "payments"
+  asset_group_id = %q
+  attributes = {
+    owner = "team-payments"
+  }
+}
+`, m.URL, mockKeyID, mockKeySecret, mockAssetGroupID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_application.test", "attributes.%", "1"),
+					func(*terraform.State) error {
+						m.mu.Lock()
+						defer m.mu.Unlock()
+						values := m.collections["AppCustomFields"][0]
+						if values["workspace"] != "prod" || values["owner"] != "team-payments" {
+							return fmt.Errorf("unexpected attributes: %v", values)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccApplicationResource_lostCreateResponse(t *testing.T) {
+	m := newMockServer(t)
+	// An application of the same name created earlier is not adopted.
+	m.add("Apps", mockEntity{"Id": "33333333-3333-3333-3333-333333333333", "Name": "payments", "AssetGroupId": mockAssetGroupID, "DateCreated": "2020-01-01T00:00:00Z"})
+	m.lostResponses = 1
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApplicationConfig(m, "payments", "High"),
+				Check: func(s *terraform.State) error {
+					m.mu.Lock()
+					defer m.mu.Unlock()
+					if n := len(m.collections["Apps"]); n != 2 {
+						return fmt.Errorf("%d applications exist, want 2", n)
+					}
+					created := m.collections["Apps"][1]["Id"]
+					if id := s.RootModule().Resources["appscan_application.test"].Primary.ID; id != created {
+						return fmt.Errorf("application ID = %s, want %s", id, created)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccApplicationResource_strictMode(t *testing.T) {
+	m := newMockServer(t)
+	config := testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_application" "test" {
+  name           = "payments"
+  asset_group_id = %q
+}
+`, mockAssetGroupID)
+	config = strings.Replace(config, `poll_interval = "100ms"`, `poll_interval = "100ms"
+  strict_mode   = true`, 1)
+	setRiskRating := func(rating string) func() {
+		return func() {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			for _, app := range m.collections["Apps"] {
+				app["RiskRating"] = rating
+			}
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("appscan_application.test", "risk_rating", "Unknown"),
+			},
+			{
+				PreConfig:   setRiskRating("Extreme"),
+				Config:      config,
+				ExpectError: regexp.MustCompile(`Unknown RiskRating in application`),
+			},
+			{
+				PreConfig: setRiskRating("High"),
+				Config:    config,
+				Check:     resource.TestCheckResourceAttr("appscan_application.test", "risk_rating", "High"),
+			},
+		},
+	})
+}
@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The schema of appscan_application is versioned so that states written by
+// earlier releases are upgraded before being planned against, rather than
+// showing spurious diffs. Each StateUpgrader converts the state of a version
+// to the next one; versions only change when a stored value needs
+// converting, not when attributes are merely added, which Read sets.
+//
+// Version 0 is the schema of the first releases: name, description,
+// asset_group_id, business_unit_id and business_impact only.
+// Version 1 stores an empty business_impact as Unspecified, the default
+// the API applies, and null strings as empty ones, as Read does.
+
+// resourceAppScanApplicationV0 is appscan_application as of schema version 0.
+func resourceAppScanApplicationV0() *schema.Resource {
+	return &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"asset_group_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"business_unit_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"business_impact": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Unspecified",
+			},
+			"id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// applicationStateUpgraders are the StateUpgraders of appscan_application.
+func applicationStateUpgraders() []schema.StateUpgrader {
+	return []schema.StateUpgrader{
+		{
+			Version: 0,
+			Type:    resourceAppScanApplicationV0().CoreConfigSchema().ImpliedType(),
+			Upgrade: upgradeApplicationStateV0,
+		},
+	}
+}
+
+func upgradeApplicationStateV0(_ context.Context, rawState map[string]interface{}, _ interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return nil, nil
+	}
+	for _, k := range []string{"description", "business_unit_id"} {
+		if v, _ := rawState[k].(string); v == "" {
+			rawState[k] = ""
+		}
+	}
+	if v, _ := rawState["business_impact"].(string); v == "" {
+		rawState["business_impact"] = "Unspecified"
+	}
+	return rawState, nil
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestUpgradeApplicationStateV0(t *testing.T) {
+	for name, tc := range map[string]struct {
+		v0, want map[string]interface{}
+	}{
+		"defaults": {
+			v0: map[string]interface{}{
+				"id":             mockApplicationID,
+				"name":           "payments",
+				"asset_group_id": mockAssetGroupID,
+			},
+			want: map[string]interface{}{
+				"id":               mockApplicationID,
+				"name":             "payments",
+				"description":      "",
+				"asset_group_id":   mockAssetGroupID,
+				"business_unit_id": "",
+				"business_impact":  "Unspecified",
+			},
+		},
+		"set": {
+			v0: map[string]interface{}{
+				"id":               mockApplicationID,
+				"name":             "payments",
+				"description":      "Card payments",
+				"asset_group_id":   mockAssetGroupID,
+				"business_unit_id": mockBusinessUnitID,
+				"business_impact":  "High",
+			},
+			want: map[string]interface{}{
+				"id":               mockApplicationID,
+				"name":             "payments",
+				"description":      "Card payments",
+				"asset_group_id":   mockAssetGroupID,
+				"business_unit_id": mockBusinessUnitID,
+				"business_impact":  "High",
+			},
+		},
+	} {
+		got, err := upgradeApplicationStateV0(context.Background(), tc.v0, nil)
+		if err != nil {
+			t.Errorf("%s: %s", name, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: got %v, want %v", name, got, tc.want)
+		}
+	}
+}
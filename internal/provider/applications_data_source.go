@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ----------------------------------------------------------------
+// Data Source: appscan_applications (lists applications, e.g. to reconcile a CMDB)
+// ----------------------------------------------------------------
+
+func dataSourceApplications() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"asset_group_id": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "If set, only the applications of this asset group are listed.",
+			ValidateFunc: validation.Any(validation.StringIsEmpty, validateGUID),
+		},
+		"applications": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The applications.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The ID of the application.",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the application.",
+					},
+					"description": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The description of the application.",
+					},
+					"asset_group_id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The ID of the asset group of the application.",
+					},
+					"business_unit_id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The ID of the business unit of the application.",
+					},
+					"business_impact": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The business impact of the application.",
+					},
+				},
+			},
+		},
+		"ids": {
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Description: "The IDs of the applications, keyed by name. When names are not unique, one of them wins.",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+	}
+	for k, v := range listQuerySchema() {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		ReadWithoutTimeout: withContext(dataSourceApplicationsRead),
+		Schema:             s,
+	}
+}
+
+func dataSourceApplicationsRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	filter := ""
+	assetGroupID := d.Get("asset_group_id").(string)
+	if assetGroupID != "" {
+		var err error
+		if filter, err = client.odataEqID(ctx, "AssetGroupId", assetGroupID); err != nil {
+			return err
+		}
+	}
+	apps, err := queryApplications(ctx, client, listQueryFor(d).values(filter, "", "Id", "Name"))
+	if err != nil {
+		return err
+	}
+
+	list := make([]interface{}, 0, len(apps))
+	ids := make(map[string]interface{}, len(apps))
+	for _, a := range apps {
+		list = append(list, map[string]interface{}{
+			"id":               a.Id,
+			"name":             a.Name,
+			"description":      a.Description,
+			"asset_group_id":   a.AssetGroupId,
+			"business_unit_id": a.BusinessUnitId,
+			"business_impact":  a.BusinessImpact,
+		})
+		ids[a.Name] = a.Id
+	}
+	if err := d.Set("applications", list); err != nil {
+		return err
+	}
+	if err := d.Set("ids", ids); err != nil {
+		return err
+	}
+
+	if assetGroupID != "" {
+		d.SetId(assetGroupID)
+	} else {
+		d.SetId(client.ApiEndpoint)
+	}
+	return nil
+}
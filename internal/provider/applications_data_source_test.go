@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccApplicationsDataSource(t *testing.T) {
+	m := newMockServer(t)
+	otherGroup := "22222222-2222-2222-2222-222222222222"
+	payments := m.add("Apps", mockEntity{"Name": "payments", "AssetGroupId": mockAssetGroupID, "BusinessImpact": "High"})
+	m.add("Apps", mockEntity{"Name": "billing", "AssetGroupId": otherGroup, "BusinessImpact": "Low"})
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + fmt.Sprintf(`
+data "appscan_applications" "all" {}
+
+data "appscan_applications" "group" {
+  asset_group_id = %q
+}
+`, mockAssetGroupID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_applications.all", "applications.#", "2"),
+					resource.TestCheckResourceAttr("data.appscan_applications.group", "applications.#", "1"),
+					resource.TestCheckResourceAttr("data.appscan_applications.group", "applications.0.business_impact", "High"),
+					resource.TestCheckResourceAttr("data.appscan_applications.group", "ids.payments", payments["Id"].(string)),
+				),
+			},
+		},
+	})
+}
+
+func TestAccApplicationsDataSource_pages(t *testing.T) {
+	for _, noCount := range []bool{false, true} {
+		t.Run(fmt.Sprintf("noCount=%t", noCount), func(t *testing.T) {
+			m := newMockServer(t)
+			m.noCount = noCount
+			for i := 0; i < 3*catalogPageSize+2; i++ {
+				m.add("Apps", mockEntity{"Name": fmt.Sprintf("app-%d", i), "AssetGroupId": mockAssetGroupID})
+			}
+			m.mu.Lock()
+			ids := make([]string, 0, len(m.collections["Apps"]))
+			for _, app := range m.collections["Apps"] {
+				ids = append(ids, app["Id"].(string))
+			}
+			m.mu.Unlock()
+			sort.Strings(ids)
+
+			resource.Test(t, resource.TestCase{
+				ProviderFactories: testAccProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config: testAccProviderConfig(m) + `
+data "appscan_applications" "all" {}
+`,
+						Check: func(s *terraform.State) error {
+							attrs := s.RootModule().Resources["data.appscan_applications.all"].Primary.Attributes
+							if attrs["applications.#"] != fmt.Sprint(len(ids)) {
+								return fmt.Errorf("listed %s applications, want %d", attrs["applications.#"], len(ids))
+							}
+							// The pages fetched concurrently are merged in order.
+							for i, id := range ids {
+								if actual := attrs[fmt.Sprintf("applications.%d.id", i)]; actual != id {
+									return fmt.Errorf("applications.%d.id = %s, want %s", i, actual, id)
+								}
+							}
+							return nil
+						},
+					},
+				},
+			})
+		})
+	}
+}
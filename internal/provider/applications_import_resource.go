@@ -0,0 +1,395 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// appscan_applications_import onboards many applications, e.g. from a CMDB,
+// with a single resource. Applications are identified by name within the
+// list: an application that already exists under that name in its asset
+// group is adopted rather than duplicated. Failures are reported per
+// application and do not stop the others.
+
+func resourceAppScanApplicationsImport() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceAppScanApplicationsImportCreate,
+		ReadContext:   resourceAppScanApplicationsImportRead,
+		UpdateContext: resourceAppScanApplicationsImportUpdate,
+		DeleteContext: resourceAppScanApplicationsImportDelete,
+		CustomizeDiff: customdiff.All(
+			customizeDiffProviderDefault("asset_group_id", "default_asset_group_id", true, func(c *AppScanClient) string { return c.DefaultAssetGroupId }),
+			customizeDiffUniqueApplicationNames,
+		),
+		Schema: map[string]*schema.Schema{
+			"asset_group_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The ID of the asset group of the applications that do not set one. Required unless the provider sets default_asset_group_id, which applies when it is omitted.",
+				ValidateFunc: validateGUID,
+			},
+			"application": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "The applications to create or update. Names must be unique within the list.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the application.",
+						},
+						"description": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Description:      "A description of the application. Leading and trailing whitespace, which the API trims, is ignored.",
+							DiffSuppressFunc: suppressSurroundingSpace,
+						},
+						"asset_group_id": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "The ID of the asset group of the application. Defaults to the asset_group_id of the resource.",
+							ValidateFunc: validation.Any(validation.StringIsEmpty, validateGUID),
+						},
+						"business_unit_id": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "The ID of the business unit of the application.",
+							ValidateFunc: validation.Any(validation.StringIsEmpty, validateGUID),
+						},
+						"business_impact": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "Unspecified",
+							Description:      "The business impact of the application: Unspecified, Low, Medium, High or Critical, in any case. Other values are sent as is, with a warning.",
+							ValidateDiagFunc: validateEnum(businessImpacts),
+							DiffSuppressFunc: suppressEqualFold,
+						},
+					},
+				},
+			},
+			"parallelism": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      4,
+				ValidateFunc: validation.IntBetween(1, 32),
+				Description:  "The number of applications created, updated or deleted at the same time. The provider's requests_per_second still applies. Defaults to 4.",
+			},
+			"delete_applications": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the applications removed from the list, or all of them when the resource is destroyed, are deleted along with their scans and issues. Defaults to false: they are left in AppScan and only stop being managed.",
+			},
+			"application_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The IDs of the applications, keyed by name.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A random identifier of the import.",
+			},
+		},
+	}
+}
+
+// appScanApplicationSummary holds the ApplicationModel fields listed in bulk.
+type appScanApplicationSummary = appscan.Application
+
+func customizeDiffUniqueApplicationNames(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	seen := map[string]bool{}
+	for _, v := range d.Get("application").([]interface{}) {
+		item, _ := v.(map[string]interface{})
+		name, _ := item["name"].(string)
+		if name == "" {
+			continue
+		}
+		if seen[name] {
+			return fmt.Errorf("application %q is listed more than once", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+func resourceAppScanApplicationsImportCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(id)
+	return syncApplications(ctx, d, m)
+}
+
+func resourceAppScanApplicationsImportRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*AppScanClient)
+
+	apps, err := listApplications(ctx, client, "")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	byID := make(map[string]appScanApplicationSummary, len(apps))
+	for _, a := range apps {
+		byID[strings.ToLower(a.Id)] = a
+	}
+
+	// Applications deleted outside Terraform are dropped, so the next plan
+	// creates them again.
+	defaultGroup := d.Get("asset_group_id").(string)
+	ids := d.Get("application_ids").(map[string]interface{})
+	items := []interface{}{}
+	found := map[string]interface{}{}
+	for _, v := range d.Get("application").([]interface{}) {
+		item := v.(map[string]interface{})
+		name := item["name"].(string)
+		id, _ := ids[name].(string)
+		app, ok := byID[strings.ToLower(id)]
+		if !ok {
+			continue
+		}
+		assetGroupID := app.AssetGroupId
+		if item["asset_group_id"].(string) == "" && strings.EqualFold(assetGroupID, defaultGroup) {
+			assetGroupID = ""
+		}
+		items = append(items, map[string]interface{}{
+			"name":             app.Name,
+			"description":      app.Description,
+			"asset_group_id":   assetGroupID,
+			"business_unit_id": app.BusinessUnitId,
+			"business_impact":  app.BusinessImpact,
+		})
+		found[app.Name] = app.Id
+	}
+	if err := d.Set("application", items); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := d.Set("application_ids", found); err != nil {
+		return diag.FromErr(err)
+	}
+	return nil
+}
+
+func resourceAppScanApplicationsImportUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	return syncApplications(ctx, d, m)
+}
+
+func resourceAppScanApplicationsImportDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*AppScanClient)
+
+	if d.Get("delete_applications").(bool) {
+		ids := d.Get("application_ids").(map[string]interface{})
+		names := make([]string, 0, len(ids))
+		for name := range ids {
+			names = append(names, name)
+		}
+		errs := make([]error, len(names))
+		forEachParallel(len(names), d.Get("parallelism").(int), func(i int) {
+			errs[i] = deleteApplication(ctx, client, ids[names[i]].(string))
+		})
+		var diags diag.Diagnostics
+		for i, err := range errs {
+			if err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  fmt.Sprintf("Failed to delete application %q", names[i]),
+					Detail:   err.Error(),
+				})
+				continue
+			}
+			client.Summary.record("application_deleted", "appscan_applications_import", ids[names[i]].(string), map[string]string{
+				"name": names[i],
+			})
+		}
+		if diags.HasError() {
+			return diags
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+// syncApplications creates or updates the listed applications and, when
+// delete_applications is set, deletes the ones removed from the list. The
+// applications that could not be processed are reported as errors against
+// their block; the others are kept in application_ids.
+func syncApplications(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*AppScanClient)
+	defaultGroup := d.Get("asset_group_id").(string)
+
+	existing, err := listApplications(ctx, client, "")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	byID := make(map[string]appScanApplicationSummary, len(existing))
+	byName := make(map[string]appScanApplicationSummary, len(existing))
+	for _, a := range existing {
+		byID[strings.ToLower(a.Id)] = a
+		byName[strings.ToLower(a.AssetGroupId)+"/"+a.Name] = a
+	}
+
+	previous := d.Get("application_ids").(map[string]interface{})
+	items := d.Get("application").([]interface{})
+	ids := make([]string, len(items))
+	errs := make([]error, len(items))
+	forEachParallel(len(items), d.Get("parallelism").(int), func(i int) {
+		item := items[i].(map[string]interface{})
+		name := item["name"].(string)
+		assetGroupID := item["asset_group_id"].(string)
+		if assetGroupID == "" {
+			assetGroupID = defaultGroup
+		}
+		payload := appScanApplicationRequest{
+			Name:           name,
+			Description:    item["description"].(string),
+			BusinessUnitId: item["business_unit_id"].(string),
+			BusinessImpact: item["business_impact"].(string),
+		}
+
+		id, _ := previous[name].(string)
+		current, ok := byID[strings.ToLower(id)]
+		if !ok {
+			// Adopt an application of the same name rather than create a
+			// duplicate, e.g. after an interrupted apply.
+			current, ok = byName[strings.ToLower(assetGroupID)+"/"+name]
+		}
+		if !ok {
+			payload.AssetGroupId = assetGroupID
+			ids[i], errs[i] = createApplication(ctx, client, payload)
+			if errs[i] == nil {
+				client.Summary.record("application_created", "appscan_applications_import", ids[i], map[string]string{
+					"name":           name,
+					"asset_group_id": assetGroupID,
+				})
+			}
+			return
+		}
+
+		ids[i] = current.Id
+		if current.Name == name && current.Description == strings.TrimSpace(item["description"].(string)) && strings.EqualFold(current.BusinessImpact, item["business_impact"].(string)) &&
+			strings.EqualFold(current.AssetGroupId, assetGroupID) && strings.EqualFold(current.BusinessUnitId, item["business_unit_id"].(string)) {
+			return
+		}
+		if !strings.EqualFold(current.AssetGroupId, assetGroupID) {
+			payload.AssetGroupId = assetGroupID
+		}
+		if errs[i] = updateApplication(ctx, client, current.Id, payload); errs[i] == nil {
+			client.Summary.record("application_updated", "appscan_applications_import", current.Id, map[string]string{
+				"name": name,
+			})
+		}
+	})
+
+	var diags diag.Diagnostics
+	result := map[string]interface{}{}
+	for i, v := range items {
+		name := v.(map[string]interface{})["name"].(string)
+		if errs[i] != nil {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Error,
+				Summary:       fmt.Sprintf("Failed to import application %q", name),
+				Detail:        errs[i].Error(),
+				AttributePath: cty.GetAttrPath("application").IndexInt(i),
+			})
+		}
+		if ids[i] != "" {
+			result[name] = ids[i]
+		}
+	}
+
+	// Applications removed from the list.
+	var removed []string
+	for name, id := range previous {
+		if !listsApplication(items, name) {
+			removed = append(removed, id.(string))
+		}
+	}
+	if d.Get("delete_applications").(bool) && len(removed) > 0 {
+		deleteErrs := make([]error, len(removed))
+		forEachParallel(len(removed), d.Get("parallelism").(int), func(i int) {
+			deleteErrs[i] = deleteApplication(ctx, client, removed[i])
+		})
+		for i, err := range deleteErrs {
+			if err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Error,
+					Summary:  fmt.Sprintf("Failed to delete application %s", removed[i]),
+					Detail:   err.Error(),
+				})
+				continue
+			}
+			client.Summary.record("application_deleted", "appscan_applications_import", removed[i], nil)
+		}
+	}
+
+	if err := d.Set("application_ids", result); err != nil {
+		return append(diags, diag.FromErr(err)...)
+	}
+	if diags.HasError() {
+		return diags
+	}
+	return append(diags, resourceAppScanApplicationsImportRead(ctx, d, m)...)
+}
+
+// listsApplication reports whether an application of that name is in items.
+func listsApplication(items []interface{}, name string) bool {
+	for _, v := range items {
+		if v.(map[string]interface{})["name"].(string) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// listApplications returns the applications matching filter, all of them
+// when it is empty.
+func listApplications(ctx context.Context, client *AppScanClient, filter string) ([]appScanApplicationSummary, error) {
+	query := url.Values{}
+	if filter != "" {
+		query.Set("$filter", filter)
+	}
+	return queryApplications(ctx, client, query)
+}
+
+// queryApplications lists the applications matching the $filter, $select
+// and $orderby options of query, following the pages of the collection. It
+// selects the fields of appScanApplicationSummary unless query selects
+// others.
+func queryApplications(ctx context.Context, client *AppScanClient, query url.Values) ([]appScanApplicationSummary, error) {
+	if !query.Has("$select") {
+		query.Set("$select", "Id,Name,Description,AssetGroupId,BusinessUnitId,BusinessImpact")
+	}
+	return listODataPages[appScanApplicationSummary](ctx, client, "Apps", query)
+}
+
+// forEachParallel calls fn for 0..n-1, running at most parallelism calls
+// at the same time, and returns when they are all done.
+func forEachParallel(n, parallelism int, fn func(i int)) {
+	sem := make(chan struct{}, max(parallelism, 1))
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
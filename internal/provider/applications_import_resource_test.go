@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccApplicationsImportResource(t *testing.T) {
+	m := newMockServer(t)
+	existing := m.add("Apps", mockEntity{"Name": "alpha", "AssetGroupId": mockAssetGroupID, "BusinessImpact": "Unspecified"})
+	missingGroup := "99999999-9999-9999-9999-999999999999"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApplicationsImportConfig(m, fmt.Sprintf(`
+  application {
+    name = "beta"
+  }
+  application {
+    name           = "broken"
+    asset_group_id = %q
+  }
+`, missingGroup)),
+				ExpectError: regexp.MustCompile(`Failed to import application "broken"`),
+			},
+			{
+				Config: testAccApplicationsImportConfig(m, `
+  application {
+    name = "beta"
+  }
+  application {
+    name = "beta"
+  }
+`),
+				ExpectError: regexp.MustCompile(`application "beta" is listed more than once`),
+			},
+			{
+				// beta, created by the failed apply, and alpha are adopted.
+				Config: testAccApplicationsImportConfig(m, `
+  application {
+    name = "alpha"
+  }
+  application {
+    name = "beta"
+  }
+  application {
+    name            = "gamma"
+    business_impact = "High"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_applications_import.test", "application_ids.%", "3"),
+					resource.TestCheckResourceAttr("appscan_applications_import.test", "application_ids.alpha", existing["Id"].(string)),
+					resource.TestCheckResourceAttr("appscan_applications_import.test", "application.2.business_impact", "High"),
+					testAccCheckMockApps(m, 3),
+				),
+			},
+			{
+				Config: testAccApplicationsImportConfig(m, `
+  application {
+    name = "alpha"
+  }
+  application {
+    name            = "beta"
+    description     = "Billing"
+    business_impact = "Critical"
+  }
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_applications_import.test", "application_ids.%", "2"),
+					resource.TestCheckResourceAttr("appscan_applications_import.test", "application.1.description", "Billing"),
+					testAccCheckMockApps(m, 2),
+				),
+			},
+		},
+	})
+}
+
+func testAccApplicationsImportConfig(m *mockServer, applications string) string {
+	return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_applications_import" "test" {
+  asset_group_id      = %q
+  delete_applications = true
+%s}
+`, mockAssetGroupID, applications)
+}
+
+// testAccCheckMockApps checks the number of applications of the mock API.
+func testAccCheckMockApps(m *mockServer, want int) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if got := len(m.collections["Apps"]); got != want {
+			return fmt.Errorf("got %d applications, want %d", got, want)
+		}
+		return nil
+	}
+}
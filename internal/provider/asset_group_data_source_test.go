@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAssetGroupDataSource(t *testing.T) {
+	m := newMockServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + `
+data "appscan_asset_group" "test" {
+  name = "Default Asset Group"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_asset_group.test", "id", mockAssetGroupID),
+					resource.TestCheckResourceAttr("data.appscan_asset_group.test", "description", "The default asset group"),
+				),
+			},
+			{
+				// Names holding quotes must be escaped in the OData filter.
+				Config: testAccProviderConfig(m) + `
+data "appscan_asset_group" "test" {
+  name = "O'Brien's Apps"
+}
+`,
+				Check: resource.TestCheckResourceAttr("data.appscan_asset_group.test", "id", "22222222-2222-2222-2222-222222222222"),
+			},
+			{
+				Config: testAccProviderConfig(m) + `
+data "appscan_asset_group" "test" {
+  name = "Missing"
+}
+`,
+				ExpectError: regexp.MustCompile("no asset group found with name: Missing"),
+			},
+		},
+	})
+}
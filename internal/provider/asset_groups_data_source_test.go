@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAssetGroupsDataSource(t *testing.T) {
+	m := newMockServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + `
+data "appscan_asset_groups" "all" {}
+
+data "appscan_asset_groups" "named" {
+  name = "Default Asset Group"
+}
+
+data "appscan_asset_groups" "query" {
+  filter   = "startswith(Name,'default')"
+  select   = ["Name"]
+  order_by = "Name desc"
+}
+
+data "appscan_asset_groups" "ordered" {
+  order_by = "Name desc"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_asset_groups.all", "asset_groups.#", "2"),
+					resource.TestCheckResourceAttr("data.appscan_asset_groups.named", "asset_groups.#", "1"),
+					resource.TestCheckResourceAttr("data.appscan_asset_groups.named", "asset_groups.0.id", mockAssetGroupID),
+					resource.TestCheckResourceAttr("data.appscan_asset_groups.query", "asset_groups.#", "1"),
+					resource.TestCheckResourceAttr("data.appscan_asset_groups.query", "asset_groups.0.id", mockAssetGroupID),
+					resource.TestCheckResourceAttr("data.appscan_asset_groups.query", "asset_groups.0.description", ""),
+					resource.TestCheckResourceAttr("data.appscan_asset_groups.ordered", "asset_groups.1.id", mockAssetGroupID),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Custom attributes (custom fields in the API) are defined for the whole
+// organization, then valued per application through the attributes argument
+// of appscan_application. The API can neither change nor delete a
+// definition: every argument forces a new one, and destroying the resource
+// only removes it from the state.
+
+func resourceAppScanAttributeDefinition() *schema.Resource {
+	return &schema.Resource{
+		CreateWithoutTimeout: withContext(resourceAppScanAttributeDefinitionCreate),
+		ReadWithoutTimeout:   withContext(resourceAppScanAttributeDefinitionRead),
+		Delete:               resourceAppScanAttributeDefinitionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The name of the attribute, used as key in the attributes of appscan_application.",
+				ValidateFunc: validation.StringLenBetween(1, 100),
+			},
+			"value_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "String",
+				Description:  "The type of the values of the attribute. Defaults to String.",
+				ValidateFunc: validation.StringLenBetween(1, 50),
+			},
+			"default_value": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Description:  "The value of the attribute for applications that do not set it.",
+				ValidateFunc: validation.StringLenBetween(0, 100),
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the attribute.",
+			},
+		},
+	}
+}
+
+// appScanCustomField holds the fields of a custom field definition or value.
+type appScanCustomField struct {
+	ColumnName   string `json:"ColumnName"`
+	ValueType    string `json:"ValueType"`
+	DefaultValue string `json:"DefaultValue"`
+	Value        string `json:"Value"`
+}
+
+func resourceAppScanAttributeDefinitionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	name := d.Get("name").(string)
+
+	orgID, err := organizationID(ctx, client)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(map[string]string{
+		"ColumnName":   name,
+		"ValueType":    d.Get("value_type").(string),
+		"DefaultValue": d.Get("default_value").(string),
+	})
+	if err != nil {
+		return err
+	}
+	urlStr := fmt.Sprintf("%s/CustomFields/%s/customFields", client.ApiBase, url.PathEscape(orgID))
+	req, err := client.newRequest(ctx, "POST", urlStr, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusNoContent {
+		return appscan.NewAPIError("create attribute definition", resp)
+	}
+	d.SetId(name)
+	client.Summary.record("attribute_definition_created", "appscan_attribute_definition", name, nil)
+	return resourceAppScanAttributeDefinitionRead(ctx, d, m)
+}
+
+func resourceAppScanAttributeDefinitionRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	orgID, err := organizationID(ctx, client)
+	if err != nil {
+		return err
+	}
+	urlStr := fmt.Sprintf("%s/CustomFields/organization/%s/CustomFields", client.ApiBase, url.PathEscape(orgID))
+	fields, err := getCustomFields(ctx, client, urlStr, "read attribute definitions")
+	if err != nil {
+		return err
+	}
+	for _, f := range fields {
+		if f.ColumnName == d.Id() {
+			d.Set("name", f.ColumnName)
+			d.Set("value_type", f.ValueType)
+			d.Set("default_value", f.DefaultValue)
+			return nil
+		}
+	}
+	d.SetId("")
+	return nil
+}
+
+// resourceAppScanAttributeDefinitionDelete only removes the definition from
+// the state: the API cannot delete definitions.
+func resourceAppScanAttributeDefinitionDelete(d *schema.ResourceData, m interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+// organizationID returns the ID of the organization custom fields are
+// defined for, which is the tenant.
+func organizationID(ctx context.Context, client *AppScanClient) (string, error) {
+	tenant, _, message := checkTenant(ctx, client)
+	if tenant == nil {
+		return "", errors.New(message)
+	}
+	return tenant.TenantId, nil
+}
+
+// getCustomFields fetches a list of custom fields. A 404 means no field is
+// defined.
+func getCustomFields(ctx context.Context, client *AppScanClient, urlStr, action string) ([]appScanCustomField, error) {
+	req, err := client.newRequest(ctx, "GET", urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, appscan.APIErrorFromBody(action, resp, respBody)
+	}
+	var fields []appScanCustomField
+	if err := appscan.DecodeJSON(resp, respBody, &fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// getApplicationAttributes returns the custom field values of an application.
+func getApplicationAttributes(ctx context.Context, client *AppScanClient, appID string) (map[string]string, error) {
+	urlStr := fmt.Sprintf("%s/CustomFields/application/%s/CustomFields", client.ApiBase, url.PathEscape(appID))
+	fields, err := getCustomFields(ctx, client, urlStr, "read application attributes")
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(fields))
+	for _, f := range fields {
+		values[f.ColumnName] = f.Value
+	}
+	return values, nil
+}
+
+// setApplicationAttribute sets the value of a custom field of an
+// application. The field must be defined for the organization.
+func setApplicationAttribute(ctx context.Context, client *AppScanClient, appID, name, value string) error {
+	body, err := json.Marshal(map[string]string{
+		"ColumnName": name,
+		"Value":      value,
+	})
+	if err != nil {
+		return err
+	}
+	urlStr := fmt.Sprintf("%s/CustomFields/Apps/%s/CustomFields", client.ApiBase, url.PathEscape(appID))
+	req, err := client.newRequest(ctx, "POST", urlStr, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return appscan.NewAPIError(fmt.Sprintf("set application attribute %s", name), resp)
+	}
+	return nil
+}
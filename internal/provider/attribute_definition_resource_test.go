@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccAttributeDefinitionResource(t *testing.T) {
+	m := newMockServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAttributeConfig(m, `
+    owner = "team-payments"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_attribute_definition.owner", "id", "owner"),
+					resource.TestCheckResourceAttr("appscan_attribute_definition.tier", "default_value", "3"),
+					resource.TestCheckResourceAttr("appscan_application.test", "attributes.%", "1"),
+					resource.TestCheckResourceAttr("appscan_application.test", "attributes.owner", "team-payments"),
+				),
+			},
+			{
+				Config: testAccAttributeConfig(m, `
+    owner = "team-billing"
+    tier  = "1"
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_application.test", "attributes.%", "2"),
+					resource.TestCheckResourceAttr("appscan_application.test", "attributes.owner", "team-billing"),
+					resource.TestCheckResourceAttr("appscan_application.test", "attributes.tier", "1"),
+				),
+			},
+			{
+				// Removing an attribute empties its value.
+				Config: testAccAttributeConfig(m, `
+    tier = "1"
+`),
+				Check: func(*terraform.State) error {
+					m.mu.Lock()
+					defer m.mu.Unlock()
+					for _, values := range m.collections["AppCustomFields"] {
+						if values["owner"] != "" {
+							return fmt.Errorf("owner not emptied: %v", values["owner"])
+						}
+					}
+					return nil
+				},
+			},
+			{
+				ResourceName:      "appscan_attribute_definition.tier",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccAttributeConfig(m *mockServer, attributes string) string {
+	return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_attribute_definition" "owner" {
+  name = "owner"
+}
+
+resource "appscan_attribute_definition" "tier" {
+  name          = "tier"
+  default_value = "3"
+}
+
+resource "appscan_application" "test" {
+  name           = "payments"
+  asset_group_id = %q
+  attributes = {
+%s  }
+
+  depends_on = [appscan_attribute_definition.owner, appscan_attribute_definition.tier]
+}
+`, mockAssetGroupID, attributes)
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ----------------------------------------------------------------
+// Data Source: appscan_auth_token (bearer token of the provider, e.g. for local-exec)
+// ----------------------------------------------------------------
+
+// The plugin SDK has no ephemeral resources, so the token is exposed by a
+// data source and ends up in the state, like any sensitive attribute. It is
+// the token the provider logged in with, which expires on its own.
+
+func dataSourceAuthToken() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAuthTokenRead,
+		Description: "Exposes the bearer token the provider authenticated with, so that provisioners and other providers can call the AppScan API without their own copy of the credentials, " +
+			"e.g. `curl -H \"Authorization: Bearer $TOKEN\" \"$API_BASE/Apps\"` from local-exec. " +
+			"The token is stored in the state: keep the state protected, as for any sensitive attribute.",
+		Schema: map[string]*schema.Schema{
+			"token": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The bearer token, obtained through ApiKeyLogin, or the bearer_token of the provider.",
+			},
+			"expires_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "When the token expires, as returned by ApiKeyLogin. Empty when the provider is configured with a bearer_token.",
+			},
+			"api_base": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL API paths are relative to, e.g. https://cloud.appscan.com/api/v4.",
+			},
+		},
+	}
+}
+
+func dataSourceAuthTokenRead(d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	d.Set("token", client.ApiToken)
+	d.Set("expires_at", client.ApiTokenExpiry)
+	d.Set("api_base", client.ApiBase)
+	d.SetId(client.ApiEndpoint)
+	return nil
+}
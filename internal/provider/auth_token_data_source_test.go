@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccAuthTokenDataSource(t *testing.T) {
+	m := newMockServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + `
+data "appscan_auth_token" "test" {}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_auth_token.test", "token", mockToken),
+					resource.TestCheckResourceAttr("data.appscan_auth_token.test", "expires_at", mockTokenExpiry),
+					resource.TestCheckResourceAttr("data.appscan_auth_token.test", "api_base", m.URL+"/api/v4"),
+				),
+			},
+			{
+				// A bearer_token has no known expiry.
+				Config: fmt.Sprintf(`
+provider "appscan" {
+  api_endpoint = %q
+  bearer_token = %q
+}
+
+data "appscan_auth_token" "test" {}
+`, m.URL, mockToken),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_auth_token.test", "token", mockToken),
+					resource.TestCheckResourceAttr("data.appscan_auth_token.test", "expires_at", ""),
+				),
+			},
+		},
+	})
+}
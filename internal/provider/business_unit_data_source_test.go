@@ -0,0 +1,28 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccBusinessUnitDataSource(t *testing.T) {
+	m := newMockServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + `
+data "appscan_business_unit" "test" {
+  name = "Default Business Unit"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_business_unit.test", "id", mockBusinessUnitID),
+					resource.TestCheckResourceAttr("data.appscan_business_unit.test", "description", "The default business unit"),
+				),
+			},
+		},
+	})
+}
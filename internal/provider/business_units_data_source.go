@@ -0,0 +1,111 @@
+package provider
+
+import (
+	"context"
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ----------------------------------------------------------------
+// Data Source: appscan_business_units (list, e.g. for for_each)
+// ----------------------------------------------------------------
+
+func dataSourceBusinessUnits() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"name_contains": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "If provided, only business units whose name contains this value are returned.",
+		},
+		"name_starts_with": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "If provided, only business units whose name starts with this value are returned.",
+		},
+		"business_units": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The business units, sorted by name unless order_by is set.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The unique identifier of the business unit.",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the business unit.",
+					},
+					"description": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The description of the business unit.",
+					},
+				},
+			},
+		},
+		"ids": {
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Description: "The IDs of the business units, keyed by name, e.g. for for_each. When names are not unique, one of them wins.",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+	}
+	for k, v := range listQuerySchema() {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		ReadWithoutTimeout: withContext(dataSourceBusinessUnitsRead),
+		Schema:             s,
+	}
+}
+
+func dataSourceBusinessUnitsRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	var clauses []string
+	for _, f := range [][2]string{{"name_contains", "contains"}, {"name_starts_with", "startswith"}} {
+		value := d.Get(f[0]).(string)
+		if value == "" {
+			continue
+		}
+		clause, err := appscan.ODataStringFunc(f[1], "Name", value)
+		if err != nil {
+			return err
+		}
+		clauses = append(clauses, clause)
+	}
+	query := listQueryFor(d).values(appscan.ODataAnd(clauses...), "Name", "Id", "Name")
+
+	units, err := listCatalog(ctx, client, "BusinessUnits", query)
+	if err != nil {
+		return err
+	}
+
+	list := make([]interface{}, len(units))
+	ids := make(map[string]interface{}, len(units))
+	for i, bu := range units {
+		list[i] = map[string]interface{}{
+			"id":          bu.Id,
+			"name":        bu.Name,
+			"description": bu.Description,
+		}
+		ids[bu.Name] = bu.Id
+	}
+	if err := d.Set("business_units", list); err != nil {
+		return err
+	}
+	if err := d.Set("ids", ids); err != nil {
+		return err
+	}
+
+	if filter := query.Get("$filter"); filter != "" {
+		d.SetId(filter)
+	} else {
+		d.SetId(client.ApiEndpoint)
+	}
+	return nil
+}
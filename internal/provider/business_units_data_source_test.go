@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccBusinessUnitsDataSource(t *testing.T) {
+	m := newMockServer(t)
+	m.add("BusinessUnits", mockEntity{"Id": "44444444-4444-4444-4444-444444444444", "Name": "Retail Banking", "Description": nil})
+	m.add("BusinessUnits", mockEntity{"Id": "55555555-5555-5555-5555-555555555555", "Name": "Investment Banking", "Description": "Markets"})
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + `
+data "appscan_business_units" "all" {}
+
+data "appscan_business_units" "banking" {
+  name_contains = "banking"
+}
+
+data "appscan_business_units" "retail" {
+  name_contains    = "Banking"
+  name_starts_with = "Retail"
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_business_units.all", "business_units.#", "3"),
+					resource.TestCheckResourceAttr("data.appscan_business_units.all", "business_units.0.name", "Default Business Unit"),
+					resource.TestCheckResourceAttr("data.appscan_business_units.all", "ids.Default Business Unit", mockBusinessUnitID),
+					resource.TestCheckResourceAttr("data.appscan_business_units.banking", "business_units.#", "2"),
+					resource.TestCheckResourceAttr("data.appscan_business_units.banking", "business_units.0.name", "Investment Banking"),
+					resource.TestCheckResourceAttr("data.appscan_business_units.banking", "business_units.0.description", "Markets"),
+					resource.TestCheckResourceAttr("data.appscan_business_units.retail", "business_units.#", "1"),
+					resource.TestCheckResourceAttr("data.appscan_business_units.retail", "ids.Retail Banking", "44444444-4444-4444-4444-444444444444"),
+				),
+			},
+		},
+	})
+}
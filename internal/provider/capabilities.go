@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+)
+
+// AppScan on Cloud, AppScan 360° and older revisions of the API differ in
+// details the provider depends on. Differences visible in each response,
+// such as the Items or value envelope of OData pages, are handled where the
+// response is decoded. The others are probed the first time they matter and
+// the result is kept for the rest of the run, so that each probe costs at
+// most one request per provider configuration.
+
+// capabilityODataCount is whether OData collections honor $count=true. When
+// they do not, entries are counted by paging through them.
+const capabilityODataCount = "odata_count"
+
+// capabilityGUIDLiteral is whether OData filters accept GUID literals, as
+// in `Id eq 11111111-2222-3333-4444-555555555555`. Some tenants reject them
+// with a 400 and only match GUIDs quoted as strings.
+const capabilityGUIDLiteral = "odata_guid_literal"
+
+// capabilityProbes are the probes of the capabilities, by name. A probe
+// returns an error only when it cannot tell, e.g. because the API is
+// unreachable; the result is then not cached.
+var capabilityProbes = map[string]func(context.Context, *AppScanClient) (bool, error){
+	capabilityODataCount:  probeODataCount,
+	capabilityGUIDLiteral: probeGUIDLiteral,
+}
+
+// apiCapabilities caches the results of the capability probes.
+type apiCapabilities struct {
+	mu      sync.Mutex
+	results map[string]bool
+}
+
+// supports reports whether the API has a capability, probing it on first use.
+func (c *AppScanClient) supports(ctx context.Context, capability string) (bool, error) {
+	probe, ok := capabilityProbes[capability]
+	if !ok {
+		return false, fmt.Errorf("unknown API capability %q", capability)
+	}
+
+	c.capabilities.mu.Lock()
+	defer c.capabilities.mu.Unlock()
+	if supported, ok := c.capabilities.results[capability]; ok {
+		return supported, nil
+	}
+	supported, err := probe(ctx, c)
+	if err != nil {
+		return false, fmt.Errorf("cannot probe API capability %s: %w", capability, err)
+	}
+	if c.capabilities.results == nil {
+		c.capabilities.results = map[string]bool{}
+	}
+	c.capabilities.results[capability] = supported
+	log.Printf("[INFO] API capability %s: %t", capability, supported)
+	return supported, nil
+}
+
+// probeCapabilities probes every capability and returns the results.
+func probeCapabilities(ctx context.Context, client *AppScanClient) (map[string]bool, error) {
+	names := make([]string, 0, len(capabilityProbes))
+	for name := range capabilityProbes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	results := map[string]bool{}
+	for _, name := range names {
+		supported, err := client.supports(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		results[name] = supported
+	}
+	return results, nil
+}
+
+// probeODataCount asks the AssetGroups collection, which every tenant can
+// list, for one entry and its count, and checks whether the count came back.
+func probeODataCount(ctx context.Context, client *AppScanClient) (bool, error) {
+	urlStr := fmt.Sprintf("%s/AssetGroups?$top=1&$count=true", client.ApiBase)
+	req, err := client.newRequest(ctx, "GET", urlStr, nil)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, err
+	}
+	if resp.StatusCode == http.StatusBadRequest {
+		// Rejecting the query option is as good an answer as ignoring it.
+		return false, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, appscan.APIErrorFromBody("list AssetGroups", resp, respBody)
+	}
+
+	var page map[string]json.RawMessage
+	if trimmed := bytes.TrimSpace(respBody); len(trimmed) > 0 && trimmed[0] == '[' {
+		return false, nil
+	}
+	if err := appscan.DecodeJSON(resp, respBody, &page); err != nil {
+		return false, err
+	}
+	for _, key := range []string{"Count", "@odata.count"} {
+		if v, ok := page[key]; ok && string(v) != "null" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// probeGUIDLiteral filters the AssetGroups collection by a GUID literal
+// matching no asset group, and checks whether the filter was accepted.
+func probeGUIDLiteral(ctx context.Context, client *AppScanClient) (bool, error) {
+	query := url.Values{}
+	query.Set("$filter", "Id eq 00000000-0000-0000-0000-000000000000")
+	query.Set("$top", "1")
+	urlStr := fmt.Sprintf("%s/AssetGroups?%s", client.ApiBase, query.Encode())
+	req, err := client.newRequest(ctx, "GET", urlStr, nil)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusBadRequest:
+		return false, nil
+	}
+	return false, appscan.NewAPIError("list AssetGroups", resp)
+}
@@ -0,0 +1,208 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ----------------------------------------------------------------
+// Data Source: appscan_compliance (policy gate for an application, for check blocks)
+// ----------------------------------------------------------------
+
+// complianceSeverities are the severities max_open_issues limits, in
+// decreasing order, with the number of open issues of the application.
+var complianceSeverities = []struct {
+	name string
+	open func(*appScanComplianceApp) int
+}{
+	{"critical", func(a *appScanComplianceApp) int { return a.CriticalIssues }},
+	{"high", func(a *appScanComplianceApp) int { return a.HighIssues }},
+	{"medium", func(a *appScanComplianceApp) int { return a.MediumIssues }},
+	{"low", func(a *appScanComplianceApp) int { return a.LowIssues }},
+	{"informational", func(a *appScanComplianceApp) int { return a.InformationalIssues }},
+}
+
+func dataSourceCompliance() *schema.Resource {
+	thresholds := map[string]*schema.Schema{}
+	for _, s := range complianceSeverities {
+		thresholds[s.name] = &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      -1,
+			ValidateFunc: validation.IntAtLeast(-1),
+			Description:  fmt.Sprintf("The maximum number of open %s issues. Defaults to -1, no limit.", s.name),
+		}
+	}
+
+	return &schema.Resource{
+		ReadWithoutTimeout: withContext(dataSourceComplianceRead),
+		Schema: map[string]*schema.Schema{
+			"application_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The ID of the application to evaluate.",
+				ValidateFunc: validateGUID,
+			},
+			"policy_names": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The names of the policies the application must comply with, each associated with the application. By default, every enabled policy associated with it, unless max_open_issues is set.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"max_open_issues": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Limits on the number of open issues of the application, per severity, e.g. `high = 0` for no open high severity issues.",
+				Elem:        &schema.Resource{Schema: thresholds},
+			},
+			"compliant": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the application complies with the policies and limits evaluated, i.e. violations is empty.",
+			},
+			"violations": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Why the application does not comply, one entry per policy or limit violated.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"policies": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The policies evaluated.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the policy.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the policy.",
+						},
+						"category": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The category of the policy: Custom, Security, Regulation or IndustryStandard.",
+						},
+						"compliant": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the application complies with the policy.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// appScanComplianceApp holds the ApplicationModel fields the compliance
+// evaluation relies on.
+type appScanComplianceApp struct {
+	ComplianceStatuses  []appScanComplianceStatus `json:"ComplianceStatuses"`
+	CriticalIssues      int                       `json:"CriticalIssues"`
+	HighIssues          int                       `json:"HighIssues"`
+	MediumIssues        int                       `json:"MediumIssues"`
+	LowIssues           int                       `json:"LowIssues"`
+	InformationalIssues int                       `json:"InformationalIssues"`
+}
+
+// appScanComplianceStatus holds the ComplianceStatus fields the provider
+// relies on.
+type appScanComplianceStatus struct {
+	PolicyId  string `json:"PolicyId"`
+	Name      string `json:"Name"`
+	Enabled   bool   `json:"Enabled"`
+	Compliant bool   `json:"Compliant"`
+	Category  string `json:"Category"`
+}
+
+func dataSourceComplianceRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	appID := d.Get("application_id").(string)
+
+	filterQuery, err := client.odataEqID(ctx, "Id", appID)
+	if err != nil {
+		return err
+	}
+	query := url.Values{}
+	query.Set("$filter", filterQuery)
+	var result struct {
+		Items []appScanComplianceApp `json:"Items"`
+	}
+	if err := getODataPage(ctx, client, "Apps", query, &result); err != nil {
+		return err
+	}
+	if len(result.Items) == 0 {
+		return fmt.Errorf("no application found with id: %s", appID)
+	}
+	app := &result.Items[0]
+	statuses := app.ComplianceStatuses
+
+	var thresholds map[string]interface{}
+	if v := d.Get("max_open_issues").([]interface{}); len(v) > 0 && v[0] != nil {
+		thresholds = v[0].(map[string]interface{})
+	}
+
+	var evaluated []appScanComplianceStatus
+	if names := d.Get("policy_names").([]interface{}); len(names) > 0 {
+		for _, n := range names {
+			name, _ := n.(string)
+			i := slices.IndexFunc(statuses, func(s appScanComplianceStatus) bool { return s.Name == name })
+			if i < 0 {
+				return fmt.Errorf("policy %q is not associated with application %s", name, appID)
+			}
+			evaluated = append(evaluated, statuses[i])
+		}
+	} else if thresholds == nil {
+		for _, s := range statuses {
+			if s.Enabled {
+				evaluated = append(evaluated, s)
+			}
+		}
+		sort.SliceStable(evaluated, func(i, j int) bool { return evaluated[i].Name < evaluated[j].Name })
+	}
+
+	violations := []string{}
+	policies := make([]interface{}, len(evaluated))
+	for i, s := range evaluated {
+		if !s.Compliant {
+			violations = append(violations, fmt.Sprintf("does not comply with policy %q", s.Name))
+		}
+		policies[i] = map[string]interface{}{
+			"id":        s.PolicyId,
+			"name":      s.Name,
+			"category":  s.Category,
+			"compliant": s.Compliant,
+		}
+	}
+	for _, s := range complianceSeverities {
+		limit, ok := thresholds[s.name].(int)
+		if !ok || limit < 0 {
+			continue
+		}
+		if open := s.open(app); open > limit {
+			violations = append(violations, fmt.Sprintf("has %d open %s issues, more than %d", open, s.name, limit))
+		}
+	}
+
+	if err := d.Set("policies", policies); err != nil {
+		return err
+	}
+	if err := d.Set("violations", violations); err != nil {
+		return err
+	}
+	d.Set("compliant", len(violations) == 0)
+	d.SetId(appID)
+	return nil
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccComplianceDataSource(t *testing.T) {
+	m := newMockServer(t)
+	app := m.add("Apps", mockEntity{
+		"Name":           "payments",
+		"CriticalIssues": 0,
+		"HighIssues":     2,
+		"ComplianceStatuses": []mockEntity{
+			{"PolicyId": "9f6c2a4e-1b3d-4e5f-8a7b-0c1d2e3f4a5b", "Name": "OWASP Top 10 2021", "Enabled": true, "Compliant": true, "Category": "IndustryStandard"},
+			{"PolicyId": "5a4b3c2d-1e0f-4a9b-8c7d-6e5f4a3b2c1d", "Name": "PCI", "Enabled": true, "Compliant": false, "Category": "Regulation"},
+			{"PolicyId": "0e1d2c3b-4a5f-4e6d-9c8b-7a6f5e4d3c2b", "Name": "HIPAA", "Enabled": false, "Compliant": false, "Category": "Regulation"},
+		},
+	})
+	config := func(arguments string) string {
+		return testAccProviderConfig(m) + fmt.Sprintf(`
+data "appscan_compliance" "test" {
+  application_id = %q
+%s
+}
+`, app["Id"], arguments)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_compliance.test", "compliant", "false"),
+					resource.TestCheckResourceAttr("data.appscan_compliance.test", "policies.#", "2"),
+					resource.TestCheckResourceAttr("data.appscan_compliance.test", "policies.0.name", "OWASP Top 10 2021"),
+					resource.TestCheckResourceAttr("data.appscan_compliance.test", "policies.1.compliant", "false"),
+					resource.TestCheckResourceAttr("data.appscan_compliance.test", "violations.#", "1"),
+					resource.TestCheckResourceAttr("data.appscan_compliance.test", "violations.0", `does not comply with policy "PCI"`),
+				),
+			},
+			{
+				Config: config(`  policy_names = ["OWASP Top 10 2021"]`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_compliance.test", "compliant", "true"),
+					resource.TestCheckResourceAttr("data.appscan_compliance.test", "violations.#", "0"),
+				),
+			},
+			{
+				Config: config(`
+  max_open_issues {
+    critical = 0
+    high     = 0
+  }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_compliance.test", "compliant", "false"),
+					resource.TestCheckResourceAttr("data.appscan_compliance.test", "policies.#", "0"),
+					resource.TestCheckResourceAttr("data.appscan_compliance.test", "violations.#", "1"),
+					resource.TestCheckResourceAttr("data.appscan_compliance.test", "violations.0", "has 2 open high issues, more than 0"),
+				),
+			},
+			{
+				Config:      config(`  policy_names = ["SOX"]`),
+				ExpectError: regexp.MustCompile(`policy "SOX" is not associated with application`),
+			},
+		},
+	})
+}
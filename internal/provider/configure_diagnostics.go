@@ -0,0 +1,149 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+)
+
+// The failures of configureClient are reported as diagnostics on the
+// provider argument at fault, telling a wrong endpoint from rejected
+// credentials and from a network failure, rather than as the bare error of
+// the request that failed.
+
+// configureError is a failure to configure the provider blamed on one of its
+// arguments, attr, or on none when it is empty.
+type configureError struct {
+	attr    string
+	summary string
+	detail  string
+}
+
+func (e *configureError) Error() string {
+	return fmt.Sprintf("%s: %s", e.summary, e.detail)
+}
+
+// configureDiagnostics converts an error of configureClient to diagnostics.
+func configureDiagnostics(err error) diag.Diagnostics {
+	var cfgErr *configureError
+	if !errors.As(err, &cfgErr) {
+		return diag.FromErr(err)
+	}
+	d := diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  cfgErr.summary,
+		Detail:   cfgErr.detail,
+	}
+	if cfgErr.attr != "" {
+		d.AttributePath = cty.GetAttrPath(cfgErr.attr)
+	}
+	return diag.Diagnostics{d}
+}
+
+// checkCredentialsFormat catches the credentials that cannot be right before
+// they are sent: pasted with surrounding whitespace or a line break.
+func checkCredentialsFormat(keyID, keySecret string) error {
+	for _, c := range []struct{ attr, value string }{{"key_id", keyID}, {"key_secret", keySecret}} {
+		if c.value != strings.TrimSpace(c.value) {
+			return &configureError{
+				attr:    c.attr,
+				summary: fmt.Sprintf("Invalid %s", c.attr),
+				detail:  fmt.Sprintf("%s has leading or trailing whitespace, typically a line break pasted along with it. Remove it.", c.attr),
+			}
+		}
+	}
+	return nil
+}
+
+// diagnoseRequestError classifies the failure err of a request sent to
+// endpoint while configuring the provider. Rejections are blamed on
+// credentials, the arguments the request authenticates with. Errors it
+// cannot classify are returned as is.
+func diagnoseRequestError(endpoint string, credentials []string, err error) error {
+	var apiErr *appscan.APIError
+	if errors.As(err, &apiErr) {
+		switch {
+		case apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden:
+			advice := fmt.Sprintf("Check %s: the token may be invalid or expired.", credentials[0])
+			if len(credentials) > 1 {
+				advice = fmt.Sprintf("The API does not tell which of %s is wrong: check that they belong to the same key, and that the key was not regenerated or revoked, nor its user disabled.", strings.Join(credentials, " and "))
+			}
+			return &configureError{
+				attr:    credentials[0],
+				summary: "AppScan rejected the credentials",
+				detail:  fmt.Sprintf("%s\n\n%s", apiErr, advice),
+			}
+		case apiErr.StatusCode == http.StatusNotFound || apiErr.StatusCode == http.StatusMethodNotAllowed:
+			return &configureError{
+				attr:    "api_endpoint",
+				summary: "api_endpoint does not serve the AppScan API",
+				detail:  fmt.Sprintf("%s\n\nCheck api_endpoint (%s), api_path_prefix and api_version.", apiErr, endpoint),
+			}
+		case apiErr.StatusCode >= 500:
+			return &configureError{
+				summary: "AppScan API unavailable",
+				detail:  fmt.Sprintf("%s\n\nThe API at %s may be down or under maintenance; try again later.", apiErr, endpoint),
+			}
+		}
+		return err
+	}
+
+	var dnsErr *net.DNSError
+	var certErr *tls.CertificateVerificationError
+	var unknownAuthority x509.UnknownAuthorityError
+	var opErr *net.OpError
+	switch {
+	case errors.As(err, &dnsErr):
+		return &configureError{
+			attr:    "api_endpoint",
+			summary: "Cannot resolve the host of api_endpoint",
+			detail:  fmt.Sprintf("%s\n\nCheck api_endpoint (%s), and the DNS and proxy_url settings of this machine.", err, endpoint),
+		}
+	case errors.As(err, &certErr) || errors.As(err, &unknownAuthority):
+		return &configureError{
+			attr:    "api_endpoint",
+			summary: "The certificate of api_endpoint is not trusted",
+			detail:  fmt.Sprintf("%s\n\nWhen a proxy or an internal CA signs it, set ca_cert_file or ca_cert_pem.", err),
+		}
+	case errors.Is(err, syscall.ECONNREFUSED) || isTransientNetworkError(err) || errors.As(err, &opErr):
+		return &configureError{
+			attr:    "api_endpoint",
+			summary: "Cannot reach the AppScan API",
+			detail:  fmt.Sprintf("%s\n\nCheck api_endpoint (%s), proxy_url and the network access of this machine.", err, endpoint),
+		}
+	}
+	return err
+}
+
+// pingAPI reads the tenant information, the lightest authenticated request
+// of the API, to check the configuration of c.
+func pingAPI(ctx context.Context, c *AppScanClient) error {
+	urlStr := fmt.Sprintf("%s/Account/TenantInfo", c.ApiBase)
+	req, err := c.newRequest(ctx, "GET", urlStr, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return appscan.NewAPIError("read tenant information", resp)
+	}
+	_, err = io.ReadAll(resp.Body)
+	return err
+}
@@ -0,0 +1,202 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ----------------------------------------------------------------
+// Data Source: appscan_counts (tenant-level snapshot for executive summaries)
+// ----------------------------------------------------------------
+
+func dataSourceCounts() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: withContext(dataSourceCountsRead),
+		Schema: map[string]*schema.Schema{
+			"total_applications": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of applications of the tenant.",
+			},
+			"total_scans": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of scans of the tenant.",
+			},
+			"scans_this_month": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of scans whose latest execution started this month (UTC).",
+			},
+			"open_issues": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The number of open issues across all applications, per severity.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"total": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The total number of open issues.",
+						},
+						"critical": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of open critical issues.",
+						},
+						"high": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of open high severity issues.",
+						},
+						"medium": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of open medium severity issues.",
+						},
+						"low": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of open low severity issues.",
+						},
+						"informational": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of open informational issues.",
+						},
+					},
+				},
+			},
+			"as_of": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the snapshot was taken (RFC 3339).",
+			},
+		},
+	}
+}
+
+func dataSourceCountsRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	now := time.Now().UTC()
+
+	// Issue counts are only available per application, so the applications
+	// are paged through and their counts summed.
+	type appCounts struct {
+		CriticalIssues      int `json:"CriticalIssues"`
+		HighIssues          int `json:"HighIssues"`
+		MediumIssues        int `json:"MediumIssues"`
+		LowIssues           int `json:"LowIssues"`
+		InformationalIssues int `json:"InformationalIssues"`
+	}
+	var apps int
+	var sum appCounts
+	for skip := 0; ; skip += catalogPageSize {
+		query := url.Values{}
+		query.Set("$select", "Id,CriticalIssues,HighIssues,MediumIssues,LowIssues,InformationalIssues")
+		query.Set("$top", strconv.Itoa(catalogPageSize))
+		query.Set("$skip", strconv.Itoa(skip))
+
+		var result struct {
+			Items []appCounts `json:"Items"`
+		}
+		if err := getODataPage(ctx, client, "Apps", query, &result); err != nil {
+			return err
+		}
+		for _, a := range result.Items {
+			sum.CriticalIssues += a.CriticalIssues
+			sum.HighIssues += a.HighIssues
+			sum.MediumIssues += a.MediumIssues
+			sum.LowIssues += a.LowIssues
+			sum.InformationalIssues += a.InformationalIssues
+		}
+		apps += len(result.Items)
+		if len(result.Items) < catalogPageSize {
+			break
+		}
+	}
+
+	totalScans, err := countOData(ctx, client, "Scans", "")
+	if err != nil {
+		return err
+	}
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	scansThisMonth, err := countOData(ctx, client, "Scans", "LatestExecution/CreatedAt ge "+monthStart.Format(time.RFC3339))
+	if err != nil {
+		return err
+	}
+
+	d.Set("total_applications", apps)
+	d.Set("total_scans", totalScans)
+	d.Set("scans_this_month", scansThisMonth)
+	if err := d.Set("open_issues", []interface{}{
+		map[string]interface{}{
+			"total":         sum.CriticalIssues + sum.HighIssues + sum.MediumIssues + sum.LowIssues + sum.InformationalIssues,
+			"critical":      sum.CriticalIssues,
+			"high":          sum.HighIssues,
+			"medium":        sum.MediumIssues,
+			"low":           sum.LowIssues,
+			"informational": sum.InformationalIssues,
+		},
+	}); err != nil {
+		return err
+	}
+	d.Set("as_of", now.Format(time.RFC3339))
+
+	d.SetId(client.ApiEndpoint)
+	return nil
+}
+
+// countOData returns the number of entries of an OData collection matching
+// filter, through $count, without fetching them. When the API does not honor
+// $count, the entries are paged through and counted.
+func countOData(ctx context.Context, client *AppScanClient, collection, filter string) (int, error) {
+	query := url.Values{}
+	if filter != "" {
+		query.Set("$filter", filter)
+	}
+	supported, err := client.supports(ctx, capabilityODataCount)
+	if err != nil {
+		return 0, err
+	}
+	if !supported {
+		return countODataPages(ctx, client, collection, query)
+	}
+	query.Set("$top", "1")
+	query.Set("$count", "true")
+
+	var result struct {
+		Count int `json:"Count"`
+	}
+	if err := getODataPage(ctx, client, collection, query, &result); err != nil {
+		return 0, err
+	}
+	return result.Count, nil
+}
+
+// countODataPages counts the entries of an OData collection matching query by
+// fetching their IDs, a page at a time.
+func countODataPages(ctx context.Context, client *AppScanClient, collection string, query url.Values) (int, error) {
+	query.Set("$select", "Id")
+	count := 0
+	for skip := 0; ; skip += catalogPageSize {
+		query.Set("$top", strconv.Itoa(catalogPageSize))
+		query.Set("$skip", strconv.Itoa(skip))
+
+		var result struct {
+			Items []json.RawMessage `json:"Items"`
+		}
+		if err := getODataPage(ctx, client, collection, query, &result); err != nil {
+			return 0, err
+		}
+		count += len(result.Items)
+		if len(result.Items) < catalogPageSize {
+			return count, nil
+		}
+	}
+}
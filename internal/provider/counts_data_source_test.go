@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccCountsDataSource(t *testing.T) {
+	m := newMockServer(t)
+	m.add("Apps", mockEntity{"Name": "payments", "CriticalIssues": 1, "HighIssues": 2, "LowIssues": 4})
+	m.add("Apps", mockEntity{"Name": "billing", "HighIssues": 3, "InformationalIssues": 5})
+	now := time.Now().UTC().Format(time.RFC3339)
+	m.add("Scans", mockEntity{"Name": "nightly", "LatestExecution": mockEntity{"CreatedAt": now}})
+	m.add("Scans", mockEntity{"Name": "main", "LatestExecution": mockEntity{"CreatedAt": now}})
+	m.add("Scans", mockEntity{"Name": "legacy", "LatestExecution": mockEntity{"CreatedAt": "2020-01-15T00:00:00Z"}})
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + `
+data "appscan_counts" "test" {}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_counts.test", "total_applications", "2"),
+					resource.TestCheckResourceAttr("data.appscan_counts.test", "total_scans", "3"),
+					resource.TestCheckResourceAttr("data.appscan_counts.test", "scans_this_month", "2"),
+					resource.TestCheckResourceAttr("data.appscan_counts.test", "open_issues.0.total", "15"),
+					resource.TestCheckResourceAttr("data.appscan_counts.test", "open_issues.0.critical", "1"),
+					resource.TestCheckResourceAttr("data.appscan_counts.test", "open_issues.0.high", "5"),
+					resource.TestCheckResourceAttr("data.appscan_counts.test", "open_issues.0.medium", "0"),
+					resource.TestCheckResourceAttr("data.appscan_counts.test", "open_issues.0.informational", "5"),
+					resource.TestCheckResourceAttrSet("data.appscan_counts.test", "as_of"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccCountsDataSource_noCount(t *testing.T) {
+	m := newMockServer(t)
+	m.noCount = true
+	for i := 0; i < catalogPageSize+1; i++ {
+		m.add("Scans", mockEntity{"Name": fmt.Sprintf("scan-%d", i), "LatestExecution": mockEntity{"CreatedAt": "2020-01-15T00:00:00Z"}})
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + `
+data "appscan_counts" "test" {}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_counts.test", "total_applications", "0"),
+					resource.TestCheckResourceAttr("data.appscan_counts.test", "total_scans", fmt.Sprint(catalogPageSize+1)),
+					resource.TestCheckResourceAttr("data.appscan_counts.test", "scans_this_month", "0"),
+				),
+			},
+		},
+	})
+}
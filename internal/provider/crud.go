@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// withContext adapts a CRUD function returning an error to the context-aware
+// functions of the SDK, so that its requests are cancelled when Terraform
+// stops the provider. It is registered as the WithoutTimeout variant: the
+// resources wait for scans and reports within their own timeouts. A
+// warning returned by f is reported as a warning diagnostic.
+func withContext(f func(context.Context, *schema.ResourceData, interface{}) error) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+		err := f(ctx, d, m)
+		var w warning
+		if errors.As(err, &w) {
+			return diag.Diagnostics{{Severity: diag.Warning, Summary: w.Error()}}
+		}
+		return diag.FromErr(err)
+	}
+}
+
+// warning is an error of a CRUD function that does not fail the operation,
+// such as the fail_on limits exceeded by a new scan, which a failed creation
+// would taint.
+type warning struct {
+	error
+}
@@ -0,0 +1,84 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// A DAST scan can be created from a scan (.scan) or scan template (.scant)
+// file exported from AppScan Standard. The file is uploaded first; the API
+// keeps it for uploadedFileLifetime and has no endpoint to read or delete
+// it, so the resource only tracks the content of the local file.
+
+// uploadedFileLifetime is how long the API keeps an uploaded file.
+const uploadedFileLifetime = 30 * time.Minute
+
+var scanFileRegexp = regexp.MustCompile(`(?i)\.scant?$`)
+
+func resourceAppScanDastScanConfig() *schema.Resource {
+	return &schema.Resource{
+		CreateWithoutTimeout: withContext(resourceAppScanDastScanConfigCreate),
+		Read:                 resourceAppScanDastScanConfigRead,
+		Update:               resourceAppScanDastScanConfigRead,
+		Delete:               resourceAppScanDastScanConfigDelete,
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The path of the .scan or .scant file, exported from AppScan Standard, to upload. The file is uploaded again when its content changes, not when only its path or modification time does.",
+				ValidateFunc: validation.StringMatch(scanFileRegexp, "must be a .scan or .scant file"),
+			},
+			"sha256": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA256 of the uploaded file.",
+			},
+			"file_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the uploaded file, to use as the `scan_file_id` of `appscan_dast_scan`.",
+			},
+			"expires_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date after which AppScan deletes the uploaded file, 30 minutes after the upload. Scans must be created from the file before then, typically in the same apply.",
+			},
+		},
+		CustomizeDiff: customizeDiffFileChecksum("path", "sha256"),
+	}
+}
+
+func resourceAppScanDastScanConfigCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	uploadedAt := time.Now().UTC()
+	fileID, checksum, err := uploadFile(ctx, client, d.Get("path").(string), "")
+	if err != nil {
+		return err
+	}
+	d.SetId(fileID)
+	d.Set("file_id", fileID)
+	d.Set("sha256", checksum)
+	d.Set("expires_at", uploadedAt.Add(uploadedFileLifetime).Format(time.RFC3339))
+	client.Summary.record("file_uploaded", "appscan_dast_scan_config", fileID, map[string]string{
+		"sha256": checksum,
+	})
+	return resourceAppScanDastScanConfigRead(d, m)
+}
+
+// resourceAppScanDastScanConfigRead has nothing to refresh: the API does not
+// return uploaded files.
+func resourceAppScanDastScanConfigRead(d *schema.ResourceData, m interface{}) error {
+	return nil
+}
+
+// resourceAppScanDastScanConfigDelete only forgets the file, which AppScan
+// deletes by itself.
+func resourceAppScanDastScanConfigDelete(d *schema.ResourceData, m interface{}) error {
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDastScanConfigResource(t *testing.T) {
+	m := newMockServer(t)
+	dir := t.TempDir()
+	writeScanFile := func(name, content string) func() {
+		return func() {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	var fileID, scanID string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDastScanConfigConfig(m, filepath.Join(dir, "site.xml")),
+				ExpectError: regexp.MustCompile(`must be a .scan or .scant file`),
+			},
+			{
+				PreConfig: writeScanFile("site.scant", "mock scan template"),
+				Config:    testAccDastScanConfigConfig(m, filepath.Join(dir, "site.scant")),
+				Check: resource.ComposeTestCheckFunc(
+					// sha256("mock scan template")
+					resource.TestCheckResourceAttr("appscan_dast_scan_config.test", "sha256", "3f14576ddae1a97099f33447bc92e43d7833ea661d4c6f18137f6dfeb4aad1cf"),
+					resource.TestCheckResourceAttrSet("appscan_dast_scan_config.test", "expires_at"),
+					resource.TestCheckResourceAttrPair("appscan_dast_scan.test", "scan_file_id", "appscan_dast_scan_config.test", "file_id"),
+					resource.TestCheckResourceAttr("appscan_dast_scan.test", "starting_url", mockScanFileStartingURL),
+					testAccSaveID("appscan_dast_scan_config.test", &fileID),
+					testAccSaveID("appscan_dast_scan.test", &scanID),
+				),
+			},
+			{
+				// Same content under another path: no new upload nor scan.
+				PreConfig: writeScanFile("renamed.scant", "mock scan template"),
+				Config:    testAccDastScanConfigConfig(m, filepath.Join(dir, "renamed.scant")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckID("appscan_dast_scan_config.test", &fileID, true),
+					testAccCheckID("appscan_dast_scan.test", &scanID, true),
+				),
+			},
+			{
+				PreConfig: writeScanFile("renamed.scant", "new mock scan template"),
+				Config:    testAccDastScanConfigConfig(m, filepath.Join(dir, "renamed.scant")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckID("appscan_dast_scan_config.test", &fileID, false),
+					testAccCheckID("appscan_dast_scan.test", &scanID, false),
+				),
+			},
+		},
+	})
+}
+
+func testAccDastScanConfigConfig(m *mockServer, path string) string {
+	return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_dast_scan_config" "test" {
+  path = %q
+}
+
+resource "appscan_dast_scan" "test" {
+  application_id      = %q
+  name                = "nightly"
+  scan_file_id        = appscan_dast_scan_config.test.file_id
+  wait_for_completion = false
+}
+`, path, mockApplicationID)
+}
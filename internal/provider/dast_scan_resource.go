@@ -0,0 +1,315 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// loginSequenceFileRegexp matches the login sequences recorded with AppScan
+// Activity Recorder (.login) or exported from AppScan Standard (.config).
+var loginSequenceFileRegexp = regexp.MustCompile(`(?i)\.(login|config)$`)
+
+func resourceAppScanDastScan() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"application_id": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			Description:  "The ID of the application the scan belongs to.",
+			ValidateFunc: validateGUID,
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The name of the scan. Changing it renames the scan in place.",
+		},
+		"starting_url": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			ForceNew:     true,
+			AtLeastOneOf: []string{"starting_url", "scan_file_id"},
+			Description:  "The URL the scan starts exploring from. Required unless `scan_file_id` is set, in which case it overrides the starting URL of the file.",
+		},
+		"scan_file_id": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Description:  "The ID of an uploaded scan or scan template file, usually the `file_id` of an `appscan_dast_scan_config`, the scan is configured from.",
+			ValidateFunc: validation.Any(validation.StringIsEmpty, validateGUID),
+		},
+		"presence_id": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Description:  "The ID of the AppScan Presence used to reach a private site.",
+			ValidateFunc: validation.Any(validation.StringIsEmpty, validateGUID),
+		},
+		"login_user": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			RequiredWith: []string{"login_password"},
+			Description:  "The user name used for automatic login. Requires login_password.",
+		},
+		"login_password": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Sensitive:    true,
+			RequiredWith: []string{"login_user"},
+			Description:  "The password used for automatic login. Requires login_user.",
+		},
+		"login_sequence_file": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			ConflictsWith: []string{"login_user", "login_password"},
+			Description:   "The path of a recorded login sequence (.login file of AppScan Activity Recorder, or .config file exported from AppScan Standard) to upload, for the sites automatic login cannot handle. A new scan is launched when the content of the file changes, not when only its path or modification time does.",
+			ValidateFunc:  validation.StringMatch(loginSequenceFileRegexp, "must be a .login or .config file"),
+		},
+		"login_sequence_file_sha256": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The SHA256 of the uploaded login sequence file.",
+		},
+		"login_type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "How the scan logs in: None, AutomaticLogin, LoginSequence...",
+		},
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The unique identifier of the scan.",
+		},
+	}
+	for k, v := range scanExecutionSchema() {
+		s[k] = v
+	}
+	for k, v := range scanDestroySchema() {
+		s[k] = v
+	}
+	for k, v := range scanMetadataSchema() {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		CreateWithoutTimeout: withContext(resourceAppScanDastScanCreate),
+		ReadWithoutTimeout:   withContext(resourceAppScanDastScanRead),
+		UpdateWithoutTimeout: withContext(resourceAppScanDastScanUpdate),
+		DeleteWithoutTimeout: withContext(resourceAppScanDastScanDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: importScan("Dast"),
+		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffFileChecksum("login_sequence_file", "login_sequence_file_sha256"),
+			customizeDiffRescan,
+			customizeDiffPublish,
+		),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(3 * time.Hour),
+		},
+		Schema: s,
+	}
+}
+
+// appScanDastScanRequest is the payload creating a DAST scan. The optional
+// fields are omitted when empty.
+type appScanDastScanRequest struct {
+	AppId                string                        `json:"AppId"`
+	ScanName             string                        `json:"ScanName"`
+	Personal             bool                          `json:"Personal"`
+	Execute              bool                          `json:"Execute"`
+	ScanConfiguration    *appScanDastScanConfiguration `json:"ScanConfiguration,omitempty"`
+	PresenceId           string                        `json:"PresenceId,omitempty"`
+	ScanOrTemplateFileId string                        `json:"ScanOrTemplateFileId,omitempty"`
+	LoginSequenceFileId  string                        `json:"LoginSequenceFileId,omitempty"`
+	appScanScanMetadata
+}
+
+// appScanDastScanConfiguration is the configuration of a new DAST scan.
+type appScanDastScanConfiguration struct {
+	Target *appScanDastTarget `json:"Target,omitempty"`
+	Login  *appScanDastLogin  `json:"Login,omitempty"`
+}
+
+// appScanDastTarget is the target of a new DAST scan.
+type appScanDastTarget struct {
+	StartingUrl string `json:"StartingUrl"`
+}
+
+// appScanDastLogin holds the credentials a new DAST scan logs in with.
+type appScanDastLogin struct {
+	UserName string `json:"UserName"`
+	Password string `json:"Password"`
+}
+
+func resourceAppScanDastScanCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	if err := checkPublish(d); err != nil {
+		return err
+	}
+	payload := appScanDastScanRequest{
+		AppId:                d.Get("application_id").(string),
+		ScanName:             d.Get("name").(string),
+		Personal:             d.Get("personal").(bool),
+		Execute:              true,
+		PresenceId:           d.Get("presence_id").(string),
+		ScanOrTemplateFileId: d.Get("scan_file_id").(string),
+		appScanScanMetadata:  scanCreationMetadata(d),
+	}
+	configuration := appScanDastScanConfiguration{}
+	if startingURL, ok := d.GetOk("starting_url"); ok {
+		configuration.Target = &appScanDastTarget{StartingUrl: startingURL.(string)}
+	}
+	if user, ok := d.GetOk("login_user"); ok {
+		configuration.Login = &appScanDastLogin{
+			UserName: user.(string),
+			Password: d.Get("login_password").(string),
+		}
+	}
+	if configuration != (appScanDastScanConfiguration{}) {
+		payload.ScanConfiguration = &configuration
+	}
+	checksum := ""
+	if path, ok := d.GetOk("login_sequence_file"); ok {
+		var err error
+		if payload.LoginSequenceFileId, checksum, err = uploadFile(ctx, client, path.(string), ""); err != nil {
+			return err
+		}
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/Scans/Dast", client.ApiBase)
+	req, err := client.newRequest(ctx, "POST", url, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return appscan.NewAPIError("create DAST scan", resp)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	var scan appScanScan
+	if err := appscan.DecodeJSON(resp, respBody, &scan); err != nil {
+		return err
+	}
+	if scan.Id == "" {
+		return fmt.Errorf("failed to retrieve scan ID from API response")
+	}
+	d.SetId(scan.Id)
+	if checksum != "" {
+		d.Set("login_sequence_file_sha256", checksum)
+	}
+	client.Summary.record("scan_launched", "appscan_dast_scan", scan.Id, map[string]string{
+		"application_id": d.Get("application_id").(string),
+		"name":           d.Get("name").(string),
+	})
+
+	var gate error
+	if d.Get("wait_for_completion").(bool) {
+		if err := waitForScanWithRetries(ctx, client, d, "Dast", scan.Id, scanExecution(d, "")); err != nil {
+			if gate = scanCreateWarning(d, err); gate == nil {
+				return err
+			}
+		}
+	}
+	if err := publishScan(ctx, client, d, "Dast"); err != nil {
+		return err
+	}
+	if err := resourceAppScanDastScanRead(ctx, d, m); err != nil {
+		return err
+	}
+	return gate
+}
+
+func resourceAppScanDastScanRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	scan, err := getScan(ctx, client, "Dast", d.Id())
+	if err != nil {
+		return err
+	}
+	if scan == nil {
+		d.SetId("")
+		return nil
+	}
+	d.Set("application_id", scan.AppId)
+	d.Set("name", scan.Name)
+	d.Set("personal", scan.IsPersonal)
+	d.Set("enable_mail_notification", scan.EnableMailNotification)
+	d.Set("login_type", scan.LoginConfigurationType)
+	if c := scan.ScanConfiguration; c != nil {
+		d.Set("starting_url", c.StartingUrl)
+		d.Set("login_user", c.LoginUser)
+	}
+	presenceID := ""
+	if scan.Presence != nil {
+		presenceID = scan.Presence.Id
+	}
+	d.Set("presence_id", presenceID)
+	return setScanExecutionAttributes(client, d, scan)
+}
+
+// resourceAppScanDastScanUpdate renames the scan and applies
+// enable_mail_notification, runs the scan again when rescan_triggers change,
+// and publishes its latest execution when publish is set. The other
+// arguments it handles, wait_for_completion and the polling settings, affect
+// the provider's behavior and are not sent to the API, and
+// login_sequence_file may only move to a path holding the same content.
+func resourceAppScanDastScanUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	if err := checkPublish(d); err != nil {
+		return err
+	}
+	if err := updateScanMetadata(ctx, client, d, "appscan_dast_scan"); err != nil {
+		return err
+	}
+	if err := rescan(ctx, client, d, "Dast", scanExecution(d, "")); err != nil {
+		return err
+	}
+	if err := publishScan(ctx, client, d, "Dast"); err != nil {
+		return err
+	}
+	return resourceAppScanDastScanRead(ctx, d, m)
+}
+
+func resourceAppScanDastScanDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	if err := prepareScanDelete(ctx, client, d, "appscan_dast_scan"); err != nil {
+		return err
+	}
+	if err := deleteScan(ctx, client, d.Id()); err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
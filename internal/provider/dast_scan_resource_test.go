@@ -0,0 +1,386 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccDastScanResource(t *testing.T) {
+	m := newMockServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_dast_scan" "test" {
+  application_id      = %q
+  name                = "nightly"
+  starting_url        = "https://example.com/"
+  wait_for_completion = false
+}
+`, mockApplicationID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_dast_scan.test", "name", "nightly"),
+					resource.TestCheckResourceAttr("appscan_dast_scan.test", "status", "Ready"),
+					resource.TestCheckResourceAttr("appscan_dast_scan.test", "issue_counts.0.high", "1"),
+				),
+			},
+			{
+				ResourceName:      "appscan_dast_scan.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "appscan_dast_scan.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportID("appscan_dast_scan.test", mockApplicationID),
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "appscan_dast_scan.test",
+				ImportState:       true,
+				ImportStateIdFunc: testAccImportID("appscan_dast_scan.test", mockAssetGroupID),
+				ExpectError:       regexp.MustCompile(`belongs to application ` + mockApplicationID),
+			},
+			{
+				ResourceName:  "appscan_dast_scan.test",
+				ImportState:   true,
+				ImportStateId: "nightly",
+				ExpectError:   regexp.MustCompile(`invalid import scan_id "nightly": must be a GUID`),
+			},
+		},
+	})
+}
+
+func TestAccDastScanResource_executionRetries(t *testing.T) {
+	m := newMockServer(t)
+	failWith := func(messages ...string) func() {
+		return func() {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			m.executionFailures = messages
+		}
+	}
+	config := testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_dast_scan" "test" {
+  application_id      = %q
+  name                = "nightly"
+  starting_url        = "https://example.com/"
+  wait_for_completion = true
+  execution_retries   = 2
+}
+`, mockApplicationID)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Failures of the scan itself are not retried.
+				PreConfig:   failWith("Login failed: invalid credentials", "Presence disconnected"),
+				Config:      config,
+				ExpectError: regexp.MustCompile("scan execution Failed: Login failed"),
+			},
+			{
+				// Neither are infrastructure failures beyond execution_retries.
+				PreConfig:   failWith("Presence disconnected", "Agent timed out", "Presence disconnected"),
+				Config:      config,
+				ExpectError: regexp.MustCompile("scan execution Failed: Presence disconnected"),
+			},
+			{
+				PreConfig: failWith("Presence disconnected", "Agent timed out"),
+				Config:    config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_dast_scan.test", "status", "Ready"),
+					resource.TestCheckResourceAttr("appscan_dast_scan.test", "issue_counts.0.high", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDastScanResource_rescanTriggers(t *testing.T) {
+	m := newMockServer(t)
+	config := func(commit string) string {
+		return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_dast_scan" "test" {
+  application_id      = %q
+  name                = "nightly"
+  starting_url        = "https://example.com/"
+  wait_for_completion = true
+  rescan_triggers = {
+    commit = %q
+  }
+}
+`, mockApplicationID, commit)
+	}
+	var scanID string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("0a1b2c3"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccSaveID("appscan_dast_scan.test", &scanID),
+					testAccCheckMockExecutions(m, 1),
+				),
+			},
+			{
+				Config: config("4d5e6f7"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckID("appscan_dast_scan.test", &scanID, true),
+					resource.TestCheckResourceAttr("appscan_dast_scan.test", "status", "Ready"),
+					testAccCheckMockExecutions(m, 2),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDastScanResource_publish(t *testing.T) {
+	m := newMockServer(t)
+	config := func(personal, publish bool, commit string) string {
+		return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_dast_scan" "test" {
+  application_id      = %q
+  name                = "nightly"
+  starting_url        = "https://example.com/"
+  wait_for_completion = true
+  personal            = %t
+  publish             = %t
+  rescan_triggers = {
+    commit = %q
+  }
+}
+`, mockApplicationID, personal, publish, commit)
+	}
+	checkPromotions := func(want int) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			if len(m.promotions) != want {
+				return fmt.Errorf("got %d promotions, want %d", len(m.promotions), want)
+			}
+			if want > 0 {
+				return resource.TestCheckResourceAttr("appscan_dast_scan.test", "published_execution_id", m.promotions[want-1])(s)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config(false, true, "0a1b2c3"),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("publish requires personal"),
+			},
+			{
+				Config: config(true, false, "0a1b2c3"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_dast_scan.test", "personal", "true"),
+					resource.TestCheckNoResourceAttr("appscan_dast_scan.test", "published_execution_id"),
+					checkPromotions(0),
+				),
+			},
+			{
+				Config: config(true, true, "0a1b2c3"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("appscan_dast_scan.test", "published_execution_id", "appscan_dast_scan.test", "latest_execution_id"),
+					checkPromotions(1),
+				),
+			},
+			{
+				Config: config(true, true, "4d5e6f7"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("appscan_dast_scan.test", "published_execution_id", "appscan_dast_scan.test", "latest_execution_id"),
+					checkPromotions(2),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDastScanResource_loginSequence(t *testing.T) {
+	m := newMockServer(t)
+	dir := t.TempDir()
+	writeLoginFile := func(name, content string) func() {
+		return func() {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	config := func(name string) string {
+		return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_dast_scan" "test" {
+  application_id      = %q
+  name                = "nightly"
+  starting_url        = "https://example.com/"
+  login_sequence_file = %q
+  wait_for_completion = false
+}
+`, mockApplicationID, filepath.Join(dir, name))
+	}
+	checkUploaded := func(want string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			scan := m.find("Scans", s.RootModule().Resources["appscan_dast_scan.test"].Primary.ID)
+			fileID, _ := scan["LoginSequenceFileId"].(string)
+			if got := string(m.files[fileID]); got != want {
+				return fmt.Errorf("login sequence %q uploaded, want %q", got, want)
+			}
+			return nil
+		}
+	}
+	var scanID string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config("login.txt"),
+				ExpectError: regexp.MustCompile(`must be a .login or .config file`),
+			},
+			{
+				PreConfig: writeLoginFile("site.login", "mock login sequence"),
+				Config:    config("site.login"),
+				Check: resource.ComposeTestCheckFunc(
+					// sha256("mock login sequence")
+					resource.TestCheckResourceAttr("appscan_dast_scan.test", "login_sequence_file_sha256", "e2eb45b7ffc33e3cd954f6d5c65d8fd8af6c6ae256a8883d861ca620b59c359a"),
+					resource.TestCheckResourceAttr("appscan_dast_scan.test", "login_type", "LoginSequence"),
+					checkUploaded("mock login sequence"),
+					testAccSaveID("appscan_dast_scan.test", &scanID),
+				),
+			},
+			{
+				// Same content under another path: no new scan.
+				PreConfig: writeLoginFile("renamed.login", "mock login sequence"),
+				Config:    config("renamed.login"),
+				Check:     testAccCheckID("appscan_dast_scan.test", &scanID, true),
+			},
+			{
+				PreConfig: writeLoginFile("renamed.login", "new mock login sequence"),
+				Config:    config("renamed.login"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckID("appscan_dast_scan.test", &scanID, false),
+					checkUploaded("new mock login sequence"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDastScanResource_loginConflicts(t *testing.T) {
+	m := newMockServer(t)
+	config := func(login string) string {
+		return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_dast_scan" "test" {
+  application_id = %q
+  name           = "nightly"
+  starting_url   = "https://example.com/"
+%s
+}
+`, mockApplicationID, login)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config(`  login_user = "scanner"`),
+				ExpectError: regexp.MustCompile(`all of\s+` + "`login_password,login_user`" + `\s+must be specified`),
+			},
+			{
+				Config: config(`
+  login_password      = "secret"
+  login_sequence_file = "site.login"
+`),
+				ExpectError: regexp.MustCompile(`"login_sequence_file": conflicts with login_password`),
+			},
+		},
+	})
+}
+
+// testAccCheckMockExecutions checks the number of executions of the scans.
+func testAccCheckMockExecutions(m *mockServer, want int) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if got := len(m.collections["Executions"]); got != want {
+			return fmt.Errorf("got %d executions, want %d", got, want)
+		}
+		return nil
+	}
+}
+
+func TestAccDastScanResource_unknownValues(t *testing.T) {
+	m := newMockServer(t)
+	// The data source is read once the application exists, so the IDs
+	// flowing into the application and the scan are unknown at plan time.
+	config := func(personal string) string {
+		return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_application" "seed" {
+  name           = "seed"
+  asset_group_id = %q
+}
+
+data "appscan_asset_group" "deferred" {
+  name       = "Default Asset Group"
+  depends_on = [appscan_application.seed]
+}
+
+resource "appscan_application" "test" {
+  name                = "chained"
+  asset_group_id      = data.appscan_asset_group.deferred.id
+  fail_if_name_exists = true
+}
+
+resource "appscan_dast_scan" "test" {
+  application_id      = appscan_application.test.id
+  name                = "chained"
+  starting_url        = "https://example.com/"
+  wait_for_completion = true
+  personal            = %s
+  publish             = true
+  rescan_triggers = {
+    asset_group = data.appscan_asset_group.deferred.id
+  }
+}
+`, mockAssetGroupID, personal)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// Checked at apply, once personal is known.
+				Config:             config(`appscan_application.seed.id == ""`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config:      config(`appscan_application.seed.id == ""`),
+				ExpectError: regexp.MustCompile("publish requires personal"),
+			},
+			{
+				Config: config(`appscan_application.seed.id != ""`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("appscan_application.test", "asset_group_id", "data.appscan_asset_group.deferred", "id"),
+					resource.TestCheckResourceAttrPair("appscan_dast_scan.test", "application_id", "appscan_application.test", "id"),
+					resource.TestCheckResourceAttr("appscan_dast_scan.test", "personal", "true"),
+					resource.TestCheckResourceAttrPair("appscan_dast_scan.test", "published_execution_id", "appscan_dast_scan.test", "latest_execution_id"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// debugBodyLimit is the number of bytes of each request/response body
+// included in the debug logs.
+const debugBodyLimit = 16 << 10
+
+// sensitiveJSONRegexp matches the JSON string members whose value must not
+// be logged: credentials (KeySecret, Password...) and tokens. A value cut
+// by truncation is matched up to the end of the text.
+var sensitiveJSONRegexp = regexp.MustCompile(`(?i)("[^"]*(?:password|secret|token)[^"]*"\s*:\s*)"(?:[^"\\]|\\.)*(?:"|$)`)
+
+// sensitiveQueryRegexp matches the names of the query parameters whose value
+// must not be logged, such as the signature of a pre-signed download URL.
+var sensitiveQueryRegexp = regexp.MustCompile(`(?i)^(sig|signature|code|key|.*(password|secret|token|credential).*)$`)
+
+// debugTransport logs every API exchange (method, URL, status, latency and
+// redacted bodies) through tflog. It is enabled by debug_http.
+type debugTransport struct {
+	transport http.RoundTripper
+	// ctx carries the provider logger. Only context-aware SDK functions
+	// receive one, so the configure context is kept for logging purposes;
+	// it is never used to issue requests.
+	ctx context.Context
+}
+
+func newDebugTransport(ctx context.Context, transport http.RoundTripper) *debugTransport {
+	return &debugTransport{transport: transport, ctx: ctx}
+}
+
+// mask masks the given secrets (key secret, access token...) wherever they
+// appear in the logs, whatever the field or JSON member holding them. It must
+// be called before the transport is used concurrently.
+func (t *debugTransport) mask(secrets ...string) {
+	for _, s := range secrets {
+		// An empty string would mask everything.
+		if s != "" {
+			t.ctx = tflog.MaskAllFieldValuesStrings(t.ctx, s)
+		}
+	}
+}
+
+func (t *debugTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fields := map[string]interface{}{
+		"http_method": req.Method,
+		"http_url":    redactURL(req.URL),
+	}
+	if body := requestBodyForLog(req); body != "" {
+		fields["http_request_body"] = body
+	}
+
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+	fields["http_duration_ms"] = time.Since(start).Milliseconds()
+	if err != nil {
+		fields["error"] = err.Error()
+		tflog.Debug(t.ctx, "AppScan API request failed", fields)
+		return resp, err
+	}
+
+	fields["http_status"] = resp.StatusCode
+	if body := responseBodyForLog(resp); body != "" {
+		fields["http_response_body"] = body
+	}
+	tflog.Debug(t.ctx, "AppScan API request", fields)
+	return resp, nil
+}
+
+// redactURL returns u with its password and the values of its sensitive
+// query parameters masked.
+func redactURL(u *url.URL) string {
+	query := u.Query()
+	redacted := false
+	for name := range query {
+		if sensitiveQueryRegexp.MatchString(name) {
+			query[name] = []string{"***"}
+			redacted = true
+		}
+	}
+	if !redacted {
+		return u.Redacted()
+	}
+	clone := *u
+	clone.RawQuery = query.Encode()
+	return clone.Redacted()
+}
+
+// requestBodyForLog returns the redacted request body. Streamed bodies (file
+// uploads) cannot be replayed and are not logged.
+func requestBodyForLog(req *http.Request) string {
+	if req.Body == nil || req.Body == http.NoBody {
+		return ""
+	}
+	if req.GetBody == nil {
+		return "(streamed body not logged)"
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return ""
+	}
+	defer body.Close()
+	content, err := io.ReadAll(io.LimitReader(body, debugBodyLimit+1))
+	if err != nil {
+		return ""
+	}
+	return redactBody(content)
+}
+
+// responseBodyForLog returns the redacted beginning of textual response
+// bodies, leaving resp.Body readable from the start. Downloads (reports...)
+// are not logged.
+func responseBodyForLog(resp *http.Response) string {
+	contentType := resp.Header.Get("Content-Type")
+	if !strings.Contains(contentType, "json") && !strings.HasPrefix(contentType, "text/") && !strings.Contains(contentType, "xml") {
+		return ""
+	}
+	content, err := io.ReadAll(io.LimitReader(resp.Body, debugBodyLimit+1))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(content), resp.Body), resp.Body}
+	if err != nil {
+		return ""
+	}
+	return redactBody(content)
+}
+
+// redactBody masks the sensitive JSON members of body and truncates it to
+// debugBodyLimit.
+func redactBody(body []byte) string {
+	truncated := len(body) > debugBodyLimit
+	if truncated {
+		body = body[:debugBodyLimit]
+	}
+	s := sensitiveJSONRegexp.ReplaceAllString(string(body), `$1"***"`)
+	if truncated {
+		s += "...(truncated)"
+	}
+	return s
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// customizeDiffProviderDefault plans the provider-level default of attr, as
+// returned by value, when the configuration omits attr. The attribute must
+// be Optional and Computed: without a default, an omitted attr keeps the
+// value read from the API. If required is set, omitting attr without a
+// default is an error.
+func customizeDiffProviderDefault(attr, providerAttr string, required bool, value func(*AppScanClient) string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, m interface{}) error {
+		if config := d.GetRawConfig(); config.IsNull() || !config.GetAttr(attr).IsNull() {
+			return nil
+		}
+		client, ok := m.(*AppScanClient)
+		if !ok || client == nil {
+			return nil
+		}
+		def := value(client)
+		if def == "" {
+			if required && d.Id() == "" {
+				return fmt.Errorf("%s is required when the provider sets no %s", attr, providerAttr)
+			}
+			return nil
+		}
+		if d.Get(attr).(string) == def {
+			return nil
+		}
+		return d.SetNew(attr, def)
+	}
+}
@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The API normalizes some of the values it stores: it title-cases
+// enumerations such as BusinessImpact and trims free text such as names and
+// descriptions. The configured value is sent as is; these functions keep
+// the normalized value read back from showing as a change. Unordered
+// collections are sets, so that the order the API returns them in is not a
+// change either.
+
+// suppressEqualFold suppresses the diffs that only change the case, for
+// enumerations.
+func suppressEqualFold(_, old, new string, _ *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
+
+// suppressSurroundingSpace suppresses the diffs that only add or remove
+// leading and trailing whitespace.
+func suppressSurroundingSpace(_, old, new string, _ *schema.ResourceData) bool {
+	return strings.TrimSpace(old) == strings.TrimSpace(new)
+}
+
+// hashEqualFold hashes the elements of a set of strings regardless of their
+// case, for sets of IDs: GUIDs are case-insensitive, and the API returns them
+// in its own case rather than the configured one.
+func hashEqualFold(v interface{}) int {
+	return schema.HashString(strings.ToLower(v.(string)))
+}
@@ -0,0 +1,348 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// DAST scans may only target domains of the allowed-domains list whose
+// ownership is verified. appscan_domain adds a domain to the list and exposes
+// its verification key and status; the verification itself happens outside
+// Terraform, e.g. by publishing the key on the site.
+
+func resourceAppScanDomain() *schema.Resource {
+	return &schema.Resource{
+		CreateWithoutTimeout: withContext(resourceAppScanDomainCreate),
+		ReadWithoutTimeout:   withContext(resourceAppScanDomainRead),
+		UpdateWithoutTimeout: withContext(resourceAppScanDomainUpdate),
+		DeleteWithoutTimeout: withContext(resourceAppScanDomainDelete),
+		Importer: &schema.ResourceImporter{
+			State: importNumericID,
+		},
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The domain or IP address allowed for DAST scans.",
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"url_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "Domain",
+				Description:  "Whether domain is a domain name or an IP address. Allowed values: Domain, IpAddress. Defaults to Domain.",
+				ValidateFunc: validation.StringInSlice([]string{"Domain", "IpAddress"}, false),
+			},
+			"description": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "A description of the domain.",
+				ValidateFunc: validation.StringLenBetween(0, 256),
+			},
+			"include_subdomains": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the subdomains of the domain are allowed too.",
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the domain may be scanned.",
+			},
+			"asset_group_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The IDs of the asset groups allowed to scan the domain. If empty, every asset group is.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         hashEqualFold,
+			},
+			"verification_key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The key proving the ownership of the domain.",
+			},
+			"verification_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "How the ownership of the domain was verified, e.g. Html, Email or Manually.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The verification status of the domain: None, Pending or Verified.",
+			},
+			"verified": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the ownership of the domain is verified.",
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the domain.",
+			},
+		},
+	}
+}
+
+// appScanDomain holds the DomainModel fields the provider relies on.
+type appScanDomain struct {
+	Id                int           `json:"Id"`
+	Domain            string        `json:"Domain"`
+	UrlType           string        `json:"UrlType"`
+	IncludeSubDomains bool          `json:"IncludeSubDomains"`
+	Description       string        `json:"Description"`
+	Enabled           bool          `json:"Enabled"`
+	Type              string        `json:"Type"`
+	Status            string        `json:"Status"`
+	Key               string        `json:"Key"`
+	AssetGroups       []namedEntity `json:"AssetGroups"`
+}
+
+// appScanDomainAllowRequest is the payload allowing a domain.
+type appScanDomainAllowRequest struct {
+	DomainUrl                     string   `json:"DomainUrl"`
+	UrlType                       string   `json:"UrlType"`
+	Description                   string   `json:"Description"`
+	IsAccessLimitedForAssetGroups bool     `json:"IsAccessLimitedForAssetGroups"`
+	AssetGroupIds                 []string `json:"AssetGroupIds"`
+}
+
+// appScanDomainUpdateRequest is the payload updating the settings of a
+// domain.
+type appScanDomainUpdateRequest struct {
+	Description                   string   `json:"Description"`
+	IncludeSubDomains             bool     `json:"IncludeSubDomains"`
+	Enabled                       bool     `json:"Enabled"`
+	IsAccessLimitedForAssetGroups bool     `json:"IsAccessLimitedForAssetGroups"`
+	AssetGroupIds                 []string `json:"AssetGroupIds"`
+}
+
+func resourceAppScanDomainCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	assetGroupIDs := domainAssetGroupIDs(d)
+	body, err := json.Marshal(appScanDomainAllowRequest{
+		DomainUrl:                     d.Get("domain").(string),
+		UrlType:                       d.Get("url_type").(string),
+		Description:                   d.Get("description").(string),
+		IsAccessLimitedForAssetGroups: len(assetGroupIDs) > 0,
+		AssetGroupIds:                 assetGroupIDs,
+	})
+	if err != nil {
+		return err
+	}
+	urlStr := fmt.Sprintf("%s/Domains/Allow", client.ApiBase)
+	req, err := client.newRequest(ctx, "POST", urlStr, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return appscan.APIErrorFromBody("allow domain", resp, respBody)
+	}
+	var result struct {
+		Domain  *appScanDomain `json:"Domain"`
+		Message string         `json:"Message"`
+	}
+	if err := appscan.DecodeJSON(resp, respBody, &result); err != nil {
+		return err
+	}
+	if result.Domain == nil || result.Domain.Id == 0 {
+		return fmt.Errorf("failed to allow domain %s: %s", d.Get("domain").(string), result.Message)
+	}
+	d.SetId(strconv.Itoa(result.Domain.Id))
+	client.Summary.record("domain_allowed", "appscan_domain", d.Id(), map[string]string{
+		"domain": result.Domain.Domain,
+	})
+
+	// The allow request does not take these settings.
+	if d.Get("include_subdomains").(bool) || !d.Get("enabled").(bool) {
+		if err := updateDomain(ctx, client, d); err != nil {
+			return err
+		}
+	}
+	return resourceAppScanDomainRead(ctx, d, m)
+}
+
+func resourceAppScanDomainRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	domain, err := getDomain(ctx, m.(*AppScanClient), d.Id())
+	if err != nil {
+		return err
+	}
+	if domain == nil {
+		d.SetId("")
+		return nil
+	}
+	d.Set("domain", domain.Domain)
+	d.Set("url_type", domain.UrlType)
+	d.Set("description", domain.Description)
+	d.Set("include_subdomains", domain.IncludeSubDomains)
+	d.Set("enabled", domain.Enabled)
+	assetGroupIDs := make([]interface{}, 0, len(domain.AssetGroups))
+	for _, ag := range domain.AssetGroups {
+		assetGroupIDs = append(assetGroupIDs, ag.Id)
+	}
+	if err := d.Set("asset_group_ids", assetGroupIDs); err != nil {
+		return err
+	}
+	d.Set("verification_key", domain.Key)
+	d.Set("verification_type", domain.Type)
+	d.Set("status", domain.Status)
+	d.Set("verified", domain.Status == "Verified")
+	return nil
+}
+
+func resourceAppScanDomainUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if err := updateDomain(ctx, m.(*AppScanClient), d); err != nil {
+		return err
+	}
+	return resourceAppScanDomainRead(ctx, d, m)
+}
+
+func resourceAppScanDomainDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	id, err := strconv.Atoi(d.Id())
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal([]int{id})
+	if err != nil {
+		return err
+	}
+	urlStr := fmt.Sprintf("%s/Domains/DeleteDomains", client.ApiBase)
+	req, err := client.newRequest(ctx, "DELETE", urlStr, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	switch resp.StatusCode {
+	case http.StatusNoContent, http.StatusNotFound:
+	case http.StatusOK, http.StatusMultiStatus:
+		// Deletions are reported per domain, with a 207 when some failed.
+		var result struct {
+			Failed []struct {
+				DomainId int    `json:"DomainId"`
+				Message  string `json:"Message"`
+			} `json:"Failed"`
+		}
+		if err := appscan.DecodeJSON(resp, respBody, &result); err != nil {
+			return err
+		}
+		for _, f := range result.Failed {
+			if f.DomainId == id {
+				return fmt.Errorf("failed to delete domain %d: %s", id, f.Message)
+			}
+		}
+	default:
+		return appscan.APIErrorFromBody("delete domain", resp, respBody)
+	}
+	client.Summary.record("domain_deleted", "appscan_domain", d.Id(), nil)
+	d.SetId("")
+	return nil
+}
+
+// updateDomain applies the updatable settings of the domain.
+func updateDomain(ctx context.Context, client *AppScanClient, d *schema.ResourceData) error {
+	assetGroupIDs := domainAssetGroupIDs(d)
+	body, err := json.Marshal(appScanDomainUpdateRequest{
+		Description:                   d.Get("description").(string),
+		IncludeSubDomains:             d.Get("include_subdomains").(bool),
+		Enabled:                       d.Get("enabled").(bool),
+		IsAccessLimitedForAssetGroups: len(assetGroupIDs) > 0,
+		AssetGroupIds:                 assetGroupIDs,
+	})
+	if err != nil {
+		return err
+	}
+	urlStr := fmt.Sprintf("%s/Domains/%s", client.ApiBase, url.PathEscape(d.Id()))
+	req, err := client.newRequest(ctx, "PUT", urlStr, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return appscan.NewAPIError("update domain", resp)
+	}
+	return nil
+}
+
+// domainAssetGroupIDs returns the asset_group_ids argument of d, the asset
+// groups the domain is limited to.
+func domainAssetGroupIDs(d *schema.ResourceData) []string {
+	ids := []string{}
+	for _, id := range d.Get("asset_group_ids").(*schema.Set).List() {
+		ids = append(ids, id.(string))
+	}
+	return ids
+}
+
+// getDomain fetches a domain of the allowed-domains list, returning nil when
+// it does not exist.
+func getDomain(ctx context.Context, client *AppScanClient, id string) (*appScanDomain, error) {
+	n, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid domain ID %q: %w", id, err)
+	}
+	filterQuery, err := appscan.ODataEqInt("Id", n)
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	query.Set("$filter", filterQuery)
+
+	var result struct {
+		Items []appScanDomain `json:"Items"`
+	}
+	if err := getODataPage(ctx, client, "Domains", query, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Items) == 0 {
+		return nil, nil
+	}
+	return &result.Items[0], nil
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDomainResource(t *testing.T) {
+	m := newMockServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccDomainConfig(m, `url_type = "Hostname"`),
+				ExpectError: regexp.MustCompile(`expected url_type to be one of`),
+			},
+			{
+				Config: testAccDomainConfig(m, `description = "Staging"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_domain.test", "id", "1"),
+					resource.TestCheckResourceAttr("appscan_domain.test", "verification_key", "mock-verification-key"),
+					resource.TestCheckResourceAttr("appscan_domain.test", "status", "Pending"),
+					resource.TestCheckResourceAttr("appscan_domain.test", "verified", "false"),
+					resource.TestCheckResourceAttr("appscan_domain.test", "include_subdomains", "false"),
+				),
+			},
+			{
+				PreConfig: func() {
+					m.mu.Lock()
+					m.collections["Domains"][0]["Status"] = "Verified"
+					m.mu.Unlock()
+				},
+				Config: testAccDomainConfig(m, fmt.Sprintf(`
+  include_subdomains = true
+  asset_group_ids    = [%q]
+`, mockAssetGroupID)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_domain.test", "verified", "true"),
+					resource.TestCheckResourceAttr("appscan_domain.test", "include_subdomains", "true"),
+					resource.TestCheckResourceAttr("appscan_domain.test", "description", ""),
+					resource.TestCheckTypeSetElemAttr("appscan_domain.test", "asset_group_ids.*", mockAssetGroupID),
+				),
+			},
+			{
+				// The IDs read back in lower case and in another order are no
+				// change.
+				Config: testAccDomainConfig(m, fmt.Sprintf(`
+  include_subdomains = true
+  asset_group_ids    = ["ABCDEF12-3456-7890-ABCD-EF1234567890", %q]
+`, mockAssetGroupID)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_domain.test", "asset_group_ids.#", "2"),
+					resource.TestCheckTypeSetElemAttr("appscan_domain.test", "asset_group_ids.*", "ABCDEF12-3456-7890-ABCD-EF1234567890"),
+				),
+			},
+			{
+				ResourceName:      "appscan_domain.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:  "appscan_domain.test",
+				ImportState:   true,
+				ImportStateId: "staging.example.com",
+				ExpectError:   regexp.MustCompile(`must be a number`),
+			},
+		},
+	})
+}
+
+func testAccDomainConfig(m *mockServer, extra string) string {
+	return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_domain" "test" {
+  domain = "staging.example.com"
+  %s
+}
+`, extra)
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// When log_drift is set, the refresh of a managed resource compares its
+// state before and after the read and reports the arguments that changed
+// outside Terraform, e.g. in the AppScan console. Computed attributes, such
+// as the status of a scan, change on their own and are not reported.
+
+// driftRedacted replaces the values of sensitive attributes in drift
+// reports.
+const driftRedacted = "(sensitive)"
+
+// driftChange is an attribute of a resource that changed outside Terraform.
+type driftChange struct {
+	Attribute string
+	Old, New  string
+}
+
+// logDrift wraps the read of resource r, named name, to report its drift
+// when the provider enables log_drift. The create and update functions call
+// the read functions directly, so only refreshes and imports are wrapped.
+func logDrift(name string, r *schema.Resource) {
+	if read := r.Read; read != nil {
+		r.Read = nil
+		r.ReadWithoutTimeout = func(_ context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+			return diag.FromErr(read(d, m))
+		}
+	}
+	for _, read := range []*schema.ReadContextFunc{&r.ReadContext, &r.ReadWithoutTimeout} {
+		if f := *read; f != nil {
+			*read = func(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+				old := d.State()
+				diags := f(ctx, d, m)
+				if !diags.HasError() {
+					reportDrift(ctx, name, r, old, d, m)
+				}
+				return diags
+			}
+		}
+	}
+}
+
+// reportDrift logs the drift of the resource d from its state old, and
+// records it in the run summary.
+func reportDrift(ctx context.Context, name string, r *schema.Resource, old *terraform.InstanceState, d *schema.ResourceData, m interface{}) {
+	client, ok := m.(*AppScanClient)
+	if !ok || !client.LogDrift || old == nil || d.Id() == "" {
+		return
+	}
+	changes := driftChanges(r.Schema, old.Attributes, d.State().Attributes)
+	if len(changes) == 0 {
+		return
+	}
+	fields := map[string]interface{}{"resource": name, "id": d.Id()}
+	details := map[string]string{}
+	for _, c := range changes {
+		fields[c.Attribute] = map[string]string{"old": c.Old, "new": c.New}
+		details[c.Attribute] = fmt.Sprintf("%q -> %q", c.Old, c.New)
+	}
+	tflog.Warn(ctx, "AppScan resource changed outside Terraform", fields)
+	client.Summary.record("drift_detected", name, d.Id(), details)
+}
+
+// driftChanges compares the flatmap attributes of a resource, before and
+// after a read, and returns the changes of the arguments of s, sorted by
+// attribute. The element counts of lists, sets and maps are left out, their
+// elements being compared, and so are the arguments missing from the old
+// state, such as those of a resource being imported.
+func driftChanges(s map[string]*schema.Schema, old, refreshed map[string]string) []driftChange {
+	keys := map[string]bool{}
+	for k := range old {
+		keys[k] = true
+	}
+	for k := range refreshed {
+		keys[k] = true
+	}
+	var changes []driftChange
+	for k := range keys {
+		if k == "id" || strings.HasSuffix(k, ".#") || strings.HasSuffix(k, ".%") || old[k] == refreshed[k] {
+			continue
+		}
+		argument, sensitive := driftAttribute(s, k)
+		if !argument || !inState(old, strings.SplitN(k, ".", 2)[0]) {
+			continue
+		}
+		c := driftChange{Attribute: k, Old: old[k], New: refreshed[k]}
+		if sensitive {
+			c.Old, c.New = driftRedacted, driftRedacted
+		}
+		changes = append(changes, c)
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Attribute < changes[j].Attribute })
+	return changes
+}
+
+// driftAttribute tells whether the flatmap key of s, such as
+// `fail_on.0.max_high` or `tags.team`, is an argument rather than a computed
+// attribute, and whether it, or a block holding it, is sensitive.
+func driftAttribute(s map[string]*schema.Schema, key string) (argument, sensitive bool) {
+	parts := strings.Split(key, ".")
+	for i := 0; i < len(parts); i++ {
+		attr, ok := s[parts[i]]
+		if !ok {
+			return false, false
+		}
+		if attr.Computed && !attr.Optional {
+			return false, false
+		}
+		sensitive = sensitive || attr.Sensitive
+		block, isBlock := attr.Elem.(*schema.Resource)
+		if !isBlock || i+2 >= len(parts) {
+			return true, sensitive
+		}
+		// Skip the index of the element of the block.
+		i++
+		s = block.Schema
+	}
+	return true, sensitive
+}
+
+// inState tells whether the flatmap attributes of a state hold the
+// attribute attr, as a value or as a list, set or map.
+func inState(attributes map[string]string, attr string) bool {
+	for _, k := range []string{attr, attr + ".#", attr + ".%"} {
+		if _, ok := attributes[k]; ok {
+			return true
+		}
+	}
+	return false
+}
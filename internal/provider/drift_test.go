@@ -0,0 +1,131 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccProvider_logDrift(t *testing.T) {
+	m := newMockServer(t)
+	summaryFile := filepath.Join(t.TempDir(), "summary.json")
+	config := fmt.Sprintf(`
+provider "appscan" {
+  api_endpoint = %q
+  key_id       = %q
+  key_secret   = %q
+  log_drift    = true
+  summary_file = %q
+}
+
+resource "appscan_application" "test" {
+  name           = "payments"
+  asset_group_id = %q
+}
+`, m.URL, mockKeyID, mockKeySecret, summaryFile, mockAssetGroupID)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				// Renamed and rated in the console: only the argument is
+				// reported.
+				PreConfig: func() {
+					m.mu.Lock()
+					defer m.mu.Unlock()
+					for _, app := range m.collections["Apps"] {
+						app["Name"] = "payments-console"
+						app["RiskRating"] = "High"
+					}
+				},
+				RefreshState: true,
+				Check: func(*terraform.State) error {
+					content, err := os.ReadFile(summaryFile)
+					if err != nil {
+						return err
+					}
+					var summary summaryDocument
+					if err := json.Unmarshal(content, &summary); err != nil {
+						return err
+					}
+					for _, e := range summary.Events {
+						if e.Kind != "drift_detected" {
+							continue
+						}
+						expected := map[string]string{"name": `"payments" -> "payments-console"`}
+						if e.Resource != "appscan_application" || !reflect.DeepEqual(e.Details, expected) {
+							return fmt.Errorf("drift_detected event = %+v, want the details %v", e, expected)
+						}
+						return nil
+					}
+					return fmt.Errorf("no drift_detected event in %s", content)
+				},
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: config,
+			},
+		},
+	})
+}
+
+func TestDriftChanges(t *testing.T) {
+	s := map[string]*schema.Schema{
+		"name":     {Type: schema.TypeString, Required: true},
+		"password": {Type: schema.TypeString, Optional: true, Sensitive: true},
+		"status":   {Type: schema.TypeString, Computed: true},
+		"tags":     {Type: schema.TypeMap, Optional: true, Elem: &schema.Schema{Type: schema.TypeString}},
+		"fail_on": {
+			Type:     schema.TypeList,
+			Optional: true,
+			Elem: &schema.Resource{Schema: map[string]*schema.Schema{
+				"max_high": {Type: schema.TypeInt, Optional: true},
+				"checked":  {Type: schema.TypeBool, Computed: true},
+			}},
+		},
+		"description": {Type: schema.TypeString, Optional: true},
+	}
+	old := map[string]string{
+		"id":                 "1",
+		"name":               "a",
+		"password":           "secret",
+		"status":             "Running",
+		"tags.%":             "1",
+		"tags.team":          "red",
+		"fail_on.#":          "1",
+		"fail_on.0.max_high": "0",
+		"fail_on.0.checked":  "false",
+	}
+	refreshed := map[string]string{
+		"id":                 "1",
+		"name":               "b",
+		"password":           "changed",
+		"status":             "Ready",
+		"tags.%":             "1",
+		"tags.owner":         "blue",
+		"fail_on.#":          "1",
+		"fail_on.0.max_high": "2",
+		"fail_on.0.checked":  "true",
+		"description":        "imported",
+	}
+	expected := []driftChange{
+		{Attribute: "fail_on.0.max_high", Old: "0", New: "2"},
+		{Attribute: "name", Old: "a", New: "b"},
+		{Attribute: "password", Old: driftRedacted, New: driftRedacted},
+		{Attribute: "tags.owner", Old: "", New: "blue"},
+		{Attribute: "tags.team", Old: "red", New: ""},
+	}
+	if actual := driftChanges(s, old, refreshed); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("driftChanges() = %+v, want %+v", actual, expected)
+	}
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// configuredEndpoint returns the endpoint of the provider configuration d:
+// api_endpoint when it is set in the configuration, else the endpoint of
+// region when it is set, else api_endpoint as set by the environment or
+// defaulted.
+func configuredEndpoint(d *schema.ResourceData) (string, error) {
+	endpoint := d.Get("api_endpoint").(string)
+	config := d.GetRawConfig()
+	configured := func(attr string) bool {
+		return !config.IsNull() && !config.GetAttr(attr).IsNull()
+	}
+	// The defaults of api_endpoint defeat ConflictsWith.
+	if configured("api_endpoint") && configured("region") {
+		return "", fmt.Errorf("api_endpoint and region are mutually exclusive")
+	}
+	if region := d.Get("region").(string); region != "" && !configured("api_endpoint") {
+		regionEndpoint, ok := appscan.RegionEndpoints[strings.ToLower(region)]
+		if !ok {
+			return "", fmt.Errorf("invalid region: %q must be one of %s", region, strings.Join(appscan.RegionNames(), ", "))
+		}
+		return regionEndpoint, nil
+	}
+	endpoint, err := appscan.NormalizeEndpoint(endpoint)
+	if err != nil {
+		return "", fmt.Errorf("invalid api_endpoint: %w", err)
+	}
+	return endpoint, nil
+}
+
+// validateEndpoint is the ValidateFunc of api_endpoint.
+func validateEndpoint(v interface{}, k string) ([]string, []error) {
+	if _, err := appscan.NormalizeEndpoint(v.(string)); err != nil {
+		return nil, []error{fmt.Errorf("invalid %s: %w", k, err)}
+	}
+	return nil, nil
+}
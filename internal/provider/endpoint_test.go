@@ -0,0 +1,28 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestConfiguredEndpoint(t *testing.T) {
+	// The environment does not win over region.
+	t.Setenv("APPSCAN_API_ENDPOINT", "https://appscan.example.com/")
+	for _, c := range []struct {
+		config   map[string]interface{}
+		expected string
+	}{
+		{map[string]interface{}{}, "https://appscan.example.com"},
+		{map[string]interface{}{"region": "eu"}, "https://eu.cloud.appscan.com"},
+		{map[string]interface{}{"region": "US"}, "https://cloud.appscan.com"},
+	} {
+		d := schema.TestResourceDataRaw(t, Provider().Schema, c.config)
+		actual, err := configuredEndpoint(d)
+		if err != nil {
+			t.Errorf("configuredEndpoint(%v): %s", c.config, err)
+		} else if actual != c.expected {
+			t.Errorf("configuredEndpoint(%v) = %q, expected %q", c.config, actual, c.expected)
+		}
+	}
+}
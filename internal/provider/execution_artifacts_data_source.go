@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ----------------------------------------------------------------
+// Data Source: appscan_execution_artifacts (lists the downloadable artifacts of a scan execution)
+// ----------------------------------------------------------------
+
+func dataSourceExecutionArtifacts() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: withContext(dataSourceExecutionArtifactsRead),
+		Schema: map[string]*schema.Schema{
+			"execution_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The ID of the scan execution.",
+				ValidateFunc: validateGUID,
+			},
+			"scan_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the scan the execution belongs to.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the execution.",
+			},
+			"artifacts": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The artifacts available for download. The URLs require the same bearer token as the rest of the API.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The kind of artifact: scan_logs, support_logs, raw_results or scan_file (DAST traffic and manual explore data).",
+						},
+						"url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The URL the artifact can be downloaded from.",
+						},
+						"size": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The size of the artifact in bytes, or -1 when the API does not report it.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceExecutionArtifactsRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	executionID := d.Get("execution_id").(string)
+	if !appscan.IsGUID(executionID) {
+		return fmt.Errorf("invalid execution_id: %q", executionID)
+	}
+
+	urlStr := fmt.Sprintf("%s/Scans/Execution/%s", client.ApiBase, url.PathEscape(executionID))
+	req, err := client.newRequest(ctx, "GET", urlStr, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return appscan.NewAPIError("read scan execution", resp)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	var execution struct {
+		Id                  string `json:"Id"`
+		ScanId              string `json:"ScanId"`
+		Status              string `json:"Status"`
+		HasLogs             bool   `json:"HasLogs"`
+		SupportModeEnabled  bool   `json:"SupportModeEnabled"`
+		IsScanFileAvailable bool   `json:"IsScanFileAvailable"`
+	}
+	if err := appscan.DecodeJSON(resp, respBody, &execution); err != nil {
+		return err
+	}
+
+	type artifact struct{ name, url string }
+	var available []artifact
+	if execution.HasLogs {
+		available = append(available, artifact{"scan_logs", fmt.Sprintf("%s/Scans/ScanLogs/%s", client.ApiBase, url.PathEscape(execution.ScanId))})
+		if execution.SupportModeEnabled {
+			available = append(available, artifact{"support_logs", fmt.Sprintf("%s/Scans/ScanLogs/%s?support=true", client.ApiBase, url.PathEscape(execution.ScanId))})
+		}
+	}
+	if execution.Status == "Ready" {
+		available = append(available, artifact{"raw_results", fmt.Sprintf("%s/Scans/ExecutionRawResults/%s", client.ApiBase, url.PathEscape(executionID))})
+	}
+	if execution.IsScanFileAvailable {
+		available = append(available, artifact{"scan_file", fmt.Sprintf("%s/Scans/DastScanFile/%s", client.ApiBase, url.PathEscape(executionID))})
+	}
+
+	artifacts := make([]interface{}, len(available))
+	for i, a := range available {
+		size, err := artifactSize(ctx, client, a.url)
+		if err != nil {
+			return err
+		}
+		artifacts[i] = map[string]interface{}{
+			"name": a.name,
+			"url":  a.url,
+			"size": size,
+		}
+	}
+	if err := d.Set("artifacts", artifacts); err != nil {
+		return err
+	}
+	d.Set("scan_id", execution.ScanId)
+	d.Set("status", execution.Status)
+
+	d.SetId(executionID)
+	return nil
+}
+
+// artifactSize returns the Content-Length the API reports for a download,
+// or -1 when it does not answer HEAD requests with one.
+func artifactSize(ctx context.Context, client *AppScanClient, urlStr string) (int64, error) {
+	req, err := client.newRequest(ctx, "HEAD", urlStr, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return -1, nil
+	}
+	return resp.ContentLength, nil
+}
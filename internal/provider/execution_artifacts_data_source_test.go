@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccExecutionArtifactsDataSource(t *testing.T) {
+	m := newMockServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_dast_scan" "test" {
+  application_id = %q
+  name           = "nightly"
+  starting_url   = "https://example.com/"
+}
+
+data "appscan_execution_artifacts" "test" {
+  execution_id = appscan_dast_scan.test.latest_execution_id
+}
+`, mockApplicationID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.appscan_execution_artifacts.test", "scan_id", "appscan_dast_scan.test", "id"),
+					resource.TestCheckResourceAttr("data.appscan_execution_artifacts.test", "artifacts.#", "3"),
+					resource.TestCheckResourceAttr("data.appscan_execution_artifacts.test", "artifacts.0.name", "scan_logs"),
+					resource.TestCheckResourceAttr("data.appscan_execution_artifacts.test", "artifacts.0.size", "21"),
+					resource.TestCheckResourceAttr("data.appscan_execution_artifacts.test", "artifacts.2.name", "scan_file"),
+				),
+			},
+		},
+	})
+}
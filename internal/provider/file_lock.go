@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// The files shared by the provider processes of a run, such as the token
+// cache and the run summary, are updated under a lock file: Terraform runs
+// every provider configuration, and the plan and the apply, in a plugin
+// process of its own.
+
+// fileLockTimeout is how long a process waits for the lock of another one
+// before giving up. Locks older than fileLockTimeout are left by processes
+// that died holding them and are removed.
+const fileLockTimeout = 2 * time.Minute
+
+// fileLockPoll is how often a process checks whether the lock of another
+// one was released.
+const fileLockPoll = 50 * time.Millisecond
+
+// lockFile creates the lock file path, waiting up to fileLockTimeout for
+// another process to remove it, and returns the function removing it.
+func lockFile(path string) (func(), error) {
+	deadline := time.Now().Add(fileLockTimeout)
+	for {
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o600)
+		if err == nil {
+			f.Close()
+			return func() { os.Remove(path) }, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("lock %s: %w", path, err)
+		}
+		if info, err := os.Stat(path); err == nil && time.Since(info.ModTime()) > fileLockTimeout {
+			os.Remove(path)
+			continue
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("lock %s: held by another process", path)
+		}
+		time.Sleep(fileLockPoll)
+	}
+}
+
+// writeFileAtomic replaces the file path with body, readable by its owner
+// only, through a temporary file so that readers never see it half written.
+func writeFileAtomic(path string, body []byte) error {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(body); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err := os.Rename(f.Name(), path); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return nil
+}
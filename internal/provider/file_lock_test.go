@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLockFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token.lock")
+	unlock, err := lockFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	released := make(chan struct{})
+	go func() {
+		time.Sleep(3 * fileLockPoll)
+		close(released)
+		unlock()
+	}()
+	unlock2, err := lockFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-released:
+	default:
+		t.Error("the lock was taken while held")
+	}
+	unlock2()
+
+	// Locks left by dead processes are removed.
+	if err := os.WriteFile(path, nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	stale := time.Now().Add(-2 * fileLockTimeout)
+	if err := os.Chtimes(path, stale, stale); err != nil {
+		t.Fatal(err)
+	}
+	unlock3, err := lockFile(path)
+	if err != nil {
+		t.Fatalf("lockFile() = %v on a stale lock", err)
+	}
+	unlock3()
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("the lock was not removed: %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// uploadFile uploads a local file through /api/v4/FileUpload and returns the
+// file ID to reference it from scan creation payloads, along with the SHA256
+// of the uploaded content. fileType may be empty for files the API
+// identifies by itself (e.g. IRX archives). The file is streamed, see
+// appscan.Client.UploadFile.
+func uploadFile(ctx context.Context, client *AppScanClient, path, fileType string) (string, string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", "", err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return "", "", err
+	}
+
+	name := filepath.Base(path)
+	hash := sha256.New()
+	fileID, err := client.api().UploadFile(ctx, appscan.Upload{
+		Name:      name,
+		Content:   io.TeeReader(f, hash),
+		Size:      info.Size(),
+		FileType:  fileType,
+		ChunkSize: client.UploadChunkSize,
+		Progress: func(sent, total int64) {
+			percent := int64(100)
+			if total > 0 {
+				percent = sent * 100 / total
+			}
+			log.Printf("[DEBUG] uploading %s: %d/%d bytes (%d%%)", name, sent, total, percent)
+		},
+	})
+	if err != nil {
+		return "", "", err
+	}
+	log.Printf("[INFO] uploaded %s (%d bytes) as file %s", path, info.Size(), fileID)
+	return fileID, hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// fileSHA256 returns the hex-encoded SHA256 of a local file.
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// customizeDiffFileChecksum makes file-backed resources track the content of
+// the file at pathAttr rather than its path: checksumAttr holds the SHA256
+// of the uploaded content and the resource is replaced only when it changes.
+// A renamed or touched file with the same content is not uploaded again.
+func customizeDiffFileChecksum(pathAttr, checksumAttr string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+		if !d.NewValueKnown(pathAttr) {
+			// The file is produced during the same apply: assume its
+			// content changes.
+			if err := d.SetNewComputed(checksumAttr); err != nil {
+				return err
+			}
+			if d.Id() != "" {
+				return d.ForceNew(checksumAttr)
+			}
+			return nil
+		}
+		path, _ := d.Get(pathAttr).(string)
+		if path == "" {
+			return nil
+		}
+		sum, err := fileSHA256(path)
+		if err != nil {
+			// Leave the plan alone; the upload reports the error at apply.
+			return nil
+		}
+		old, _ := d.GetChange(checksumAttr)
+		if old.(string) == sum {
+			return nil
+		}
+		if err := d.SetNew(checksumAttr, sum); err != nil {
+			return err
+		}
+		// State written before the checksum was tracked has none: record it
+		// without launching a new scan.
+		if d.Id() != "" && old.(string) != "" {
+			return d.ForceNew(checksumAttr)
+		}
+		return nil
+	}
+}
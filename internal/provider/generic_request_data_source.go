@@ -0,0 +1,245 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ----------------------------------------------------------------
+// Data Source: appscan_generic_request (raw API call, for endpoints the provider does not model)
+// ----------------------------------------------------------------
+
+// genericPathRegexp matches the paths appscan_generic_request accepts,
+// relative to the API base, e.g. Account/TenantInfo.
+var genericPathRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.-]*(/[A-Za-z0-9_.-]+)*$`)
+
+// genericPathClean tells whether the path p of appscan_generic_request has
+// no `.` or `..` segment, which would make the request, and the token sent
+// with it, leave the API base.
+func genericPathClean(p string) bool {
+	if path.Clean(p) != p {
+		return false
+	}
+	for _, segment := range strings.Split(p, "/") {
+		if segment == "." || segment == ".." {
+			return false
+		}
+	}
+	return true
+}
+
+// validateGenericPath returns the validation of the paths matching re and
+// having no `.` or `..` segment, described by message.
+func validateGenericPath(re *regexp.Regexp, message string) schema.SchemaValidateFunc {
+	return func(v interface{}, k string) ([]string, []error) {
+		p, ok := v.(string)
+		if !ok {
+			return nil, []error{fmt.Errorf("expected type of %s to be string", k)}
+		}
+		if !re.MatchString(p) || !genericPathClean(p) {
+			return nil, []error{fmt.Errorf("invalid value for %s (%s)", k, message)}
+		}
+		return nil, nil
+	}
+}
+
+// genericMethods are the methods appscan_generic_request sends.
+var genericMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE"}
+
+// genericRequestSchema returns the arguments appscan_generic_request shares
+// between the data source and the resource.
+func genericRequestSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"query": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Description: "The query parameters of the requests.",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"body": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "The JSON body of the request, e.g. built with jsonencode().",
+			ValidateFunc: validation.StringIsJSON,
+		},
+		"response_fields": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "The fields of the JSON response copied to response_values, by name; nested fields are named by their path, e.g. `LatestExecution.Status`.",
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+		},
+		"response_values": {
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Description: "The values of response_fields, by field. Strings are copied as is, other values as JSON; missing fields are left out.",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"response_body": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The body of the response, as returned by the API. Use jsondecode() to read it.",
+		},
+	}
+}
+
+func dataSourceGenericRequest() *schema.Resource {
+	s := genericRequestSchema()
+	s["path"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		Description:  "The path of the endpoint, relative to the API base, e.g. `Account/TenantInfo`.",
+		ValidateFunc: validateGenericPath(genericPathRegexp, "must be a path such as Account/TenantInfo, without leading slash, query or . and .. segments"),
+	}
+	s["method"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "GET",
+		Description:  "The method of the request. The request is sent on every refresh: only use methods without side effects. Defaults to GET.",
+		ValidateFunc: validation.StringInSlice(genericMethods, false),
+	}
+	return &schema.Resource{
+		ReadWithoutTimeout: withContext(dataSourceGenericRequestRead),
+		Description: "Sends a request to an endpoint of the AppScan API the provider does not model yet, with the authentication of the provider, and reads its response. " +
+			"It is an escape hatch: the provider neither validates the request nor interprets the response.",
+		Schema: s,
+	}
+}
+
+func dataSourceGenericRequestRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	method := d.Get("method").(string)
+	path := d.Get("path").(string)
+	query := genericQuery(d)
+	body := d.Get("body").(string)
+
+	respBody, err := sendGenericRequest(ctx, client, method, path, query, body)
+	if err != nil {
+		return err
+	}
+	if err := setGenericResponse(d, respBody); err != nil {
+		return err
+	}
+
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s %s?%s\n%s", method, path, query.Encode(), body)))
+	d.SetId(hex.EncodeToString(sum[:]))
+	return nil
+}
+
+// genericQuery returns the query argument of d.
+func genericQuery(d *schema.ResourceData) url.Values {
+	query := url.Values{}
+	for k, v := range d.Get("query").(map[string]interface{}) {
+		query.Set(k, v.(string))
+	}
+	return query
+}
+
+// sendGenericRequest sends a request of method to path, relative to the API
+// base, and returns the body of its response.
+func sendGenericRequest(ctx context.Context, client *AppScanClient, method, path string, query url.Values, body string) ([]byte, error) {
+	// The paths are validated, but not the IDs read from the API and
+	// substituted for {id}.
+	if !genericPathClean(path) {
+		return nil, fmt.Errorf("refusing to send %s %s: the path leaves the API base", method, path)
+	}
+	urlStr := fmt.Sprintf("%s/%s", client.ApiBase, path)
+	if len(query) > 0 {
+		urlStr += "?" + query.Encode()
+	}
+	var reqBody io.Reader
+	if body != "" {
+		reqBody = bytes.NewBufferString(body)
+	}
+	req, err := client.newRequest(ctx, method, urlStr, reqBody)
+	if err != nil {
+		return nil, err
+	}
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, appscan.APIErrorFromBody(fmt.Sprintf("%s %s", method, path), resp, respBody)
+	}
+	return respBody, nil
+}
+
+// setGenericResponse sets response_body and response_values from the body
+// of a response.
+func setGenericResponse(d *schema.ResourceData, respBody []byte) error {
+	values := map[string]interface{}{}
+	if fields := d.Get("response_fields").([]interface{}); len(fields) > 0 {
+		var response interface{}
+		if err := json.Unmarshal(respBody, &response); err != nil {
+			return fmt.Errorf("cannot read response_fields, the response is not JSON: %w", err)
+		}
+		for _, f := range fields {
+			field := f.(string)
+			value, ok := jsonPathValue(response, field)
+			if !ok {
+				continue
+			}
+			if s, isString := value.(string); isString {
+				values[field] = s
+				continue
+			}
+			encoded, err := json.Marshal(value)
+			if err != nil {
+				return err
+			}
+			values[field] = string(encoded)
+		}
+	}
+	d.Set("response_body", string(respBody))
+	return d.Set("response_values", values)
+}
+
+// jsonPathValue returns the field of a decoded JSON value at path, whose
+// parts are separated by dots. Parts index arrays by number.
+func jsonPathValue(value interface{}, path string) (interface{}, bool) {
+	for _, part := range strings.Split(path, ".") {
+		switch v := value.(type) {
+		case map[string]interface{}:
+			var ok bool
+			if value, ok = v[part]; !ok {
+				return nil, false
+			}
+		case []interface{}:
+			i, err := strconv.Atoi(part)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			value = v[i]
+		default:
+			return nil, false
+		}
+	}
+	return value, value != nil
+}
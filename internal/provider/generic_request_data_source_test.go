@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGenericRequestDataSource(t *testing.T) {
+	m := newMockServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + `
+data "appscan_generic_request" "test" {
+  path = "Apps/../../x"
+}
+`,
+				ExpectError: regexp.MustCompile(`invalid value for path`),
+			},
+			{
+				Config: testAccProviderConfig(m) + `
+data "appscan_generic_request" "test" {
+  path            = "Account/TenantInfo"
+  response_fields = ["TenantName", "Subscriptions.1.NSeats", "Subscriptions.9.NSeats", "AllowPresence"]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_generic_request.test", "response_values.%", "3"),
+					resource.TestCheckResourceAttr("data.appscan_generic_request.test", "response_values.TenantName", "Mock Tenant"),
+					resource.TestCheckResourceAttr("data.appscan_generic_request.test", "response_values.Subscriptions.1.NSeats", "100"),
+					resource.TestCheckResourceAttr("data.appscan_generic_request.test", "response_values.AllowPresence", "true"),
+					resource.TestMatchResourceAttr("data.appscan_generic_request.test", "response_body", regexp.MustCompile(`"TenantId":`)),
+				),
+			},
+			{
+				Config: testAccProviderConfig(m) + `
+data "appscan_generic_request" "test" {
+  path = "Account/Missing"
+}
+`,
+				ExpectError: regexp.MustCompile(`failed to GET Account/Missing, status: 404`),
+			},
+		},
+	})
+}
+
+func TestJSONPathValue(t *testing.T) {
+	value := map[string]interface{}{
+		"Items": []interface{}{map[string]interface{}{"Id": "a"}},
+		"Count": 1.0,
+		"Next":  nil,
+	}
+	for path, expected := range map[string]interface{}{
+		"Count":      1.0,
+		"Items.0.Id": "a",
+		"Items.1.Id": nil,
+		"Items.x":    nil,
+		"Count.Id":   nil,
+		"Next":       nil,
+		"Missing":    nil,
+	} {
+		actual, ok := jsonPathValue(value, path)
+		if ok != (expected != nil) || actual != expected {
+			t.Errorf("jsonPathValue(%q) = %v, %v, want %v", path, actual, ok, expected)
+		}
+	}
+}
+
+func TestGenericPathClean(t *testing.T) {
+	for p, expected := range map[string]bool{
+		"Account/TenantInfo":    true,
+		"Apps/a.b/..c":          true,
+		"Apps/..":               false,
+		"Apps/../../x":          false,
+		"Apps/./Count":          false,
+		"Apps//Count":           false,
+		"Apps/":                 false,
+		"Apps/{id}/../Settings": false,
+	} {
+		if actual := genericPathClean(p); actual != expected {
+			t.Errorf("genericPathClean(%q) = %t, want %t", p, actual, expected)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/structure"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ----------------------------------------------------------------
+// Resource: appscan_generic_request (raw API object, for endpoints the provider does not model)
+// ----------------------------------------------------------------
+
+// The resource creates an object by sending its body to path, reads it back
+// from read_path and updates and deletes it at update_path and destroy_path,
+// where {id} stands for the ID the creation returned. The body is not
+// compared with what the API reads back: only changes of the configuration
+// are applied.
+
+// genericPathTemplateRegexp matches the paths of the object of
+// appscan_generic_request, in which {id} stands for its ID.
+var genericPathTemplateRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_.-]*(/([A-Za-z0-9_.-]+|\{id\}))*$`)
+
+func resourceAppScanGenericRequest() *schema.Resource {
+	s := genericRequestSchema()
+	s["body"].DiffSuppressFunc = structure.SuppressJsonDiff
+	s["path"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		ForceNew:     true,
+		Description:  "The path the object is created at, relative to the API base, e.g. `Webhooks`.",
+		ValidateFunc: validateGenericPath(genericPathRegexp, "must be a path such as Webhooks, without leading slash, query or . and .. segments"),
+	}
+	pathTemplate := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  description,
+			ValidateFunc: validateGenericPath(genericPathTemplateRegexp, "must be a path such as Webhooks/{id}, without leading slash, query or . and .. segments"),
+		}
+	}
+	s["read_path"] = pathTemplate("The path the object is read from, `{id}` standing for its ID. Defaults to `<path>/{id}`.")
+	s["update_path"] = pathTemplate("The path the object is updated at. Defaults to read_path.")
+	s["destroy_path"] = pathTemplate("The path the object is deleted at. Defaults to read_path.")
+	s["create_method"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "POST",
+		Description:  "The method creating the object. Defaults to POST.",
+		ValidateFunc: validation.StringInSlice(genericMethods, false),
+	}
+	s["update_method"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "PUT",
+		Description:  "The method updating the object with body, when body or query changes. Defaults to PUT.",
+		ValidateFunc: validation.StringInSlice(genericMethods, false),
+	}
+	s["destroy_method"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      "DELETE",
+		Description:  "The method deleting the object. Defaults to DELETE.",
+		ValidateFunc: validation.StringInSlice(genericMethods, false),
+	}
+	s["id_field"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		ForceNew:     true,
+		Default:      "Id",
+		Description:  "The field of the creation response holding the ID of the object, a path such as `Item.Id` when nested. Defaults to Id.",
+		ValidateFunc: validation.StringIsNotEmpty,
+	}
+	s["id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "The ID of the object, read from id_field.",
+	}
+	return &schema.Resource{
+		CreateWithoutTimeout: withContext(resourceAppScanGenericRequestCreate),
+		ReadWithoutTimeout:   withContext(resourceAppScanGenericRequestRead),
+		UpdateWithoutTimeout: withContext(resourceAppScanGenericRequestUpdate),
+		DeleteWithoutTimeout: withContext(resourceAppScanGenericRequestDelete),
+		Description: "Manages an object of an endpoint of the AppScan API the provider does not model yet, with the authentication of the provider. " +
+			"It is an escape hatch: the provider neither validates the body nor compares it with the object the API reads back, so that changes made outside of Terraform are not detected.",
+		Schema: s,
+	}
+}
+
+func resourceAppScanGenericRequestCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	path := d.Get("path").(string)
+	method := d.Get("create_method").(string)
+
+	respBody, err := sendGenericRequest(ctx, client, method, path, genericQuery(d), d.Get("body").(string))
+	if err != nil {
+		return err
+	}
+	var response interface{}
+	if err := json.Unmarshal(respBody, &response); err != nil {
+		return fmt.Errorf("cannot read the ID of the object created by %s %s, the response is not JSON: %w", method, path, err)
+	}
+	idField := d.Get("id_field").(string)
+	id, ok := jsonPathValue(response, idField)
+	if !ok {
+		return fmt.Errorf("the response of %s %s has no %s field: set id_field", method, path, idField)
+	}
+	if s, isString := id.(string); isString {
+		d.SetId(s)
+	} else {
+		d.SetId(fmt.Sprint(id))
+	}
+	client.Summary.record("generic_object_created", "appscan_generic_request", d.Id(), map[string]string{"path": path})
+	return resourceAppScanGenericRequestRead(ctx, d, m)
+}
+
+func resourceAppScanGenericRequestRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	path := genericObjectPath(d, "read_path")
+
+	respBody, err := sendGenericRequest(ctx, client, "GET", path, genericQuery(d), "")
+	var apiErr *appscan.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		log.Printf("[INFO] %s no longer exists, removing it from state", path)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return setGenericResponse(d, respBody)
+}
+
+func resourceAppScanGenericRequestUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if d.HasChanges("body", "query") {
+		client := m.(*AppScanClient)
+		path := genericObjectPath(d, "update_path")
+		if _, err := sendGenericRequest(ctx, client, d.Get("update_method").(string), path, genericQuery(d), d.Get("body").(string)); err != nil {
+			return err
+		}
+	}
+	return resourceAppScanGenericRequestRead(ctx, d, m)
+}
+
+func resourceAppScanGenericRequestDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	path := genericObjectPath(d, "destroy_path")
+
+	_, err := sendGenericRequest(ctx, client, d.Get("destroy_method").(string), path, genericQuery(d), "")
+	var apiErr *appscan.APIError
+	if err != nil && !(errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound) {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
+
+// genericObjectPath returns the path attr of the object d, read_path when
+// attr is not set, and path/{id} when neither is, with its ID in place of
+// {id}.
+func genericObjectPath(d *schema.ResourceData, attr string) string {
+	template := d.Get(attr).(string)
+	if template == "" {
+		template = d.Get("read_path").(string)
+	}
+	if template == "" {
+		template = d.Get("path").(string) + "/{id}"
+	}
+	return strings.ReplaceAll(template, "{id}", url.PathEscape(d.Id()))
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccGenericRequestResource(t *testing.T) {
+	m := newMockServer(t)
+	var appID string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			if m.find("Apps", appID) != nil {
+				return fmt.Errorf("application %s was not deleted", appID)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + `
+resource "appscan_generic_request" "escape" {
+  path      = "Apps"
+  read_path = "Apps/{id}/../../../Account/TenantInfo"
+}
+`,
+				ExpectError: regexp.MustCompile(`invalid value for read_path`),
+			},
+			{
+				Config: testAccGenericRequestResourceConfig(m, "generic"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccSaveID("appscan_generic_request.test", &appID),
+					resource.TestCheckResourceAttrSet("appscan_generic_request.test", "response_values.Count"),
+					testAccCheckMockApp(m, &appID, "generic"),
+				),
+			},
+			{
+				// The body is updated in place.
+				Config: testAccGenericRequestResourceConfig(m, "renamed"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckID("appscan_generic_request.test", &appID, true),
+					testAccCheckMockApp(m, &appID, "renamed"),
+				),
+			},
+			{
+				// Reformatting the body changes nothing.
+				Config: testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_generic_request" "test" {
+  path            = "Apps"
+  body            = <<-EOT
+    {"AssetGroupId": "%s", "Name": "renamed"}
+  EOT
+  read_path       = "Apps"
+  update_path     = "Apps/{id}"
+  destroy_path    = "Apps/{id}"
+  response_fields = ["Count"]
+}
+`, mockAssetGroupID),
+				PlanOnly: true,
+			},
+			{
+				// Changing only the query updates the object too.
+				PreConfig: func() {
+					m.mu.Lock()
+					defer m.mu.Unlock()
+					m.requests = map[string]int{}
+				},
+				Config: testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_generic_request" "test" {
+  path = "Apps"
+  body = jsonencode({
+    Name         = "renamed"
+    AssetGroupId = %q
+  })
+  query = {
+    notify = "false"
+  }
+  read_path       = "Apps"
+  update_path     = "Apps/{id}"
+  destroy_path    = "Apps/{id}"
+  response_fields = ["Count"]
+}
+`, mockAssetGroupID),
+				Check: func(*terraform.State) error {
+					m.mu.Lock()
+					defer m.mu.Unlock()
+					if updates := m.requests["PUT /api/v4/Apps/"+appID]; updates != 1 {
+						return fmt.Errorf("the application was updated %d times, want 1", updates)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+// testAccGenericRequestResourceConfig manages an application, which the API
+// lists but does not serve by ID.
+func testAccGenericRequestResourceConfig(m *mockServer, name string) string {
+	return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_generic_request" "test" {
+  path = "Apps"
+  body = jsonencode({
+    Name         = %q
+    AssetGroupId = %q
+  })
+  read_path       = "Apps"
+  update_path     = "Apps/{id}"
+  destroy_path    = "Apps/{id}"
+  response_fields = ["Count"]
+}
+`, name, mockAssetGroupID)
+}
+
+// testAccCheckMockApp checks the name of the mock application *id.
+func testAccCheckMockApp(m *mockServer, id *string, name string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		app := m.find("Apps", *id)
+		if app == nil || app["Name"] != name {
+			return fmt.Errorf("application %s = %v, want name %q", *id, app, name)
+		}
+		return nil
+	}
+}
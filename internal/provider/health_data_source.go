@@ -0,0 +1,215 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ----------------------------------------------------------------
+// Data Source: appscan_health (checks API reachability, authentication and entitlements)
+// ----------------------------------------------------------------
+
+// scanTechnologies maps the technology names of the API to the short names
+// used in attribute names and arguments.
+var scanTechnologies = map[string]string{
+	"DynamicAnalyzer": "dast",
+	"StaticAnalyzer":  "sast",
+	"ScaAnalyzer":     "sca",
+	"IASTAnalyzer":    "iast",
+}
+
+func dataSourceHealth() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: withContext(dataSourceHealthRead),
+		Schema: map[string]*schema.Schema{
+			"required_technologies": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Technologies the tenant must be entitled to for the check to pass. Allowed values: dast, sast, sca, iast.",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"dast", "sast", "sca", "iast"}, false),
+				},
+			},
+			"fail_if_unhealthy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "If true, reading the data source fails when a check does not pass, so a plan stops right away. Set to false to only expose the results.",
+			},
+			"reachable": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the API answered.",
+			},
+			"authenticated": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the API accepted the provider's credentials.",
+			},
+			"entitled": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the tenant is entitled to every technology of required_technologies.",
+			},
+			"healthy": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether every check passed.",
+			},
+			"message": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Why the check failed, empty when healthy.",
+			},
+			"tenant_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the tenant.",
+			},
+			"tenant_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the tenant.",
+			},
+			"active_technologies": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The technologies the tenant is entitled to (dast, sast, sca, iast).",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"presence_allowed": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the tenant may use AppScan Presence to scan private sites.",
+			},
+			"capabilities": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The optional API behaviors the provider probed, and whether the API supports them (e.g. `odata_count`, whether collections report their number of entries). The provider adapts to their absence.",
+				Elem:        &schema.Schema{Type: schema.TypeBool},
+			},
+		},
+	}
+}
+
+func dataSourceHealthRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	tenant, reachable, message := checkTenant(ctx, client)
+	d.Set("reachable", reachable)
+	d.Set("authenticated", tenant != nil)
+
+	var active []string
+	entitled := false
+	if tenant != nil {
+		active = parseTechnologies(tenant.ActiveTechnologies)
+		entitled = true
+		for _, v := range d.Get("required_technologies").([]interface{}) {
+			if !slices.Contains(active, v.(string)) {
+				entitled = false
+				message = fmt.Sprintf("tenant %s is not entitled to %s scans", tenant.TenantName, v.(string))
+				break
+			}
+		}
+		d.Set("tenant_id", tenant.TenantId)
+		d.Set("tenant_name", tenant.TenantName)
+		d.Set("presence_allowed", tenant.AllowPresence)
+
+		capabilities, err := probeCapabilities(ctx, client)
+		if err != nil && message == "" {
+			message = err.Error()
+		}
+		if err := d.Set("capabilities", capabilities); err != nil {
+			return err
+		}
+	}
+	if err := d.Set("active_technologies", active); err != nil {
+		return err
+	}
+	d.Set("entitled", entitled)
+	d.Set("healthy", message == "")
+	d.Set("message", message)
+
+	if message != "" && d.Get("fail_if_unhealthy").(bool) {
+		return fmt.Errorf("AppScan health check failed: %s", message)
+	}
+	d.SetId(client.ApiEndpoint)
+	return nil
+}
+
+// appScanTenant holds the TenantInfo fields the provider relies on.
+type appScanTenant struct {
+	TenantId           string `json:"TenantId"`
+	TenantName         string `json:"TenantName"`
+	ActiveTechnologies string `json:"ActiveTechnologies"`
+	AllowPresence      bool   `json:"AllowPresence"`
+
+	NumberOfApps   int                   `json:"NumberOfApps"`
+	MaxScansPerApp int                   `json:"MaxScansPerApp"`
+	MaxUsers       int                   `json:"MaxUsers"`
+	Subscriptions  []appScanSubscription `json:"Subscriptions"`
+
+	// The account settings.
+	ContactEmail                              string `json:"ContactEmail"`
+	AutoDeleteExceededScansPerApp             bool   `json:"AutoDeleteExceededScansPerApp"`
+	AllowAppManagerOverrideAutoDeleteSettings bool   `json:"AllowAppManagerOverrideAutoDeleteSettings"`
+	EnableIssuesAutoClose                     bool   `json:"EnableIssuesAutoClose"`
+	EnableOverrideIssuesAutoClose             bool   `json:"EnableOverrideIssuesAutoClose"`
+	ShowNonCompliantIssuesOnly                bool   `json:"ShowNonCompliantIssuesOnly"`
+	IdleTimeForSignout                        int    `json:"IdleTimeForSignout"`
+	DomainVerificationRequired                bool   `json:"DomainVerificationRequired"`
+}
+
+// checkTenant fetches the tenant information. When that fails, it returns a
+// nil tenant, whether the API answered at all, and why it failed.
+func checkTenant(ctx context.Context, client *AppScanClient) (*appScanTenant, bool, string) {
+	urlStr := fmt.Sprintf("%s/Account/TenantInfo", client.ApiBase)
+	req, err := client.newRequest(ctx, "GET", urlStr, nil)
+	if err != nil {
+		return nil, false, err.Error()
+	}
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return nil, false, fmt.Sprintf("API unreachable: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return nil, true, fmt.Sprintf("authentication rejected: %s", appscan.NewAPIError("read tenant information", resp))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, true, fmt.Sprintf("API unavailable: %s", appscan.NewAPIError("read tenant information", resp))
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, false, fmt.Sprintf("API unreachable: %s", err)
+	}
+	var tenant appScanTenant
+	if err := appscan.DecodeJSON(resp, respBody, &tenant); err != nil {
+		return nil, true, fmt.Sprintf("API unavailable: unexpected tenant information: %s", err)
+	}
+	return &tenant, true, ""
+}
+
+// parseTechnologies converts a flags enum such as
+// "DynamicAnalyzer, StaticAnalyzer" to short technology names.
+func parseTechnologies(flags string) []string {
+	var technologies []string
+	for _, f := range strings.Split(flags, ",") {
+		if t, ok := scanTechnologies[strings.TrimSpace(f)]; ok {
+			technologies = append(technologies, t)
+		}
+	}
+	return technologies
+}
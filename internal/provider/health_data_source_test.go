@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccHealthDataSource(t *testing.T) {
+	m := newMockServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + `
+data "appscan_health" "test" {
+  required_technologies = ["dast", "sast"]
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_health.test", "healthy", "true"),
+					resource.TestCheckResourceAttr("data.appscan_health.test", "tenant_id", mockTenantID),
+					resource.TestCheckResourceAttr("data.appscan_health.test", "active_technologies.#", "2"),
+					resource.TestCheckResourceAttr("data.appscan_health.test", "capabilities.odata_count", "true"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(m) + `
+data "appscan_health" "test" {
+  required_technologies = ["sca"]
+  fail_if_unhealthy     = false
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_health.test", "healthy", "false"),
+					resource.TestCheckResourceAttr("data.appscan_health.test", "authenticated", "true"),
+					resource.TestCheckResourceAttr("data.appscan_health.test", "entitled", "false"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(m) + `
+data "appscan_health" "test" {
+  required_technologies = ["iast"]
+}
+`,
+				ExpectError: regexp.MustCompile("not entitled to iast scans"),
+			},
+		},
+	})
+}
@@ -0,0 +1,261 @@
+package provider
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ----------------------------------------------------------------
+// Data Source: appscan_import_config (HCL and import blocks generated from a portal export)
+// ----------------------------------------------------------------
+
+// exportColumns maps the normalized CSV headers of a portal export to the
+// ApplicationModel fields they hold.
+var exportColumns = map[string]string{
+	"id":               "Id",
+	"applicationid":    "Id",
+	"name":             "Name",
+	"applicationname":  "Name",
+	"description":      "Description",
+	"assetgroupid":     "AssetGroupId",
+	"assetgroup":       "AssetGroupName",
+	"assetgroupname":   "AssetGroupName",
+	"businessunitid":   "BusinessUnitId",
+	"businessunit":     "BusinessUnit",
+	"businessunitname": "BusinessUnit",
+	"businessimpact":   "BusinessImpact",
+}
+
+var hclLabelInvalidRegexp = regexp.MustCompile(`[^a-z0-9_]+`)
+
+func dataSourceImportConfig() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceImportConfigRead,
+		Schema: map[string]*schema.Schema{
+			"export_file": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The path of the application export of the portal, either a CSV file whose header names the columns (Id, Name, Description, Asset Group, Asset Group Id, Business Unit, Business Unit Id, Business Impact) or a JSON array of applications as returned by the API.",
+			},
+			"format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "The format of the export: csv or json. By default, it is guessed from the file extension.",
+				ValidateFunc: validation.StringInSlice([]string{"csv", "json"}, false),
+			},
+			"hcl": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The generated configuration: an `appscan_application` resource and an `import` block (Terraform 1.5 or later) per application, and `appscan_asset_group` and `appscan_business_unit` data sources for the groups and units referenced by name. Write it to a .tf file, e.g. with the local_file resource, then run terraform plan.",
+			},
+			"application_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of applications of the export.",
+			},
+		},
+	}
+}
+
+func dataSourceImportConfigRead(d *schema.ResourceData, m interface{}) error {
+	path := d.Get("export_file").(string)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	format := d.Get("format").(string)
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(path)), ".")
+	}
+	var apps []map[string]interface{}
+	switch format {
+	case "csv":
+		apps, err = parseCSVExport(content)
+	case "json":
+		apps, err = parseJSONExport(content)
+	default:
+		return fmt.Errorf("cannot guess the format of %s: set format to csv or json", path)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid export %s: %w", path, err)
+	}
+
+	hcl, err := generateImportConfig(apps)
+	if err != nil {
+		return fmt.Errorf("invalid export %s: %w", path, err)
+	}
+	d.Set("hcl", hcl)
+	d.Set("application_count", len(apps))
+
+	sum := sha256.Sum256(content)
+	d.SetId(hex.EncodeToString(sum[:]))
+	return nil
+}
+
+// parseCSVExport reads the applications of a CSV export. Headers are matched
+// ignoring case, spaces and underscores; unknown columns are ignored.
+func parseCSVExport(content []byte) ([]map[string]interface{}, error) {
+	reader := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(content, []byte("\ufeff"))))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, fmt.Errorf("no header row")
+	}
+	fields := make([]string, len(records[0]))
+	for i, h := range records[0] {
+		key := strings.ToLower(strings.NewReplacer(" ", "", "_", "").Replace(strings.TrimSpace(h)))
+		fields[i] = exportColumns[key]
+	}
+
+	apps := make([]map[string]interface{}, 0, len(records)-1)
+	for _, record := range records[1:] {
+		app := map[string]interface{}{}
+		for i, v := range record {
+			if i < len(fields) && fields[i] != "" && v != "" {
+				app[fields[i]] = strings.TrimSpace(v)
+			}
+		}
+		apps = append(apps, app)
+	}
+	return apps, nil
+}
+
+// parseJSONExport reads the applications of a JSON export: an array of
+// ApplicationModel, or a page of the Apps collection.
+func parseJSONExport(content []byte) ([]map[string]interface{}, error) {
+	var apps []map[string]interface{}
+	if err := json.Unmarshal(content, &apps); err == nil {
+		return apps, nil
+	}
+	var page struct {
+		Items []map[string]interface{} `json:"Items"`
+	}
+	if err := json.Unmarshal(content, &page); err != nil {
+		return nil, err
+	}
+	return page.Items, nil
+}
+
+// generateImportConfig renders the configuration importing the applications.
+// Resources are labelled after the names, made unique, and sorted so that the
+// output only changes when the export does.
+func generateImportConfig(apps []map[string]interface{}) (string, error) {
+	labels := map[string]int{}
+	label := func(kind, name string) string {
+		base := strings.Trim(hclLabelInvalidRegexp.ReplaceAllString(strings.ToLower(name), "_"), "_")
+		if base == "" || (base[0] >= '0' && base[0] <= '9') {
+			base = kind + "_" + base
+		}
+		labels[kind+"."+base]++
+		if n := labels[kind+"."+base]; n > 1 {
+			return fmt.Sprintf("%s_%d", base, n)
+		}
+		return base
+	}
+
+	sorted := append([]map[string]interface{}(nil), apps...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return stringField(sorted[i], "Name") < stringField(sorted[j], "Name")
+	})
+
+	var data, resources strings.Builder
+	assetGroups := map[string]string{}
+	businessUnits := map[string]string{}
+	reference := func(kind, name, id string, seen map[string]string) string {
+		if name == "" {
+			return hclQuote(id)
+		}
+		if ref, ok := seen[name]; ok {
+			return ref
+		}
+		l := label(kind, name)
+		fmt.Fprintf(&data, "data %q %q {\n  name = %s\n}\n\n", "appscan_"+kind, l, hclQuote(name))
+		seen[name] = fmt.Sprintf("data.appscan_%s.%s.id", kind, l)
+		return seen[name]
+	}
+
+	for _, app := range sorted {
+		id, name := stringField(app, "Id"), stringField(app, "Name")
+		if !appscan.IsGUID(id) {
+			return "", fmt.Errorf("application %q has no valid Id: %q", name, id)
+		}
+		if name == "" {
+			return "", fmt.Errorf("application %s has no Name", id)
+		}
+		groupID, groupName := stringField(app, "AssetGroupId"), stringField(app, "AssetGroupName")
+		if groupID == "" && groupName == "" {
+			return "", fmt.Errorf("application %s has no asset group", id)
+		}
+
+		attributes := [][2]string{{"name", hclQuote(name)}}
+		if description := stringField(app, "Description"); description != "" {
+			attributes = append(attributes, [2]string{"description", hclQuote(description)})
+		}
+		attributes = append(attributes, [2]string{"asset_group_id", reference("asset_group", groupName, groupID, assetGroups)})
+		if unitID, unitName := stringField(app, "BusinessUnitId"), stringField(app, "BusinessUnit"); unitID != "" || unitName != "" {
+			attributes = append(attributes, [2]string{"business_unit_id", reference("business_unit", unitName, unitID, businessUnits)})
+		}
+		if impact := stringField(app, "BusinessImpact"); impact != "" && impact != "Unspecified" {
+			attributes = append(attributes, [2]string{"business_impact", hclQuote(impact)})
+		}
+
+		l := label("application", name)
+		fmt.Fprintf(&resources, "import {\n  to = appscan_application.%s\n  id = %q\n}\n\n", l, id)
+		fmt.Fprintf(&resources, "resource \"appscan_application\" %q {\n", l)
+		width := 0
+		for _, a := range attributes {
+			width = max(width, len(a[0]))
+		}
+		for _, a := range attributes {
+			fmt.Fprintf(&resources, "  %-*s = %s\n", width, a[0], a[1])
+		}
+		resources.WriteString("}\n\n")
+	}
+	return strings.TrimSuffix(data.String()+resources.String(), "\n"), nil
+}
+
+// hclQuote returns value as an HCL string literal, escaping the template
+// sequences as well as the characters Go and HCL both escape.
+func hclQuote(value string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for i, r := range value {
+		switch {
+		case r == '"' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case (r == '$' || r == '%') && strings.HasPrefix(value[i+1:], "{"):
+			b.WriteRune(r)
+			b.WriteRune(r)
+		case r < 0x20:
+			fmt.Fprintf(&b, `\u%04x`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
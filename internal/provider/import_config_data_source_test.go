@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const testImportConfigCSV = `Name,Id,Description,Asset Group,Asset Group Id,Business Unit,Business Impact,Risk Rating
+payments,44444444-4444-4444-4444-444444444444,"Card ""vault"" ${secret}",Default Asset Group,11111111-1111-1111-1111-111111111111,Default Business Unit,High,Low
+payments,55555555-5555-5555-5555-555555555555,,O'Brien's Apps,22222222-2222-2222-2222-222222222222,,Unspecified,Unknown
+2fa,66666666-6666-6666-6666-666666666666,,,11111111-1111-1111-1111-111111111111,,,
+`
+
+const testImportConfigHCL = `data "appscan_asset_group" "default_asset_group" {
+  name = "Default Asset Group"
+}
+
+data "appscan_business_unit" "default_business_unit" {
+  name = "Default Business Unit"
+}
+
+data "appscan_asset_group" "o_brien_s_apps" {
+  name = "O'Brien's Apps"
+}
+
+import {
+  to = appscan_application.application_2fa
+  id = "66666666-6666-6666-6666-666666666666"
+}
+
+resource "appscan_application" "application_2fa" {
+  name           = "2fa"
+  asset_group_id = "11111111-1111-1111-1111-111111111111"
+}
+
+import {
+  to = appscan_application.payments
+  id = "44444444-4444-4444-4444-444444444444"
+}
+
+resource "appscan_application" "payments" {
+  name             = "payments"
+  description      = "Card \"vault\" $${secret}"
+  asset_group_id   = data.appscan_asset_group.default_asset_group.id
+  business_unit_id = data.appscan_business_unit.default_business_unit.id
+  business_impact  = "High"
+}
+
+import {
+  to = appscan_application.payments_2
+  id = "55555555-5555-5555-5555-555555555555"
+}
+
+resource "appscan_application" "payments_2" {
+  name           = "payments"
+  asset_group_id = data.appscan_asset_group.o_brien_s_apps.id
+}
+`
+
+func TestGenerateImportConfig(t *testing.T) {
+	apps, err := parseCSVExport([]byte(testImportConfigCSV))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hcl, err := generateImportConfig(apps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hcl != testImportConfigHCL {
+		t.Errorf("got:\n%s\nwant:\n%s", hcl, testImportConfigHCL)
+	}
+
+	if _, err := generateImportConfig([]map[string]interface{}{{"Id": "payments", "Name": "payments"}}); err == nil {
+		t.Error("expected an error for an invalid Id")
+	}
+}
+
+func TestHCLQuote(t *testing.T) {
+	for value, want := range map[string]string{
+		"payments":        `"payments"`,
+		`C:\apps "main"`:  `"C:\\apps \"main\""`,
+		"line\nbreak":     `"line\nbreak"`,
+		"${var} %{if} $5": `"$${var} %%{if} $5"`,
+	} {
+		if got := hclQuote(value); got != want {
+			t.Errorf("hclQuote(%q) = %s, want %s", value, got, want)
+		}
+	}
+}
+
+func TestAccImportConfigDataSource(t *testing.T) {
+	m := newMockServer(t)
+	dir := t.TempDir()
+	export := filepath.Join(dir, "apps.json")
+	if err := os.WriteFile(export, []byte(`{"Items": [{"Id": "44444444-4444-4444-4444-444444444444", "Name": "payments", "AssetGroupId": "11111111-1111-1111-1111-111111111111", "BusinessImpact": "Unspecified"}]}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccImportConfigConfig(m, filepath.Join(dir, "apps.xlsx")),
+				ExpectError: regexp.MustCompile(`no such file`),
+			},
+			{
+				Config: testAccImportConfigConfig(m, export),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_import_config.test", "application_count", "1"),
+					resource.TestMatchResourceAttr("data.appscan_import_config.test", "hcl", regexp.MustCompile(`(?s)^import \{\n  to = appscan_application.payments\n.*asset_group_id = "11111111-1111-1111-1111-111111111111"\n\}\n$`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccImportConfigConfig(m *mockServer, path string) string {
+	return testAccProviderConfig(m) + fmt.Sprintf(`
+data "appscan_import_config" "test" {
+  export_file = %q
+}
+`, path)
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Resources are imported by the GUID of their API object. Resources that
+// belong to a parent object also accept a composite ID, such as
+// application_id:scan_id, which additionally checks the object belongs to
+// that parent. The read that follows the import fills in the attributes.
+
+// importGUID imports a resource whose ID is the GUID of its API object.
+func importGUID(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := validateImportGUID("ID", d.Id()); err != nil {
+		return nil, err
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// importNumericID imports a resource whose ID is the integer ID of its API
+// object, such as a domain.
+func importNumericID(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if _, err := strconv.Atoi(d.Id()); err != nil {
+		return nil, fmt.Errorf("invalid import ID %q: must be a number", d.Id())
+	}
+	return []*schema.ResourceData{d}, nil
+}
+
+// parseImportID splits an import ID made of the colon-separated parts of
+// format, e.g. "application_id:scan_id". Only the last required parts have
+// to be given: the result is aligned on the right, with the omitted leading
+// parts left empty.
+func parseImportID(id, format string, required int) ([]string, error) {
+	names := strings.Split(format, ":")
+	parts := strings.Split(id, ":")
+	if len(parts) < required || len(parts) > len(names) {
+		return nil, fmt.Errorf("unexpected import ID %q, expected %s", id, importFormats(names, required))
+	}
+	result := make([]string, len(names)-len(parts), len(names))
+	return append(result, parts...), nil
+}
+
+// importFormats lists the accepted import ID formats, e.g.
+// "scan_id or application_id:scan_id".
+func importFormats(names []string, required int) string {
+	var formats []string
+	for n := required; n <= len(names); n++ {
+		formats = append(formats, strings.Join(names[len(names)-n:], ":"))
+	}
+	return strings.Join(formats, " or ")
+}
+
+// validateImportGUID checks that a part of an import ID is a GUID, so that a
+// typo is reported as such rather than as a missing object.
+func validateImportGUID(name, value string) error {
+	if !appscan.IsGUID(value) {
+		return fmt.Errorf("invalid import %s %q: must be a GUID", name, value)
+	}
+	return nil
+}
+
+// checkImportParent fails when the object does not belong to the parent
+// given in a composite import ID. An empty expected parent always matches.
+func checkImportParent(kind, id, parentKind, expected, actual string) error {
+	if expected != "" && !strings.EqualFold(expected, actual) {
+		return fmt.Errorf("%s %s belongs to %s %s, not %s", kind, id, parentKind, actual, expected)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseImportID(t *testing.T) {
+	cases := []struct {
+		id       string
+		format   string
+		required int
+		want     []string
+		wantErr  string
+	}{
+		{"scan", "application_id:scan_id", 1, []string{"", "scan"}, ""},
+		{"app:scan", "application_id:scan_id", 1, []string{"app", "scan"}, ""},
+		{"a:b:c", "application_id:scan_id", 1, nil, `unexpected import ID "a:b:c", expected scan_id or application_id:scan_id`},
+		{"b:c", "scope:scope_id:report_id", 3, nil, `unexpected import ID "b:c", expected scope:scope_id:report_id`},
+	}
+	for _, c := range cases {
+		got, err := parseImportID(c.id, c.format, c.required)
+		if c.wantErr != "" {
+			if err == nil || err.Error() != c.wantErr {
+				t.Errorf("parseImportID(%q): error %v, want %q", c.id, err, c.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseImportID(%q): %s", c.id, err)
+		} else if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("parseImportID(%q) = %q, want %q", c.id, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ----------------------------------------------------------------
+// Resource: appscan_irx (IRX file generated by SAClientUtil, for appscan_sast_scan)
+// ----------------------------------------------------------------
+
+// SAClientUtil generates the IRX file of the directory it runs in with
+// "appscan prepare". The resource runs it on the machine running Terraform,
+// without a shell, so that a SAST scan is a single graph from the sources to
+// the results: its irx_file feeds the irx_file of appscan_sast_scan.
+
+// irxOutputLines is the number of lines of the output of SAClientUtil kept
+// in the error when it fails.
+const irxOutputLines = 20
+
+// irxNameRegexp matches the names of IRX files: a file name, not a path.
+var irxNameRegexp = regexp.MustCompile(`^[^/\\]+$`)
+
+func resourceAppScanIRX() *schema.Resource {
+	return &schema.Resource{
+		CreateWithoutTimeout: withContext(resourceAppScanIRXCreate),
+		Read:                 resourceAppScanIRXRead,
+		Delete:               resourceAppScanIRXDelete,
+		Description: "Generates the IRX file of a source directory by running SAClientUtil (`appscan prepare`) on the machine running Terraform, " +
+			"so that the file can be scanned by appscan_sast_scan in the same apply. The file is generated again when the arguments or triggers change, " +
+			"or when it no longer exists, e.g. in a fresh CI workspace; the appscan_sast_scan of a new file is replaced, as its content is only known once generated. " +
+			"Destroying the resource deletes the file.",
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(time.Hour),
+		},
+		Schema: map[string]*schema.Schema{
+			"source_dir": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The directory SAClientUtil runs in, whose sources (or build outputs) it prepares.",
+				ValidateFunc: validation.StringIsNotEmpty,
+			},
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The name of the IRX file, without the .irx extension.",
+				ValidateFunc: validation.StringMatch(irxNameRegexp, "must be a file name, without a directory"),
+			},
+			"output_dir": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The directory the IRX file is written to. Defaults to source_dir.",
+			},
+			"sa_client_util_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				DefaultFunc: schema.EnvDefaultFunc("APPSCAN_SACLIENTUTIL_PATH", "appscan.sh"),
+				Description: "The path of the SAClientUtil launcher, `bin/appscan.sh` (or `bin\\appscan.bat` on Windows) of its installation. A name without a directory is looked up in the PATH. Defaults to appscan.sh; can also be set with the APPSCAN_SACLIENTUTIL_PATH environment variable.",
+			},
+			"config_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The path of an appscan-config.xml file selecting what is prepared (`-c`).",
+			},
+			"extra_args": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "More arguments passed to `appscan prepare`, e.g. `[\"-oso\"]` to only scan open source packages.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary values that generate the file again when they change, e.g. the commit of the sources.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"irx_file": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The path of the generated IRX file.",
+			},
+			"irx_file_sha256": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA256 of the generated IRX file.",
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA256 of the generated IRX file.",
+			},
+		},
+	}
+}
+
+func resourceAppScanIRXCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	sourceDir := d.Get("source_dir").(string)
+	outputDir := d.Get("output_dir").(string)
+	if outputDir == "" {
+		outputDir = sourceDir
+	}
+	outputDir, err := filepath.Abs(outputDir)
+	if err != nil {
+		return err
+	}
+	name := d.Get("name").(string)
+
+	args := []string{"prepare", "-n", name, "-d", outputDir}
+	if config := d.Get("config_file").(string); config != "" {
+		args = append(args, "-c", config)
+	}
+	for _, v := range d.Get("extra_args").([]interface{}) {
+		arg, _ := v.(string)
+		args = append(args, arg)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+	path := filepath.Join(outputDir, name+".irx")
+	if err := runSAClientUtil(ctx, d.Get("sa_client_util_path").(string), sourceDir, args, path); err != nil {
+		return err
+	}
+
+	checksum, err := fileSHA256(path)
+	if err != nil {
+		return err
+	}
+	d.SetId(checksum)
+	d.Set("irx_file", path)
+	d.Set("irx_file_sha256", checksum)
+	return nil
+}
+
+// resourceAppScanIRXRead forgets the file when it was deleted, so that it is
+// generated again.
+func resourceAppScanIRXRead(d *schema.ResourceData, m interface{}) error {
+	path := d.Get("irx_file").(string)
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		log.Printf("[INFO] IRX file %s no longer exists, removing it from state", path)
+		d.SetId("")
+		return nil
+	} else if err != nil {
+		return err
+	}
+	return nil
+}
+
+func resourceAppScanIRXDelete(d *schema.ResourceData, m interface{}) error {
+	if err := os.Remove(d.Get("irx_file").(string)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
+
+// runSAClientUtil runs program with args in dir, without a shell, and checks
+// that it generated the IRX file path.
+func runSAClientUtil(ctx context.Context, program, dir string, args []string, path string) error {
+	// Remove a file left by a previous run, which would hide a failure.
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, program, args...)
+	cmd.Dir = dir
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	log.Printf("[INFO] running %s %s in %s", program, strings.Join(args, " "), dir)
+	err := cmd.Run()
+	log.Printf("[DEBUG] output of %s:\n%s", program, output.String())
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("SAClientUtil %s did not generate the IRX file in time; raise the create timeout", program)
+	}
+	if err != nil {
+		return fmt.Errorf("SAClientUtil %s: %w%s", program, err, outputTail(output.String()))
+	}
+	if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("SAClientUtil %s did not generate %s%s", program, path, outputTail(output.String()))
+	}
+	return nil
+}
+
+// outputTail returns the last irxOutputLines lines of output, to be appended
+// to an error.
+func outputTail(output string) string {
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) == 1 && lines[0] == "" {
+		return ""
+	}
+	if len(lines) > irxOutputLines {
+		lines = lines[len(lines)-irxOutputLines:]
+	}
+	return ":\n\n" + strings.Join(lines, "\n")
+}
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// fakeSAClientUtil writes a script standing for SAClientUtil to dir: it
+// writes the name of the directory it runs in to the IRX file, or fails
+// when that directory holds a file named "fail".
+func fakeSAClientUtil(t *testing.T, dir string) string {
+	t.Helper()
+	path := filepath.Join(dir, "appscan.sh")
+	script := `#!/bin/sh
+[ -e fail ] && { echo "no scannable files found" >&2; exit 3; }
+while [ $# -gt 0 ]; do
+  case $1 in
+    -n) name=$2; shift;;
+    -d) out=$2; shift;;
+  esac
+  shift
+done
+pwd > "$out/$name.irx"
+`
+	if err := os.WriteFile(path, []byte(script), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestAccIRXResource(t *testing.T) {
+	m := newMockServer(t)
+	tools := t.TempDir()
+	sources := t.TempDir()
+	program := fakeSAClientUtil(t, tools)
+	config := func(commit string) string {
+		return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_irx" "test" {
+  source_dir          = %q
+  name                = "app"
+  sa_client_util_path = %q
+  triggers = {
+    commit = %q
+  }
+}
+
+resource "appscan_sast_scan" "test" {
+  application_id = %q
+  name           = "main"
+  irx_file       = appscan_irx.test.irx_file
+}
+`, sources, program, commit, mockApplicationID)
+	}
+	var scanID string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy: func(*terraform.State) error {
+			if _, err := os.Stat(filepath.Join(sources, "app.irx")); !os.IsNotExist(err) {
+				return fmt.Errorf("app.irx was not deleted: %v", err)
+			}
+			return nil
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: config("1a2b3c4"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_irx.test", "irx_file", filepath.Join(sources, "app.irx")),
+					resource.TestCheckResourceAttrPair("appscan_irx.test", "irx_file_sha256", "appscan_sast_scan.test", "irx_file_sha256"),
+					resource.TestCheckResourceAttr("appscan_sast_scan.test", "status", "Ready"),
+					testAccSaveID("appscan_sast_scan.test", &scanID),
+				),
+			},
+			{
+				// A new file is known to the plan only by its path: its
+				// scan is launched again.
+				Config: config("4d5e6f7"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckID("appscan_sast_scan.test", &scanID, false),
+					testAccSaveID("appscan_sast_scan.test", &scanID),
+				),
+			},
+			{
+				Config:   config("4d5e6f7"),
+				PlanOnly: true,
+			},
+			{
+				PreConfig: func() {
+					if err := os.Remove(filepath.Join(sources, "app.irx")); err != nil {
+						t.Fatal(err)
+					}
+					if err := os.WriteFile(filepath.Join(sources, "fail"), nil, 0o600); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:      config("4d5e6f7"),
+				ExpectError: regexp.MustCompile(`exit status 3:\s+no scannable files found`),
+			},
+			{
+				PreConfig: func() {
+					if err := os.Remove(filepath.Join(sources, "fail")); err != nil {
+						t.Fatal(err)
+					}
+				},
+				// The deleted file is generated again.
+				Config: config("4d5e6f7"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("appscan_irx.test", "irx_file_sha256"),
+					testAccCheckID("appscan_sast_scan.test", &scanID, false),
+				),
+			},
+		},
+	})
+}
+
+func TestOutputTail(t *testing.T) {
+	var lines []string
+	for i := 1; i <= irxOutputLines+5; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	tail := outputTail(strings.Join(lines, "\n") + "\n")
+	if !strings.HasPrefix(tail, ":\n\nline 6\n") || !strings.HasSuffix(tail, fmt.Sprintf("line %d", irxOutputLines+5)) {
+		t.Errorf("outputTail kept %q", tail)
+	}
+	if tail := outputTail(" \n"); tail != "" {
+		t.Errorf("outputTail of no output = %q, want empty", tail)
+	}
+}
@@ -0,0 +1,178 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ----------------------------------------------------------------
+// Resource: appscan_issue_comment (comment attached to an issue)
+// ----------------------------------------------------------------
+
+// Comments are added through the filtered-issues update endpoint, like
+// statuses, and have no ID of their own: the resource finds its comment back
+// by text among the comments of the issue. The API cannot edit or delete
+// comments, so changing the text adds a new comment and destroying the
+// resource leaves the comment on the issue.
+
+func resourceAppScanIssueComment() *schema.Resource {
+	return &schema.Resource{
+		CreateWithoutTimeout: withContext(resourceAppScanIssueCommentCreate),
+		ReadWithoutTimeout:   withContext(resourceAppScanIssueCommentRead),
+		Delete:               resourceAppScanIssueCommentDelete,
+		Description: "Adds a comment to an issue, e.g. the key of the ticket tracking its fix. " +
+			"If the issue already has a comment with the same text, it is adopted rather than added again. " +
+			"Comments cannot be edited or deleted: changing comment adds a new one, and destroying the resource leaves the comment on the issue.",
+		Schema: map[string]*schema.Schema{
+			"issue_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The ID of the issue to comment.",
+				ValidateFunc: validateGUID,
+			},
+			"application_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Description:  "The ID of the application the issue belongs to. Looked up from the issue when omitted.",
+				ValidateFunc: validation.Any(validation.StringIsEmpty, validateGUID),
+			},
+			"comment": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The text of the comment.",
+				ValidateFunc: validation.StringLenBetween(1, 2048),
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the comment was added.",
+			},
+			"created_by": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The user name of the author of the comment.",
+			},
+		},
+	}
+}
+
+// appScanIssueComment holds the CommentModelResponse fields the provider
+// relies on.
+type appScanIssueComment struct {
+	Comment     string `json:"Comment"`
+	DateCreated string `json:"DateCreated"`
+	CreatedBy   struct {
+		UserName string `json:"UserName"`
+	} `json:"CreatedBy"`
+}
+
+func resourceAppScanIssueCommentCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	issueID := d.Get("issue_id").(string)
+	text := d.Get("comment").(string)
+
+	if _, ok := d.GetOk("application_id"); !ok {
+		issue, err := getIssue(ctx, client, issueID)
+		if err != nil {
+			return err
+		}
+		if issue == nil {
+			return fmt.Errorf("no issue found with id: %s", issueID)
+		}
+		d.Set("application_id", issue.ApplicationId)
+	}
+
+	comment, err := findIssueComment(ctx, client, issueID, text, "")
+	if err != nil {
+		return err
+	}
+	if comment != nil {
+		log.Printf("[INFO] Issue %s already has the comment, adopting it", issueID)
+	} else {
+		payload := appScanIssueUpdate{Comment: text}
+		if err := updateIssue(ctx, client, d.Get("application_id").(string), issueID, payload, "comment issue"); err != nil {
+			return err
+		}
+		if comment, err = findIssueComment(ctx, client, issueID, text, ""); err != nil {
+			return err
+		}
+		if comment == nil {
+			return fmt.Errorf("comment not found on issue %s after adding it", issueID)
+		}
+		client.Summary.record("issue_commented", "appscan_issue_comment", issueID, nil)
+	}
+	d.SetId(issueID + ":" + comment.DateCreated)
+	d.Set("created_at", comment.DateCreated)
+	d.Set("created_by", comment.CreatedBy.UserName)
+	return nil
+}
+
+// resourceAppScanIssueCommentRead removes the comment from the state when
+// the issue, or its comment, no longer exists.
+func resourceAppScanIssueCommentRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	comment, err := findIssueComment(ctx, client, d.Get("issue_id").(string), d.Get("comment").(string), d.Get("created_at").(string))
+	if err != nil {
+		return err
+	}
+	if comment == nil {
+		d.SetId("")
+		return nil
+	}
+	d.Set("created_by", comment.CreatedBy.UserName)
+	return nil
+}
+
+// resourceAppScanIssueCommentDelete only forgets the comment, which the API
+// cannot delete.
+func resourceAppScanIssueCommentDelete(d *schema.ResourceData, m interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+// findIssueComment returns the latest comment of an issue whose text is
+// text, and whose date is createdAt unless empty, or nil when there is none
+// or the issue does not exist.
+func findIssueComment(ctx context.Context, client *AppScanClient, issueID, text, createdAt string) (*appScanIssueComment, error) {
+	collection := fmt.Sprintf("Issues/%s/Comments", url.PathEscape(issueID))
+	query := url.Values{}
+	query.Set("$orderby", "DateCreated desc")
+	for skip := 0; ; skip += catalogPageSize {
+		query.Set("$top", strconv.Itoa(catalogPageSize))
+		query.Set("$skip", strconv.Itoa(skip))
+
+		var result struct {
+			Items []appScanIssueComment `json:"Items"`
+		}
+		err := getODataPage(ctx, client, collection, query, &result)
+		var apiErr *appscan.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		for i, c := range result.Items {
+			if c.Comment == text && (createdAt == "" || c.DateCreated == createdAt) {
+				return &result.Items[i], nil
+			}
+		}
+		if len(result.Items) < catalogPageSize {
+			return nil, nil
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccIssueCommentResource(t *testing.T) {
+	m := newMockServer(t)
+	addMockIssues(m)
+	// The issue already has the comment of the second resource.
+	m.add("IssueComments", mockEntity{"IssueId": "55555555-5555-5555-5555-555555555552", "Comment": "Tracked in SEC-2", "DateCreated": "2024-01-01T00:00:00Z", "CreatedBy": map[string]interface{}{"UserName": "someone"}})
+
+	var id string
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIssueCommentConfig(m, "Tracked in SEC-1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_issue_comment.test", "application_id", mockApplicationID),
+					resource.TestCheckResourceAttr("appscan_issue_comment.test", "created_by", "mock-user"),
+					resource.TestCheckResourceAttrSet("appscan_issue_comment.test", "created_at"),
+					resource.TestCheckResourceAttr("appscan_issue_comment.existing", "created_at", "2024-01-01T00:00:00Z"),
+					resource.TestCheckResourceAttr("appscan_issue_comment.existing", "created_by", "someone"),
+					testAccSaveID("appscan_issue_comment.test", &id),
+					testAccCheckIssueComments(m, "55555555-5555-5555-5555-555555555551", 1),
+					testAccCheckIssueComments(m, "55555555-5555-5555-5555-555555555552", 1),
+				),
+			},
+			{
+				// Re-applying finds the comments back rather than adding them again.
+				Config:   testAccIssueCommentConfig(m, "Tracked in SEC-1"),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccIssueCommentConfig(m, "Tracked in SEC-3"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckID("appscan_issue_comment.test", &id, false),
+					testAccCheckIssueComments(m, "55555555-5555-5555-5555-555555555551", 2),
+				),
+			},
+		},
+	})
+}
+
+func testAccIssueCommentConfig(m *mockServer, comment string) string {
+	return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_issue_comment" "test" {
+  issue_id = "55555555-5555-5555-5555-555555555551"
+  comment  = %q
+}
+
+resource "appscan_issue_comment" "existing" {
+  issue_id       = "55555555-5555-5555-5555-555555555552"
+  application_id = %q
+  comment        = "Tracked in SEC-2"
+}
+`, comment, mockApplicationID)
+}
+
+// testAccCheckIssueComments checks the number of comments of an issue.
+func testAccCheckIssueComments(m *mockServer, issueID string, want int) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		got := 0
+		for _, c := range m.collections["IssueComments"] {
+			if c["IssueId"] == issueID {
+				got++
+			}
+		}
+		if got != want {
+			return fmt.Errorf("issue %s has %d comments, want %d", issueID, got, want)
+		}
+		return nil
+	}
+}
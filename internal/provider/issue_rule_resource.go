@@ -0,0 +1,278 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ----------------------------------------------------------------
+// Resource: appscan_issue_rule (disposition applied to the matching issues of an application)
+// ----------------------------------------------------------------
+
+// The API has no disposition rules of its own: a rule is a filter of the
+// issues of an application and the status to set on them, applied through
+// the filtered-issues update endpoint. Read counts the matching issues not
+// in that status yet, found by the scans since the last apply, and the plan
+// applies the rule again when there are some.
+
+// issueRuleCriteria are the arguments selecting the issues of a rule.
+var issueRuleCriteria = []string{"paths", "source_files", "cwes", "issue_types", "severities"}
+
+// issueRulePatternRegexp matches the patterns of paths and source_files: a
+// value, optionally ending with a * matching any suffix.
+var issueRulePatternRegexp = regexp.MustCompile(`^[^*]+\*?$`)
+
+func resourceAppScanIssueRule() *schema.Resource {
+	patternList := func(description string) *schema.Schema {
+		return &schema.Schema{
+			Type:         schema.TypeList,
+			Optional:     true,
+			Description:  description,
+			AtLeastOneOf: issueRuleCriteria,
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringMatch(issueRulePatternRegexp, "must be a value, optionally ending with a * matching any suffix"),
+			},
+		}
+	}
+	return &schema.Resource{
+		CreateWithoutTimeout: withContext(resourceAppScanIssueRuleCreate),
+		ReadWithoutTimeout:   withContext(resourceAppScanIssueRuleRead),
+		UpdateWithoutTimeout: withContext(resourceAppScanIssueRuleUpdate),
+		Delete:               resourceAppScanIssueRuleDelete,
+		CustomizeDiff:        customizeDiffIssueRulePending,
+		Description: "Sets a status, typically Noise, on the issues of an application matching criteria, so that triage policy is reproducible across environments. " +
+			"The issues match when they match every criterion set, and a criterion when they match any of its values. " +
+			"AppScan has no rules of its own: the rule is applied on apply, to the issues found so far, and planned again when later scans find new matching issues. " +
+			"Destroying the rule leaves the status of the issues as it is.",
+		Schema: map[string]*schema.Schema{
+			"application_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The ID of the application whose issues the rule applies to.",
+				ValidateFunc: validateGUID,
+			},
+			"status": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The status set on the matching issues. Allowed values: Open, InProgress, Reopened, Noise, Passed, Fixed, New.",
+				ValidateFunc: validation.StringInSlice(issueStatuses, false),
+			},
+			"comment": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "A comment recorded on the issues whose status the rule changes, e.g. the reason of the rule.",
+				ValidateFunc: validation.StringLenBetween(0, 2048),
+			},
+			"paths":        patternList("The paths of the issues, as reported for DAST issues, e.g. `/test/*`. A trailing * matches any suffix."),
+			"source_files": patternList("The source files of the issues, as reported for SAST issues, e.g. `src/test/*`. A trailing * matches any suffix."),
+			"cwes": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Description:  "The CWE numbers of the issues, e.g. 79.",
+				AtLeastOneOf: issueRuleCriteria,
+				Elem: &schema.Schema{
+					Type:         schema.TypeInt,
+					ValidateFunc: validation.IntAtLeast(1),
+				},
+			},
+			"issue_types": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Description:  "The issue types of the issues, e.g. `Missing HSTS Header`.",
+				AtLeastOneOf: issueRuleCriteria,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotEmpty,
+				},
+			},
+			"severities": {
+				Type:         schema.TypeSet,
+				Optional:     true,
+				Description:  "The severities of the issues: Critical, High, Medium, Low or Informational.",
+				AtLeastOneOf: issueRuleCriteria,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice([]string{"Critical", "High", "Medium", "Low", "Informational"}, false),
+				},
+			},
+			"matching_issues": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of issues the rule matches.",
+			},
+			"pending_issues": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of matching issues not in status yet, which the next apply updates.",
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A random identifier of the rule.",
+			},
+		},
+	}
+}
+
+func resourceAppScanIssueRuleCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return err
+	}
+	if err := applyIssueRule(ctx, d, m); err != nil {
+		return err
+	}
+	d.SetId(id)
+	return resourceAppScanIssueRuleRead(ctx, d, m)
+}
+
+func resourceAppScanIssueRuleRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	appID := d.Get("application_id").(string)
+
+	filter, err := issueRuleFilter(d)
+	if err != nil {
+		return err
+	}
+	matching, err := countOData(ctx, client, "Issues/Application/"+appID, filter)
+	var apiErr *appscan.APIError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound {
+		log.Printf("[INFO] application %s no longer exists, removing issue rule %s from state", appID, d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	pending := 0
+	if matching > 0 {
+		notInStatus := "Status ne " + appscan.ODataQuote(d.Get("status").(string))
+		pending, err = countOData(ctx, client, "Issues/Application/"+appID, appscan.ODataAnd(filter, notInStatus))
+		if err != nil {
+			return err
+		}
+	}
+	d.Set("matching_issues", matching)
+	d.Set("pending_issues", pending)
+	return nil
+}
+
+func resourceAppScanIssueRuleUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if err := applyIssueRule(ctx, d, m); err != nil {
+		return err
+	}
+	return resourceAppScanIssueRuleRead(ctx, d, m)
+}
+
+// resourceAppScanIssueRuleDelete only forgets the rule: the issues keep the
+// status it set.
+func resourceAppScanIssueRuleDelete(d *schema.ResourceData, m interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+// customizeDiffIssueRulePending plans an update of the rules that have
+// pending issues, so that the apply updates them.
+func customizeDiffIssueRulePending(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" {
+		return nil
+	}
+	if pending, _ := d.GetChange("pending_issues"); pending.(int) > 0 {
+		return d.SetNew("pending_issues", 0)
+	}
+	return nil
+}
+
+// applyIssueRule sets the status of the rule on the matching issues.
+func applyIssueRule(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	appID := d.Get("application_id").(string)
+	status := d.Get("status").(string)
+
+	filter, err := issueRuleFilter(d)
+	if err != nil {
+		return err
+	}
+	payload := appScanIssueUpdate{
+		Status:  status,
+		Comment: d.Get("comment").(string),
+	}
+	// Only the issues not in status yet, so that the comment is recorded
+	// on the issues the rule changes.
+	filter = appscan.ODataAnd(filter, "Status ne "+appscan.ODataQuote(status))
+	updated, err := updateFilteredIssues(ctx, client, appID, filter, payload, "apply issue rule")
+	if err != nil {
+		return err
+	}
+	client.Summary.record("issue_rule_applied", "appscan_issue_rule", appID, map[string]string{
+		"status":         status,
+		"updated_issues": fmt.Sprint(updated),
+	})
+	return nil
+}
+
+// issueRuleFilter returns the OData filter of the issues matching the
+// criteria of the rule d.
+func issueRuleFilter(d *schema.ResourceData) (string, error) {
+	var criteria []string
+	for _, c := range []struct{ field, attr string }{{"Path", "paths"}, {"SourceFile", "source_files"}} {
+		var exprs []string
+		for _, v := range d.Get(c.attr).([]interface{}) {
+			expr, err := issueRulePatternFilter(c.field, v.(string))
+			if err != nil {
+				return "", err
+			}
+			exprs = append(exprs, expr)
+		}
+		criteria = append(criteria, appscan.ODataOr(exprs))
+	}
+
+	var cwes []string
+	for _, v := range d.Get("cwes").(*schema.Set).List() {
+		expr, err := appscan.ODataEqInt("Cwe", v.(int))
+		if err != nil {
+			return "", err
+		}
+		cwes = append(cwes, expr)
+	}
+	criteria = append(criteria, appscan.ODataOr(cwes))
+
+	for _, c := range []struct{ field, attr string }{{"IssueType", "issue_types"}, {"Severity", "severities"}} {
+		var exprs []string
+		for _, v := range d.Get(c.attr).(*schema.Set).List() {
+			expr, err := appscan.ODataEqString(c.field, v.(string))
+			if err != nil {
+				return "", err
+			}
+			exprs = append(exprs, expr)
+		}
+		criteria = append(criteria, appscan.ODataOr(exprs))
+	}
+
+	filter := appscan.ODataAnd(criteria...)
+	if filter == "" {
+		return "", fmt.Errorf("the rule has no criteria: set one of %s", strings.Join(issueRuleCriteria, ", "))
+	}
+	return filter, nil
+}
+
+// issueRulePatternFilter matches field against pattern, a prefix when it ends
+// with a *.
+func issueRulePatternFilter(field, pattern string) (string, error) {
+	if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+		return appscan.ODataStringFunc("startswith", field, prefix)
+	}
+	return appscan.ODataEqString(field, pattern)
+}
@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const (
+	mockTestXSSIssueID = "66666666-6666-6666-6666-666666666661"
+	mockTestSQLIssueID = "66666666-6666-6666-6666-666666666662"
+	mockAppXSSIssueID  = "66666666-6666-6666-6666-666666666663"
+	mockNewTestIssueID = "66666666-6666-6666-6666-666666666664"
+)
+
+func TestAccIssueRuleResource(t *testing.T) {
+	m := newMockServer(t)
+	m.add("Issues", mockEntity{"Id": mockTestXSSIssueID, "ApplicationId": mockApplicationID, "Status": "Open", "Cwe": 79, "Path": "/test/search"})
+	m.add("Issues", mockEntity{"Id": mockTestSQLIssueID, "ApplicationId": mockApplicationID, "Status": "Open", "Cwe": 89, "Path": "/test/login"})
+	m.add("Issues", mockEntity{"Id": mockAppXSSIssueID, "ApplicationId": mockApplicationID, "Status": "Open", "Cwe": 79, "Path": "/search"})
+	config := testAccIssueRuleConfig(m)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_issue_rule.test", "matching_issues", "1"),
+					resource.TestCheckResourceAttr("appscan_issue_rule.test", "pending_issues", "0"),
+					testAccCheckIssueStatuses(m, map[string]string{
+						mockTestXSSIssueID: "Noise",
+						mockTestSQLIssueID: "Open",
+						mockAppXSSIssueID:  "Open",
+					}),
+					testAccCheckIssueComments(m, mockTestXSSIssueID, 1),
+				),
+			},
+			{
+				// A later scan found a matching issue: the rule is applied
+				// again.
+				PreConfig: func() {
+					m.add("Issues", mockEntity{"Id": mockNewTestIssueID, "ApplicationId": mockApplicationID, "Status": "New", "Cwe": 79, "Path": "/test/admin"})
+				},
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_issue_rule.test", "matching_issues", "2"),
+					resource.TestCheckResourceAttr("appscan_issue_rule.test", "pending_issues", "0"),
+					testAccCheckIssueStatuses(m, map[string]string{mockNewTestIssueID: "Noise"}),
+					// Only the issues the rule changes get the comment.
+					testAccCheckIssueComments(m, mockTestXSSIssueID, 1),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIssueRuleResource_noCriteria(t *testing.T) {
+	m := newMockServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_issue_rule" "test" {
+  application_id = %q
+  status         = "Noise"
+}
+`, mockApplicationID),
+				ExpectError: regexp.MustCompile(`one of\s+` + "`cwes,issue_types,paths,severities,source_files`"),
+			},
+		},
+	})
+}
+
+func testAccIssueRuleConfig(m *mockServer) string {
+	return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_issue_rule" "test" {
+  application_id = %q
+  status         = "Noise"
+  comment        = "Test code is not deployed"
+  paths          = ["/test/*"]
+  cwes           = [79]
+}
+`, mockApplicationID)
+}
+
+// testAccCheckIssueStatuses checks the statuses of mock issues, by ID.
+func testAccCheckIssueStatuses(m *mockServer, statuses map[string]string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		for id, status := range statuses {
+			if actual := m.find("Issues", id)["Status"]; actual != status {
+				return fmt.Errorf("issue %s has status %v, want %s", id, actual, status)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,262 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceAppScanIssueStatus() *schema.Resource {
+	return &schema.Resource{
+		CreateWithoutTimeout: withContext(resourceAppScanIssueStatusCreate),
+		ReadWithoutTimeout:   withContext(resourceAppScanIssueStatusRead),
+		UpdateWithoutTimeout: withContext(resourceAppScanIssueStatusUpdate),
+		Delete:               resourceAppScanIssueStatusDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceAppScanIssueStatusImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"issue_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The ID of the issue whose status is managed.",
+				ValidateFunc: validateGUID,
+			},
+			"application_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Description:  "The ID of the application the issue belongs to. Looked up from the issue when omitted.",
+				ValidateFunc: validation.Any(validation.StringIsEmpty, validateGUID),
+			},
+			"status": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The status (disposition) of the issue. Allowed values: Open, InProgress, Reopened, Noise, Passed, Fixed, New.",
+				ValidateFunc: validation.StringInSlice(issueStatuses, false),
+			},
+			"comment": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "A comment recorded with the status change.",
+				ValidateFunc: validation.StringLenBetween(0, 2048),
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the issue.",
+			},
+		},
+	}
+}
+
+func resourceAppScanIssueStatusCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	issueID := d.Get("issue_id").(string)
+	if _, ok := d.GetOk("application_id"); !ok {
+		issue, err := getIssue(ctx, m.(*AppScanClient), issueID)
+		if err != nil {
+			return err
+		}
+		if issue == nil {
+			return fmt.Errorf("no issue found with id: %s", issueID)
+		}
+		d.Set("application_id", issue.ApplicationId)
+	}
+	if err := updateIssueStatus(ctx, d, m); err != nil {
+		return err
+	}
+	d.SetId(issueID)
+	return resourceAppScanIssueStatusRead(ctx, d, m)
+}
+
+func resourceAppScanIssueStatusRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	issue, err := getIssue(ctx, client, d.Id())
+	if err != nil {
+		return err
+	}
+	if issue == nil {
+		d.SetId("")
+		return nil
+	}
+	if err := diagnosticsError(client.checkEnum("issue "+issue.Id, "Status", issue.Status, issueStatuses)); err != nil {
+		return err
+	}
+	d.Set("issue_id", issue.Id)
+	d.Set("application_id", issue.ApplicationId)
+	d.Set("status", issue.Status)
+	return nil
+}
+
+func resourceAppScanIssueStatusUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if err := updateIssueStatus(ctx, d, m); err != nil {
+		return err
+	}
+	return resourceAppScanIssueStatusRead(ctx, d, m)
+}
+
+// resourceAppScanIssueStatusImport imports an issue by issue_id or
+// application_id:issue_id. The comment cannot be read back.
+func resourceAppScanIssueStatusImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts, err := parseImportID(d.Id(), "application_id:issue_id", 1)
+	if err != nil {
+		return nil, err
+	}
+	appID, issueID := parts[0], parts[1]
+	if appID != "" {
+		if err := validateImportGUID("application_id", appID); err != nil {
+			return nil, err
+		}
+	}
+	if err := validateImportGUID("issue_id", issueID); err != nil {
+		return nil, err
+	}
+
+	issue, err := getIssue(ctx, m.(*AppScanClient), issueID)
+	if err != nil {
+		return nil, err
+	}
+	if issue == nil {
+		return nil, fmt.Errorf("no issue found with id: %s", issueID)
+	}
+	if err := checkImportParent("issue", issueID, "application", appID, issue.ApplicationId); err != nil {
+		return nil, err
+	}
+	d.SetId(issueID)
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceAppScanIssueStatusDelete only forgets the issue: an issue always
+// has a status, so the last applied disposition is left in place.
+func resourceAppScanIssueStatusDelete(d *schema.ResourceData, m interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+// appScanIssue holds the issue fields the provider relies on.
+type appScanIssue struct {
+	Id            string `json:"Id"`
+	ApplicationId string `json:"ApplicationId"`
+	Status        string `json:"Status"`
+}
+
+// getIssue fetches a single issue, returning nil when it does not exist.
+func getIssue(ctx context.Context, client *AppScanClient, issueID string) (*appScanIssue, error) {
+	urlStr := fmt.Sprintf("%s/Issues/%s", client.ApiBase, url.PathEscape(issueID))
+	req, err := client.newRequest(ctx, "GET", urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, appscan.NewAPIError("read issue", resp)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var issue appScanIssue
+	if err := appscan.DecodeJSON(resp, respBody, &issue); err != nil {
+		return nil, err
+	}
+	return &issue, nil
+}
+
+// updateIssueStatus applies the configured status and comment to the issue
+// through the filtered-issues update endpoint of its application.
+func updateIssueStatus(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	issueID := d.Get("issue_id").(string)
+
+	payload := appScanIssueUpdate{
+		Status:  d.Get("status").(string),
+		Comment: d.Get("comment").(string),
+	}
+	if err := updateIssue(ctx, client, d.Get("application_id").(string), issueID, payload, "update issue status"); err != nil {
+		return err
+	}
+	client.Summary.record("issue_status_updated", "appscan_issue_status", issueID, map[string]string{
+		"status": d.Get("status").(string),
+	})
+	return nil
+}
+
+// appScanIssueUpdate is the UpdateIssue payload changing the status of
+// issues, commenting them, or both. The fields are omitted when empty.
+type appScanIssueUpdate struct {
+	Status  string `json:"Status,omitempty"`
+	Comment string `json:"Comment,omitempty"`
+}
+
+// updateIssue sends payload, an UpdateIssue model, to the filtered-issues
+// update endpoint of an application, for the issue issueID only.
+func updateIssue(ctx context.Context, client *AppScanClient, appID, issueID string, payload appScanIssueUpdate, op string) error {
+	filterQuery, err := client.odataEqID(ctx, "Id", issueID)
+	if err != nil {
+		return err
+	}
+	_, err = updateFilteredIssues(ctx, client, appID, filterQuery, payload, op)
+	return err
+}
+
+// updateFilteredIssues sends payload, an UpdateIssue model, to the issues of
+// an application matching filter, and returns the number of issues whose
+// status changed.
+func updateFilteredIssues(ctx context.Context, client *AppScanClient, appID, filter string, payload appScanIssueUpdate, op string) (int, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, err
+	}
+
+	query := url.Values{}
+	query.Set("odataFilter", filter)
+	urlStr := fmt.Sprintf("%s/Issues/Application/%s?%s", client.ApiBase, url.PathEscape(appID), query.Encode())
+	req, err := client.newRequest(ctx, "PUT", urlStr, bytes.NewBuffer(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, appscan.APIErrorFromBody(op, resp, respBody)
+	}
+	// TriageResult model.
+	var result struct {
+		NUpdatedIssues int `json:"NUpdatedIssues"`
+	}
+	if err := appscan.DecodeJSON(resp, respBody, &result); err != nil {
+		return 0, err
+	}
+	return result.NUpdatedIssues, nil
+}
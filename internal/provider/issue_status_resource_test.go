@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIssueStatusResource(t *testing.T) {
+	m := newMockServer(t)
+	addMockIssues(m)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccIssueStatusConfig(m, "Noise"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_issue_status.test", "status", "Noise"),
+					resource.TestCheckResourceAttr("appscan_issue_status.test", "application_id", mockApplicationID),
+				),
+			},
+			{
+				Config: testAccIssueStatusConfig(m, "Fixed"),
+				Check:  resource.TestCheckResourceAttr("appscan_issue_status.test", "status", "Fixed"),
+			},
+			{
+				ResourceName:            "appscan_issue_status.test",
+				ImportState:             true,
+				ImportStateIdFunc:       testAccImportID("appscan_issue_status.test", mockApplicationID),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"comment"},
+			},
+		},
+	})
+}
+
+func testAccIssueStatusConfig(m *mockServer, status string) string {
+	return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_issue_status" "test" {
+  issue_id = "55555555-5555-5555-5555-555555555551"
+  status   = %q
+  comment  = "Triaged"
+}
+`, status)
+}
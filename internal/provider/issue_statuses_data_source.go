@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ----------------------------------------------------------------
+// Data Source: appscan_issue_statuses (status & severity catalog)
+// ----------------------------------------------------------------
+
+// The v4 API has no endpoint listing issue statuses or severities (custom
+// statuses included); the catalog is the set of values its models accept.
+
+func dataSourceIssueStatuses() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceIssueStatusesRead,
+		Schema: map[string]*schema.Schema{
+			"statuses": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The issue statuses accepted by the API.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"severities": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The issue severities reported by the API, from lowest to highest.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceIssueStatusesRead(d *schema.ResourceData, m interface{}) error {
+	if err := d.Set("statuses", issueStatuses); err != nil {
+		return err
+	}
+	if err := d.Set("severities", issueSeverities); err != nil {
+		return err
+	}
+	d.SetId("issue_statuses")
+	return nil
+}
@@ -0,0 +1,27 @@
+package provider
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIssueStatusesDataSource(t *testing.T) {
+	m := newMockServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + `
+data "appscan_issue_statuses" "test" {}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_issue_statuses.test", "statuses.#", strconv.Itoa(len(issueStatuses))),
+					resource.TestCheckResourceAttr("data.appscan_issue_statuses.test", "severities.#", strconv.Itoa(len(issueSeverities))),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,145 @@
+package provider
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ----------------------------------------------------------------
+// Data Source: appscan_issue_types (issue types and their CWE, by name)
+// ----------------------------------------------------------------
+
+// The API has no catalog of issue types: they are read from the issues of
+// an application, so only the types its scans found are listed.
+
+// appScanIssueType holds the IssueModel fields identifying the type of an
+// issue.
+type appScanIssueType struct {
+	IssueTypeId string `json:"IssueTypeId"`
+	IssueType   string `json:"IssueType"`
+	Cwe         *int   `json:"Cwe"`
+}
+
+func dataSourceIssueTypes() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: withContext(dataSourceIssueTypesRead),
+		Description: "Lists the issue types of the issues of an application, with their ID and CWE, " +
+			"so that appscan_issue_rule, policies or scripts can refer to them by name. " +
+			"The API has no catalog of issue types: only the types found by the scans of the application are listed.",
+		Schema: map[string]*schema.Schema{
+			"application_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The ID of the application whose issue types are listed.",
+				ValidateFunc: validateGUID,
+			},
+			"issue_types": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The issue types, by name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the issue type, e.g. `CrossSiteScripting`.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the issue type, as matched by the issue_types of appscan_issue_rule.",
+						},
+						"cwe": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The CWE the issue type is mapped to, 0 when none.",
+						},
+						"issues": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of issues of the application of this type.",
+						},
+					},
+				},
+			},
+			"ids_by_name": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The IDs of the issue types, by name.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"cwes_by_name": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The CWE of the issue types mapped to one, by name.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceIssueTypesRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	appID := d.Get("application_id").(string)
+
+	query := url.Values{}
+	query.Set("$select", "Id,IssueTypeId,IssueType,Cwe")
+	issues, err := listODataPages[appScanIssueType](ctx, client, "Issues/Application/"+url.PathEscape(appID), query)
+	if err != nil {
+		return err
+	}
+
+	// The issues of a type share its name, but not always its CWE mapping:
+	// the first mapping found is kept.
+	counts := map[string]int{}
+	types := map[string]appScanIssueType{}
+	for _, issue := range issues {
+		if t, ok := types[issue.IssueTypeId]; !ok || (t.Cwe == nil && issue.Cwe != nil) {
+			types[issue.IssueTypeId] = issue
+		}
+		counts[issue.IssueTypeId]++
+	}
+	list := make([]appScanIssueType, 0, len(types))
+	for _, t := range types {
+		list = append(list, t)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].IssueType != list[j].IssueType {
+			return list[i].IssueType < list[j].IssueType
+		}
+		return list[i].IssueTypeId < list[j].IssueTypeId
+	})
+
+	items := make([]interface{}, 0, len(list))
+	ids := map[string]interface{}{}
+	cwes := map[string]interface{}{}
+	for _, t := range list {
+		cwe := 0
+		if t.Cwe != nil {
+			cwe = *t.Cwe
+			cwes[t.IssueType] = strconv.Itoa(cwe)
+		}
+		items = append(items, map[string]interface{}{
+			"id":     t.IssueTypeId,
+			"name":   t.IssueType,
+			"cwe":    cwe,
+			"issues": counts[t.IssueTypeId],
+		})
+		ids[t.IssueType] = t.IssueTypeId
+	}
+	if err := d.Set("issue_types", items); err != nil {
+		return err
+	}
+	if err := d.Set("ids_by_name", ids); err != nil {
+		return err
+	}
+	if err := d.Set("cwes_by_name", cwes); err != nil {
+		return err
+	}
+	d.SetId(appID)
+	return nil
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccIssueTypesDataSource(t *testing.T) {
+	m := newMockServer(t)
+	m.add("Issues", mockEntity{"ApplicationId": mockApplicationID, "IssueTypeId": "CrossSiteScripting", "IssueType": "Cross-Site Scripting", "Cwe": nil})
+	m.add("Issues", mockEntity{"ApplicationId": mockApplicationID, "IssueTypeId": "CrossSiteScripting", "IssueType": "Cross-Site Scripting", "Cwe": 79})
+	m.add("Issues", mockEntity{"ApplicationId": mockApplicationID, "IssueTypeId": "SqlInjection", "IssueType": "SQL Injection", "Cwe": 89})
+	m.add("Issues", mockEntity{"ApplicationId": mockApplicationID, "IssueTypeId": "InsecureCookie", "IssueType": "Cookie Without Secure Flag"})
+	// Of another application.
+	m.add("Issues", mockEntity{"ApplicationId": "44444444-4444-4444-4444-444444444445", "IssueTypeId": "PathTraversal", "IssueType": "Path Traversal", "Cwe": 22})
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + fmt.Sprintf(`
+data "appscan_issue_types" "test" {
+  application_id = %q
+}
+`, mockApplicationID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_issue_types.test", "issue_types.#", "3"),
+					resource.TestCheckResourceAttr("data.appscan_issue_types.test", "issue_types.0.name", "Cookie Without Secure Flag"),
+					resource.TestCheckResourceAttr("data.appscan_issue_types.test", "issue_types.0.cwe", "0"),
+					resource.TestCheckResourceAttr("data.appscan_issue_types.test", "issue_types.1.id", "CrossSiteScripting"),
+					resource.TestCheckResourceAttr("data.appscan_issue_types.test", "issue_types.1.cwe", "79"),
+					resource.TestCheckResourceAttr("data.appscan_issue_types.test", "issue_types.1.issues", "2"),
+					resource.TestCheckResourceAttr("data.appscan_issue_types.test", "ids_by_name.SQL Injection", "SqlInjection"),
+					resource.TestCheckResourceAttr("data.appscan_issue_types.test", "cwes_by_name.%", "2"),
+					resource.TestCheckResourceAttr("data.appscan_issue_types.test", "cwes_by_name.Cross-Site Scripting", "79"),
+				),
+			},
+		},
+	})
+}
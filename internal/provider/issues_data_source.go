@@ -0,0 +1,202 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ----------------------------------------------------------------
+// Data Source: appscan_issues (issues of an application)
+// ----------------------------------------------------------------
+
+var (
+	issueSeverities = []string{"Undetermined", "Informational", "Low", "Medium", "High", "Critical"}
+	issueStatuses   = []string{"Open", "InProgress", "Reopened", "Noise", "Passed", "Fixed", "New"}
+)
+
+func dataSourceIssues() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"application_id": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "The ID of the application whose issues are returned.",
+			ValidateFunc: validateGUID,
+		},
+		"severities": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "If provided, only issues with one of these severities are returned. Allowed values: Undetermined, Informational, Low, Medium, High, Critical.",
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice(issueSeverities, false),
+			},
+		},
+		"statuses": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "If provided, only issues with one of these statuses are returned. Allowed values: Open, InProgress, Reopened, Noise, Passed, Fixed, New.",
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice(issueStatuses, false),
+			},
+		},
+		"cwes": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "If provided, only issues mapped to one of these CWE identifiers are returned.",
+			Elem: &schema.Schema{
+				Type: schema.TypeInt,
+			},
+		},
+		"issues": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The issues matching the filters.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The unique identifier of the issue.",
+					},
+					"issue_type": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The issue type.",
+					},
+					"severity": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The severity of the issue.",
+					},
+					"status": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The status of the issue.",
+					},
+					"cwe": {
+						Type:        schema.TypeInt,
+						Computed:    true,
+						Description: "The CWE identifier of the issue.",
+					},
+					"location": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The location of the issue.",
+					},
+					"scan_name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the scan that found the issue.",
+					},
+					"date_created": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The date the issue was created.",
+					},
+					"last_found": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The date the issue was last found.",
+					},
+				},
+			},
+		},
+	}
+	for k, v := range listQuerySchema() {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		ReadWithoutTimeout: withContext(dataSourceIssuesRead),
+		Schema:             s,
+	}
+}
+
+func dataSourceIssuesRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	appID := d.Get("application_id").(string)
+	if !appscan.IsGUID(appID) {
+		return fmt.Errorf("invalid application_id: %q", appID)
+	}
+
+	filterQuery, err := issuesFilter(d)
+	if err != nil {
+		return err
+	}
+
+	type issueItem struct {
+		Id          string `json:"Id"`
+		IssueType   string `json:"IssueType"`
+		Severity    string `json:"Severity"`
+		Status      string `json:"Status"`
+		Cwe         int    `json:"Cwe"`
+		Location    string `json:"Location"`
+		ScanName    string `json:"ScanName"`
+		DateCreated string `json:"DateCreated"`
+		LastFound   string `json:"LastFound"`
+	}
+
+	query := listQueryFor(d).values(filterQuery, "", "Id")
+	items, err := listODataPages[issueItem](ctx, client, "Issues/Application/"+appID, query)
+	if err != nil {
+		return err
+	}
+
+	issues := make([]interface{}, len(items))
+	for i, is := range items {
+		issues[i] = map[string]interface{}{
+			"id":           is.Id,
+			"issue_type":   is.IssueType,
+			"severity":     is.Severity,
+			"status":       is.Status,
+			"cwe":          is.Cwe,
+			"location":     is.Location,
+			"scan_name":    is.ScanName,
+			"date_created": is.DateCreated,
+			"last_found":   is.LastFound,
+		}
+	}
+	if err := d.Set("issues", issues); err != nil {
+		return err
+	}
+
+	d.SetId(appID)
+	return nil
+}
+
+// issuesFilter builds the OData filter matching the severities, statuses and
+// cwes arguments. Values within an argument are or-ed, arguments are and-ed.
+func issuesFilter(d *schema.ResourceData) (string, error) {
+	var clauses []string
+	for _, arg := range []struct{ key, field string }{
+		{"severities", "Severity"},
+		{"statuses", "Status"},
+	} {
+		var exprs []string
+		for _, v := range d.Get(arg.key).([]interface{}) {
+			expr, err := appscan.ODataEqString(arg.field, v.(string))
+			if err != nil {
+				return "", err
+			}
+			exprs = append(exprs, expr)
+		}
+		clauses = append(clauses, appscan.ODataOr(exprs))
+	}
+
+	var cwes []string
+	for _, v := range d.Get("cwes").([]interface{}) {
+		expr, err := appscan.ODataEqInt("Cwe", v.(int))
+		if err != nil {
+			return "", err
+		}
+		cwes = append(cwes, expr)
+	}
+	clauses = append(clauses, appscan.ODataOr(cwes))
+
+	return appscan.ODataAnd(clauses...), nil
+}
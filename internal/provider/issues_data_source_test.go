@@ -0,0 +1,92 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const mockApplicationID = "44444444-4444-4444-4444-444444444444"
+
+// addMockIssues seeds the mock with three issues of mockApplicationID.
+func addMockIssues(m *mockServer) {
+	m.add("Issues", mockEntity{"Id": "55555555-5555-5555-5555-555555555551", "ApplicationId": mockApplicationID, "IssueType": "Cross-Site Scripting", "Severity": "High", "Status": "Open", "Cwe": 79, "Location": "https://example.com/search"})
+	m.add("Issues", mockEntity{"Id": "55555555-5555-5555-5555-555555555552", "ApplicationId": mockApplicationID, "IssueType": "SQL Injection", "Severity": "Critical", "Status": "New", "Cwe": 89, "Location": "https://example.com/login"})
+	m.add("Issues", mockEntity{"Id": "55555555-5555-5555-5555-555555555553", "ApplicationId": mockApplicationID, "IssueType": "Missing HSTS Header", "Severity": "Low", "Status": "Open", "Cwe": 523, "Location": "https://example.com/"})
+}
+
+func TestAccIssuesDataSource(t *testing.T) {
+	m := newMockServer(t)
+	addMockIssues(m)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + fmt.Sprintf(`
+data "appscan_issues" "all" {
+  application_id = %[1]q
+}
+
+data "appscan_issues" "severe" {
+  application_id = %[1]q
+  severities     = ["High", "Critical"]
+  statuses       = ["Open"]
+}
+
+data "appscan_issues" "cwe" {
+  application_id = %[1]q
+  cwes           = [89]
+}
+`, mockApplicationID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_issues.all", "issues.#", "3"),
+					resource.TestCheckResourceAttr("data.appscan_issues.severe", "issues.#", "1"),
+					resource.TestCheckResourceAttr("data.appscan_issues.severe", "issues.0.cwe", "79"),
+					resource.TestCheckResourceAttr("data.appscan_issues.cwe", "issues.#", "1"),
+					resource.TestCheckResourceAttr("data.appscan_issues.cwe", "issues.0.issue_type", "SQL Injection"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccIssuesDataSource_pages(t *testing.T) {
+	m := newMockServer(t)
+	for i := 0; i < 2*catalogPageSize+1; i++ {
+		m.add("Issues", mockEntity{"ApplicationId": mockApplicationID, "IssueType": "SQL Injection", "Severity": "High", "Status": "Open", "Cwe": 89})
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + fmt.Sprintf(`
+data "appscan_issues" "all" {
+  application_id = %q
+}
+`, mockApplicationID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_issues.all", "issues.#", fmt.Sprint(2*catalogPageSize+1)),
+					// Tied on every other field, the issues are ordered by ID
+					// and the pages fetched concurrently neither overlap nor
+					// miss any.
+					func(s *terraform.State) error {
+						attrs := s.RootModule().Resources["data.appscan_issues.all"].Primary.Attributes
+						seen := map[string]bool{}
+						for i := 0; i < 2*catalogPageSize+1; i++ {
+							id := attrs[fmt.Sprintf("issues.%d.id", i)]
+							if seen[id] {
+								return fmt.Errorf("issue %s listed twice", id)
+							}
+							seen[id] = true
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
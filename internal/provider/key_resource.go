@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The API can only generate a new key for the authenticated user, which
+// revokes the previous one; keys can neither be read back nor deleted.
+// appscan_key therefore keeps the generated key in the state and rotates it
+// by replacement. Since the revoked key may be the one the provider logged in
+// with, cutting off the rest of the run, a key is only generated once
+// revoke_current_key acknowledges it.
+
+// errKeyRevocationNotAcknowledged is returned when appscan_key would
+// generate a key without revoke_current_key.
+var errKeyRevocationNotAcknowledged = errors.New("generating an API key revokes the current key of the user the provider authenticates as, " +
+	"including the key_id the provider may be configured with, which fails the rest of the run: set revoke_current_key = true to acknowledge it")
+
+func resourceAppScanKey() *schema.Resource {
+	return &schema.Resource{
+		CreateWithoutTimeout: withContext(resourceAppScanKeyCreate),
+		Read:                 resourceAppScanKeyRead,
+		Update:               resourceAppScanKeyRead,
+		Delete:               resourceAppScanKeyDelete,
+		CustomizeDiff:        customizeDiffKeyRevocation,
+		Description: "Generates an API key for the user the provider authenticates as, typically a service account. " +
+			"Generating a key revokes the previous key of that user, including the one the provider is configured with: " +
+			"store the generated key where the provider configuration reads it from, e.g. a secrets manager, so the next run logs in with it. " +
+			"Keys cannot be deleted through the API; destroying the resource only removes the key from the state.",
+		Schema: map[string]*schema.Schema{
+			"revoke_current_key": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Acknowledges that generating a key revokes the current key of the user the provider authenticates as. Required to be true to generate a key: when the provider logs in with that key, the requests made after the key is generated fail, so the key is best generated by a configuration, or a run, of its own. Changing it alone generates no key.",
+			},
+			"rotation_triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary values that, when changed, generate a new key, e.g. a date to rotate the key on a schedule.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"key_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The ID of the generated API key.",
+			},
+			"key_secret": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The secret of the generated API key.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the key was generated.",
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A random identifier of the key, so that the key ID does not show in plans.",
+			},
+		},
+	}
+}
+
+// customizeDiffKeyRevocation fails the plans generating a key without
+// revoke_current_key, when it is known.
+func customizeDiffKeyRevocation(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if (d.Id() == "" || d.HasChange("rotation_triggers")) && d.NewValueKnown("revoke_current_key") && !d.Get("revoke_current_key").(bool) {
+		return errKeyRevocationNotAcknowledged
+	}
+	return nil
+}
+
+func resourceAppScanKeyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	if !d.Get("revoke_current_key").(bool) {
+		return errKeyRevocationNotAcknowledged
+	}
+
+	url := fmt.Sprintf("%s/Account/ApiKey", client.ApiBase)
+	req, err := client.newRequest(ctx, "POST", url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return appscan.NewAPIError("generate API key", resp)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	var key struct {
+		KeyId     string `json:"KeyId"`
+		KeySecret string `json:"KeySecret"`
+		CreatedAt string `json:"CreatedAt"`
+	}
+	if err := appscan.DecodeJSON(resp, respBody, &key); err != nil {
+		return err
+	}
+	if key.KeyId == "" || key.KeySecret == "" {
+		return fmt.Errorf("failed to retrieve the API key from API response")
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return err
+	}
+	d.SetId(id)
+	d.Set("key_id", key.KeyId)
+	d.Set("key_secret", key.KeySecret)
+	d.Set("created_at", key.CreatedAt)
+	client.Summary.record("api_key_generated", "appscan_key", id, nil)
+	return nil
+}
+
+// resourceAppScanKeyRead keeps the state as is: the API cannot read keys back.
+func resourceAppScanKeyRead(d *schema.ResourceData, m interface{}) error {
+	return nil
+}
+
+// resourceAppScanKeyDelete only removes the key from the state: the API
+// cannot delete keys, the key stays valid until a new one is generated.
+func resourceAppScanKeyDelete(d *schema.ResourceData, m interface{}) error {
+	d.SetId("")
+	return nil
+}
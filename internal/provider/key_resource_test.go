@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccKeyResource(t *testing.T) {
+	m := newMockServer(t)
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + `
+resource "appscan_key" "test" {}
+`,
+				ExpectError: regexp.MustCompile(`set revoke_current_key = true`),
+			},
+			{
+				// Checked again at apply, once known.
+				Config: testAccProviderConfig(m) + `
+resource "appscan_key" "test" {
+  revoke_current_key = timestamp() == ""
+}
+`,
+				ExpectError: regexp.MustCompile(`set revoke_current_key = true`),
+			},
+			{
+				PreConfig: func() {
+					m.mu.Lock()
+					defer m.mu.Unlock()
+					if n := len(m.collections["ApiKeys"]); n != 0 {
+						t.Errorf("%d keys were generated without revoke_current_key", n)
+					}
+				},
+				Config: testAccKeyConfig(m, "2026-Q1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMockKey(m, "appscan_key.test"),
+					resource.TestCheckResourceAttrSet("appscan_key.test", "created_at"),
+					testAccSaveID("appscan_key.test", &id),
+				),
+			},
+			{
+				Config: testAccKeyConfig(m, "2026-Q2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMockKey(m, "appscan_key.test"),
+					testAccCheckID("appscan_key.test", &id, false),
+				),
+			},
+		},
+	})
+}
+
+func testAccKeyConfig(m *mockServer, quarter string) string {
+	return testAccProviderConfig(m) + `
+resource "appscan_key" "test" {
+  revoke_current_key = true
+  rotation_triggers = {
+    quarter = "` + quarter + `"
+  }
+}
+`
+}
+
+// testAccCheckMockKey checks the resource holds the last key the mock server
+// generated.
+func testAccCheckMockKey(m *mockServer, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		key := m.collections["ApiKeys"][0]
+		return resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(name, "key_id", key["KeyId"].(string)),
+			resource.TestCheckResourceAttr(name, "key_secret", key["KeySecret"].(string)),
+		)(s)
+	}
+}
@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The list data sources accept the filter, select and order_by arguments,
+// passed to the API as the $filter, $select and $orderby options of their
+// collection, for the queries their first-class arguments do not cover.
+
+// listQuerySchema returns the filter, select and order_by arguments of a
+// list data source.
+func listQuerySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"filter": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "An OData $filter expression, sent as is and combined with the other arguments, e.g. `startswith(Name,'pay')`. Values are not escaped: quote them with `replace(value, \"'\", \"''\")` when they may contain single quotes.",
+		},
+		"select": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "The fields the API returns ($select), e.g. to reduce large responses. The identifying fields, such as the ID, are always returned; the attributes of the fields not selected are empty. By default, all of them.",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"order_by": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The OData $orderby expression, e.g. `DateCreated desc`. The entries it leaves tied are ordered by ID.",
+		},
+	}
+}
+
+// listQuery holds the filter, select and order_by arguments of a list data
+// source.
+type listQuery struct {
+	filter  string
+	fields  []string
+	orderBy string
+}
+
+// listQueryFor reads the filter, select and order_by arguments of d.
+func listQueryFor(d *schema.ResourceData) listQuery {
+	q := listQuery{
+		filter:  d.Get("filter").(string),
+		orderBy: d.Get("order_by").(string),
+	}
+	for _, f := range d.Get("select").([]interface{}) {
+		if name, _ := f.(string); name != "" {
+			q.fields = append(q.fields, name)
+		}
+	}
+	return q
+}
+
+// values returns the query options of the list: filter and-ed with the
+// filter of the first-class arguments, the selected fields along with the
+// required ones, which the data source cannot do without, and order_by or
+// else defaultOrderBy.
+func (q listQuery) values(filter, defaultOrderBy string, required ...string) url.Values {
+	query := url.Values{}
+	if q.filter != "" {
+		filter = appscan.ODataAnd(filter, "("+q.filter+")")
+	}
+	if filter != "" {
+		query.Set("$filter", filter)
+	}
+	if len(q.fields) > 0 {
+		fields := slices.Clone(required)
+		for _, f := range q.fields {
+			if !slices.Contains(fields, f) {
+				fields = append(fields, f)
+			}
+		}
+		query.Set("$select", strings.Join(fields, ","))
+	}
+	if q.orderBy != "" {
+		query.Set("$orderby", q.orderBy)
+	} else if defaultOrderBy != "" {
+		query.Set("$orderby", defaultOrderBy)
+	}
+	return query
+}
+
+// listCatalog lists the asset groups or business units matching query,
+// following the pages of the collection.
+func listCatalog(ctx context.Context, client *AppScanClient, collection string, query url.Values) ([]catalogEntry, error) {
+	return listODataPages[catalogEntry](ctx, client, collection, query)
+}
+
+// listPageParallelism bounds the number of pages of a collection fetched at
+// the same time.
+const listPageParallelism = 4
+
+// listODataPages lists the entries of an OData collection matching query,
+// following its pages. The first page tells how many entries match
+// ($count): the other pages are then fetched concurrently, at most
+// listPageParallelism at a time, and merged in order. Without $count, they
+// are fetched one after the other. The entries are ordered by Id after the
+// $orderby of query, if any, so that pages fetched concurrently neither
+// overlap nor miss entries the order leaves tied. query is not modified.
+func listODataPages[T any](ctx context.Context, client *AppScanClient, collection string, query url.Values) ([]T, error) {
+	query = cloneQuery(query)
+	query.Set("$orderby", orderByWithID(query.Get("$orderby")))
+	counted, err := client.supports(ctx, capabilityODataCount)
+	if err != nil {
+		return nil, err
+	}
+	if counted {
+		query.Set("$count", "true")
+	}
+	items, count, err := getListPage[T](ctx, client, collection, query, 0)
+	if err != nil {
+		return nil, err
+	}
+	skip := catalogPageSize
+	if len(items) < catalogPageSize {
+		return items, nil
+	}
+
+	if counted && count > skip {
+		n := (count - skip + catalogPageSize - 1) / catalogPageSize
+		pages := make([][]T, n)
+		err := forEachPage(ctx, n, listPageParallelism, func(ctx context.Context, i int) error {
+			var err error
+			pages[i], _, err = getListPage[T](ctx, client, collection, cloneQuery(query), skip+i*catalogPageSize)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, page := range pages {
+			items = append(items, page...)
+		}
+		skip += n * catalogPageSize
+		if len(pages[n-1]) < catalogPageSize {
+			return items, nil
+		}
+	}
+
+	// The entries added since the count, if any, or all of them without
+	// $count.
+	query.Del("$count")
+	for ; ; skip += catalogPageSize {
+		page, _, err := getListPage[T](ctx, client, collection, query, skip)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, page...)
+		if len(page) < catalogPageSize {
+			return items, nil
+		}
+	}
+}
+
+// forEachPage calls fetch for the pages 0..n-1, running at most parallelism
+// calls at the same time. Once a call fails, or ctx is done, no other call is
+// started and the context of the calls running is cancelled: the first error
+// is returned when they are done.
+func forEachPage(ctx context.Context, n, parallelism int, fetch func(ctx context.Context, i int) error) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	sem := make(chan struct{}, max(parallelism, 1))
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			if err := fetch(ctx, i); err != nil {
+				cancel(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	return context.Cause(ctx)
+}
+
+// orderByWithID returns the $orderby expression orderBy with Id added as the
+// last key, unless orderBy already orders by Id.
+func orderByWithID(orderBy string) string {
+	if orderBy == "" {
+		return "Id"
+	}
+	for _, key := range strings.Split(orderBy, ",") {
+		if field, _, _ := strings.Cut(strings.TrimSpace(key), " "); strings.EqualFold(field, "Id") {
+			return orderBy
+		}
+	}
+	return orderBy + ",Id"
+}
+
+// getListPage fetches the page of query starting at skip, and returns its
+// entries with the number of entries matching query when it has $count.
+func getListPage[T any](ctx context.Context, client *AppScanClient, collection string, query url.Values, skip int) ([]T, int, error) {
+	query.Set("$top", strconv.Itoa(catalogPageSize))
+	query.Set("$skip", strconv.Itoa(skip))
+	var result struct {
+		Items []T `json:"Items"`
+		Count int `json:"Count"`
+	}
+	if err := getODataPage(ctx, client, collection, query, &result); err != nil {
+		return nil, 0, err
+	}
+	return result.Items, result.Count, nil
+}
+
+// cloneQuery returns a copy of query, which the pages fetched concurrently
+// modify.
+func cloneQuery(query url.Values) url.Values {
+	clone := make(url.Values, len(query))
+	for k, v := range query {
+		clone[k] = slices.Clone(v)
+	}
+	return clone
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+)
+
+func TestOrderByWithID(t *testing.T) {
+	for orderBy, expected := range map[string]string{
+		"":                     "Id",
+		"Name":                 "Name,Id",
+		"DateCreated desc":     "DateCreated desc,Id",
+		"Name,Id desc":         "Name,Id desc",
+		"id":                   "id",
+		"Name desc, Id":        "Name desc, Id",
+		"IdentityProvider asc": "IdentityProvider asc,Id",
+	} {
+		if actual := orderByWithID(orderBy); actual != expected {
+			t.Errorf("orderByWithID(%q) = %q, expected %q", orderBy, actual, expected)
+		}
+	}
+}
+
+func TestForEachPageStopsAfterError(t *testing.T) {
+	failure := errors.New("page 2 failed")
+	var started atomic.Int32
+	err := forEachPage(context.Background(), 100, 1, func(ctx context.Context, i int) error {
+		started.Add(1)
+		if i == 2 {
+			return failure
+		}
+		return nil
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("forEachPage returned %v, expected %v", err, failure)
+	}
+	if n := started.Load(); n > 4 {
+		t.Errorf("forEachPage fetched %d pages after the failure of page 2", n-3)
+	}
+}
+
+func TestForEachPageStopsOnCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var started atomic.Int32
+	err := forEachPage(ctx, 100, 2, func(ctx context.Context, i int) error {
+		if started.Add(1) == 2 {
+			cancel()
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("forEachPage returned %v, expected %v", err, context.Canceled)
+	}
+	if n := started.Load(); n > 2 {
+		t.Errorf("forEachPage fetched %d pages after the cancellation", n-2)
+	}
+}
+
+func TestForEachPage(t *testing.T) {
+	fetched := make([]bool, 10)
+	if err := forEachPage(context.Background(), len(fetched), 4, func(ctx context.Context, i int) error {
+		fetched[i] = true
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	for i, ok := range fetched {
+		if !ok {
+			t.Errorf("page %d was not fetched", i)
+		}
+	}
+}
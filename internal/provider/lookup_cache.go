@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"sync"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+)
+
+// Large configurations read the same asset group or business unit by name
+// from many modules. The lookups are kept for the rest of the run, i.e. the
+// life of the provider configuration, so that each name costs one request.
+// Concurrent reads of the same name wait for the first one, and share its
+// result, rather than sending their own request. Failed lookups are not kept
+// for the reads that follow.
+
+// catalogEntry is an asset group or business unit, as returned by the
+// lookups by name.
+type catalogEntry struct {
+	Id          string `json:"Id"`
+	Name        string `json:"Name"`
+	Description string `json:"Description"`
+}
+
+// lookupCache holds the lookups by name of the run, keyed by collection and
+// name.
+type lookupCache struct {
+	mu      sync.Mutex
+	entries map[[2]string]*lookupEntry
+}
+
+// lookupEntry is a lookup, done once done is closed.
+type lookupEntry struct {
+	done  chan struct{}
+	items []catalogEntry
+	err   error
+}
+
+// lookupByName returns the entries of an OData collection, such as
+// AssetGroups or BusinessUnits, named name.
+func (c *AppScanClient) lookupByName(ctx context.Context, collection, name string) ([]catalogEntry, error) {
+	key := [2]string{collection, name}
+
+	c.lookups.mu.Lock()
+	if c.lookups.entries == nil {
+		c.lookups.entries = map[[2]string]*lookupEntry{}
+	}
+	if e, ok := c.lookups.entries[key]; ok {
+		c.lookups.mu.Unlock()
+		<-e.done
+		if e.err == nil {
+			log.Printf("[DEBUG] %s named %q found in the lookup cache", collection, name)
+		}
+		return e.items, e.err
+	}
+	e := &lookupEntry{done: make(chan struct{})}
+	c.lookups.entries[key] = e
+	c.lookups.mu.Unlock()
+
+	e.items, e.err = fetchByName(ctx, c, collection, name)
+	if e.err != nil {
+		c.lookups.mu.Lock()
+		delete(c.lookups.entries, key)
+		c.lookups.mu.Unlock()
+	}
+	close(e.done)
+	return e.items, e.err
+}
+
+// fetchByName lists the entries of an OData collection named name.
+func fetchByName(ctx context.Context, client *AppScanClient, collection, name string) ([]catalogEntry, error) {
+	filterQuery, err := appscan.ODataEqString("Name", name)
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	query.Set("$filter", filterQuery)
+
+	urlStr := fmt.Sprintf("%s/%s?%s", client.ApiBase, collection, query.Encode())
+	req, err := client.newRequest(ctx, "GET", urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, appscan.NewAPIError("read "+collection, resp)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Items []catalogEntry `json:"Items"`
+	}
+	if err := appscan.DecodeODataPage(resp, body, &result); err != nil {
+		return nil, err
+	}
+	return result.Items, nil
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestLookupByName(t *testing.T) {
+	m := newMockServer(t)
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"api_endpoint": m.URL,
+		"key_id":       mockKeyID,
+		"key_secret":   mockKeySecret,
+	})
+	client, err := configureClient(context.Background(), d, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			items, err := client.lookupByName(context.Background(), "AssetGroups", "Default Asset Group")
+			if err != nil {
+				t.Error(err)
+			} else if len(items) != 1 || items[0].Id != mockAssetGroupID {
+				t.Errorf("unexpected asset groups: %v", items)
+			}
+		}()
+	}
+	wg.Wait()
+	if _, err := client.lookupByName(context.Background(), "BusinessUnits", "Default Business Unit"); err != nil {
+		t.Fatal(err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if n := m.requests["GET /api/v4/AssetGroups"]; n != 1 {
+		t.Errorf("expected 1 request for the asset group, got %d", n)
+	}
+	if n := m.requests["GET /api/v4/BusinessUnits"]; n != 1 {
+		t.Errorf("expected 1 request for the business unit, got %d", n)
+	}
+}
@@ -0,0 +1,281 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// metricsPushTimeout bounds the push to the Prometheus Pushgateway, which
+// happens while Terraform waits for the provider to exit.
+const metricsPushTimeout = 5 * time.Second
+
+// metricsJob is the Pushgateway job the metrics are pushed under.
+const metricsJob = "terraform-provider-appscan"
+
+// endpointIDRegexp matches the IDs in API paths, so that the calls to the
+// same endpoint are counted together whatever the entity.
+var endpointIDRegexp = regexp.MustCompile(`[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}`)
+
+// requestMetrics counts the API calls of a provider run per endpoint: the
+// calls, the retries of throttled or failed calls, the failures and the
+// latency. They are logged when Terraform stops the provider and, when
+// configured, mirrored to a JSON file, rewritten after every call like the
+// run summary, and pushed to a Prometheus Pushgateway.
+type requestMetrics struct {
+	mu             sync.Mutex
+	path           string
+	pushgatewayURL string
+	// basePath is the path of the API base, trimmed from the endpoints.
+	basePath string
+	client   *http.Client
+
+	StartedAt time.Time                   `json:"started_at"`
+	UpdatedAt time.Time                   `json:"updated_at"`
+	Endpoints map[string]*endpointMetrics `json:"endpoints"`
+}
+
+// endpointMetrics are the metrics of an endpoint, e.g. "GET /Apps/{id}".
+type endpointMetrics struct {
+	Calls int `json:"calls"`
+	// Retries counts the calls that repeated a throttled or failed one.
+	Retries int `json:"retries"`
+	// Failures counts the calls that failed to connect or returned an
+	// error status.
+	Failures   int   `json:"failures"`
+	DurationMs int64 `json:"duration_ms"`
+	MaxMs      int64 `json:"max_ms"`
+}
+
+// newRequestMetrics returns the metrics of a run on the API whose base has
+// basePath, e.g. /api/v4, written to path and pushed to pushgatewayURL
+// through client when set.
+func newRequestMetrics(basePath, path, pushgatewayURL string, client *http.Client) *requestMetrics {
+	now := time.Now().UTC()
+	return &requestMetrics{
+		path:           path,
+		pushgatewayURL: strings.TrimRight(pushgatewayURL, "/"),
+		basePath:       strings.TrimRight(basePath, "/"),
+		client:         client,
+		StartedAt:      now,
+		UpdatedAt:      now,
+		Endpoints:      map[string]*endpointMetrics{},
+	}
+}
+
+// endpoint returns the name of the endpoint req is sent to: its method and
+// its path, relative to the API base, with the IDs replaced by {id}.
+func (r *requestMetrics) endpoint(req *http.Request) string {
+	path := req.URL.Path
+	if r.basePath != "" && strings.HasPrefix(path, r.basePath+"/") {
+		path = strings.TrimPrefix(path, r.basePath)
+	}
+	return req.Method + " " + endpointIDRegexp.ReplaceAllString(path, "{id}")
+}
+
+// get returns the metrics of an endpoint, adding them when missing. r.mu
+// must be held.
+func (r *requestMetrics) get(endpoint string) *endpointMetrics {
+	e, ok := r.Endpoints[endpoint]
+	if !ok {
+		e = &endpointMetrics{}
+		r.Endpoints[endpoint] = e
+	}
+	return e
+}
+
+// call records a call to the endpoint of req. It is a no-op on nil metrics,
+// like record on a nil run summary.
+func (r *requestMetrics) call(req *http.Request, duration time.Duration, failed bool) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	e := r.get(r.endpoint(req))
+	e.Calls++
+	if failed {
+		e.Failures++
+	}
+	ms := duration.Milliseconds()
+	e.DurationMs += ms
+	e.MaxMs = max(e.MaxMs, ms)
+	r.UpdatedAt = time.Now().UTC()
+	if r.path != "" {
+		if err := r.flush(); err != nil {
+			log.Printf("[WARN] unable to write API metrics to %s: %s", r.path, err)
+		}
+	}
+}
+
+// retry records that req is about to be sent again.
+func (r *requestMetrics) retry(req *http.Request) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.get(r.endpoint(req)).Retries++
+}
+
+// flush writes the metrics atomically, like the run summary. r.mu must be
+// held.
+func (r *requestMetrics) flush() error {
+	body, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(r.path), ".appscan-metrics-*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(body); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), r.path)
+}
+
+// endpointNames returns the endpoints called, the most time spent first.
+// r.mu must be held.
+func (r *requestMetrics) endpointNames() []string {
+	names := make([]string, 0, len(r.Endpoints))
+	for name := range r.Endpoints {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		a, b := r.Endpoints[names[i]], r.Endpoints[names[j]]
+		if a.DurationMs != b.DurationMs {
+			return a.DurationMs > b.DurationMs
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// summary returns the metrics as log lines: the totals of the run, then one
+// line per endpoint.
+func (r *requestMetrics) summary() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var total endpointMetrics
+	names := r.endpointNames()
+	lines := []string{""}
+	for _, name := range names {
+		e := r.Endpoints[name]
+		total.Calls += e.Calls
+		total.Retries += e.Retries
+		total.Failures += e.Failures
+		total.DurationMs += e.DurationMs
+		lines = append(lines, fmt.Sprintf("%s: %d calls, %d retries, %d failures, %s total, %s max",
+			name, e.Calls, e.Retries, e.Failures, time.Duration(e.DurationMs)*time.Millisecond, time.Duration(e.MaxMs)*time.Millisecond))
+	}
+	lines[0] = fmt.Sprintf("%d API calls to %d endpoints in %s, %d retries, %d failures, %s spent in calls",
+		total.Calls, len(names), r.UpdatedAt.Sub(r.StartedAt).Round(time.Millisecond), total.Retries, total.Failures,
+		time.Duration(total.DurationMs)*time.Millisecond)
+	return lines
+}
+
+// prometheus returns the metrics in the Prometheus text exposition format.
+func (r *requestMetrics) prometheus() string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var b strings.Builder
+	names := r.endpointNames()
+	sort.Strings(names)
+	series := []struct {
+		name, kind, help string
+		value            func(e *endpointMetrics) string
+	}{
+		{"appscan_api_requests_total", "counter", "API calls, retries included.", func(e *endpointMetrics) string { return fmt.Sprint(e.Calls) }},
+		{"appscan_api_retries_total", "counter", "Retries of throttled or failed API calls.", func(e *endpointMetrics) string { return fmt.Sprint(e.Retries) }},
+		{"appscan_api_failures_total", "counter", "API calls that failed to connect or returned an error status.", func(e *endpointMetrics) string { return fmt.Sprint(e.Failures) }},
+		{"appscan_api_request_duration_seconds_total", "counter", "Time spent in API calls.", func(e *endpointMetrics) string { return fmt.Sprint(float64(e.DurationMs) / 1000) }},
+		{"appscan_api_request_duration_seconds_max", "gauge", "Longest API call.", func(e *endpointMetrics) string { return fmt.Sprint(float64(e.MaxMs) / 1000) }},
+	}
+	for _, s := range series {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n", s.name, s.help, s.name, s.kind)
+		for _, name := range names {
+			method, path, _ := strings.Cut(name, " ")
+			fmt.Fprintf(&b, "%s{method=%q,endpoint=%q} %s\n", s.name, method, path, s.value(r.Endpoints[name]))
+		}
+	}
+	return b.String()
+}
+
+// push replaces the metrics of the job on the Pushgateway with r.
+func (r *requestMetrics) push() error {
+	req, err := http.NewRequest("PUT", r.pushgatewayURL+"/metrics/job/"+metricsJob, bytes.NewBufferString(r.prometheus()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 {
+		body, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// report logs the metrics and pushes them to the Pushgateway, if any.
+func (r *requestMetrics) report() {
+	for _, line := range r.summary() {
+		log.Printf("[INFO] AppScan API metrics: %s", line)
+	}
+	if r.pushgatewayURL == "" {
+		return
+	}
+	if err := r.push(); err != nil {
+		log.Printf("[WARN] unable to push API metrics to %s: %s", r.pushgatewayURL, err)
+	}
+}
+
+// ReportMetrics logs the API metrics of the run of p, and pushes them to the
+// Pushgateway when configured. Terraform gives providers no "end of apply"
+// hook: it is called once p has stopped serving, at the end of each
+// Terraform command.
+func ReportMetrics(p *schema.Provider) {
+	if client, ok := p.Meta().(*AppScanClient); ok && client.Metrics != nil {
+		client.Metrics.report()
+	}
+}
+
+// metricsTransport records every API call, retries included, in metrics.
+type metricsTransport struct {
+	transport http.RoundTripper
+	metrics   *requestMetrics
+}
+
+func (t *metricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.transport.RoundTrip(req)
+	t.metrics.call(req, time.Since(start), err != nil || resp.StatusCode >= 400)
+	return resp, err
+}
@@ -0,0 +1,112 @@
+package provider
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRequestMetrics(t *testing.T) {
+	defer func(d time.Duration) { retryBaseDelay = d }(retryBaseDelay)
+	retryBaseDelay = time.Millisecond
+
+	throttled := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/Apps") && throttled < 2 {
+			throttled++
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		if strings.Contains(r.URL.Path, "/Scans/") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(t.TempDir(), "metrics.json")
+	metrics := newRequestMetrics("/api/v4/", path, "", nil)
+	rateLimit := newRateLimitTransport(&metricsTransport{transport: http.DefaultTransport, metrics: metrics}, 0, 3)
+	rateLimit.metrics = metrics
+	client := &http.Client{Transport: rateLimit}
+
+	for _, u := range []string{
+		"/api/v4/Apps",
+		"/api/v4/Apps/44444444-4444-4444-4444-444444444444",
+		"/api/v4/Apps/44444444-4444-4444-4444-444444444445",
+		"/api/v4/Scans/aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa",
+		"/download",
+	} {
+		resp, err := client.Get(server.URL + u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+
+	want := map[string]endpointMetrics{
+		"GET /Apps":       {Calls: 3, Retries: 2, Failures: 2},
+		"GET /Apps/{id}":  {Calls: 2},
+		"GET /Scans/{id}": {Calls: 1, Failures: 1},
+		"GET /download":   {Calls: 1},
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var written struct {
+		Endpoints map[string]endpointMetrics `json:"endpoints"`
+	}
+	if err := json.Unmarshal(content, &written); err != nil {
+		t.Fatal(err)
+	}
+	if len(written.Endpoints) != len(want) {
+		t.Errorf("endpoints = %v, want %v", written.Endpoints, want)
+	}
+	for name, w := range want {
+		got := written.Endpoints[name]
+		if got.Calls != w.Calls || got.Retries != w.Retries || got.Failures != w.Failures {
+			t.Errorf("%s = %+v, want %+v", name, got, w)
+		}
+	}
+
+	summary := metrics.summary()
+	if len(summary) != 5 || !strings.HasPrefix(summary[0], "7 API calls to 4 endpoints") || !strings.Contains(summary[0], "2 retries, 3 failures") {
+		t.Errorf("summary = %q", summary)
+	}
+}
+
+func TestRequestMetricsPush(t *testing.T) {
+	var method, path, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		content, _ := io.ReadAll(r.Body)
+		method, path, body = r.Method, r.URL.Path, string(content)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	metrics := newRequestMetrics("/api/v4", "", server.URL+"/", server.Client())
+	req := httptest.NewRequest("DELETE", "https://cloud.appscan.com/api/v4/Apps/44444444-4444-4444-4444-444444444444", nil)
+	metrics.call(req, 1500*time.Millisecond, false)
+	metrics.report()
+
+	if method != "PUT" || path != "/metrics/job/"+metricsJob {
+		t.Errorf("pushed with %s %s", method, path)
+	}
+	for _, line := range []string{
+		"# TYPE appscan_api_requests_total counter",
+		`appscan_api_requests_total{method="DELETE",endpoint="/Apps/{id}"} 1`,
+		`appscan_api_request_duration_seconds_max{method="DELETE",endpoint="/Apps/{id}"} 1.5`,
+	} {
+		if !strings.Contains(body, line+"\n") {
+			t.Errorf("pushed metrics lack %q:\n%s", line, body)
+		}
+	}
+}
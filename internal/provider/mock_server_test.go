@@ -0,0 +1,1201 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+)
+
+// Mock AppScan on Cloud API used by the acceptance tests. It keeps entities
+// in memory, one collection per API resource, and understands the subset of
+// OData the provider emits (eq and ge clauses joined by and/or, $orderby on
+// one field, $select, $top, $skip and $count).
+
+const (
+	mockKeyID       = "mock-key-id"
+	mockKeySecret   = "mock-key-secret"
+	mockToken       = "mock-token"
+	mockTokenExpiry = "2030-01-01T00:00:00Z"
+)
+
+type mockEntity map[string]interface{}
+
+type mockServer struct {
+	*httptest.Server
+
+	mu          sync.Mutex
+	collections map[string][]mockEntity
+	files       map[string][]byte
+	// rejectMoves makes the API refuse to move applications between asset
+	// groups.
+	rejectMoves bool
+	// headers are added to every authenticated response, e.g. rate-limit
+	// headers.
+	headers http.Header
+	// lostResponses is the number of upcoming requests other than GET whose
+	// response never reaches the client, as when the connection breaks
+	// after the API acted on them.
+	lostResponses int
+	// executionFailures are the messages of the next scan executions to
+	// fail, one per execution.
+	executionFailures []string
+	// noCount makes the API ignore $count, as older revisions do.
+	noCount bool
+	// quotedGUIDs makes the API reject GUID literals in $filter, as some
+	// tenants do, only matching GUIDs quoted as strings.
+	quotedGUIDs bool
+	// promotions are the IDs of the executions whose issues were promoted
+	// to their application.
+	promotions []string
+	// requests counts the authenticated requests and the logins, by method
+	// and path, e.g. "GET /api/v4/AssetGroups".
+	requests map[string]int
+	// listingDelay is the number of list requests that omit the entities
+	// created from then on, as when the API is eventually consistent.
+	listingDelay int
+	// unlisted counts, by ID, the list requests still omitting an entity.
+	unlisted map[string]int
+}
+
+// newMockServer starts a mock API seeded with one asset group and one
+// business unit, and stops it when the test ends.
+func newMockServer(t *testing.T) *mockServer {
+	t.Helper()
+
+	m := &mockServer{
+		collections: map[string][]mockEntity{},
+		files:       map[string][]byte{},
+		requests:    map[string]int{},
+		unlisted:    map[string]int{},
+	}
+	m.add("AssetGroups", mockEntity{"Id": mockAssetGroupID, "Name": "Default Asset Group", "Description": "The default asset group"})
+	m.add("AssetGroups", mockEntity{"Id": "22222222-2222-2222-2222-222222222222", "Name": "O'Brien's Apps", "Description": ""})
+	m.add("BusinessUnits", mockEntity{"Id": mockBusinessUnitID, "Name": "Default Business Unit", "Description": "The default business unit"})
+	m.add("Tenants", mockEntity{"TenantId": mockTenantID, "TenantName": "Mock Tenant", "ActiveTechnologies": "DynamicAnalyzer, StaticAnalyzer", "AllowPresence": true,
+		"NumberOfApps": 2, "MaxScansPerApp": 100, "MaxUsers": 25, "ContactEmail": "security@example.com", "IdleTimeForSignout": 30,
+		"EnableIssuesAutoClose": false, "ShowNonCompliantIssuesOnly": false,
+		"Subscriptions": []mockEntity{
+			{"SubscriptionId": 1001, "OfferingType": "ScanExecution", "State": "Active", "ExpirationDate": "2020-01-01T00:00:00Z", "NSeats": 50, "NTakenSeats": 50},
+			{"SubscriptionId": 1002, "OfferingType": "ScanExecution", "State": "Active", "ExpirationDate": "2999-01-01T00:00:00Z", "NSeats": 100, "NTakenSeats": 40, "MaxConcurrentScans": 5},
+		}})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v4/Account/ApiKeyLogin", m.handleLogin)
+	mux.HandleFunc("GET /api/v4/Account/TenantInfo", m.authenticated(m.handleTenantInfo))
+	mux.HandleFunc("PUT /api/v4/Account/TenantInfo", m.authenticated(m.handleUpdateTenantInfo))
+
+	mux.HandleFunc("POST /api/v4/Account/ApiKey", m.authenticated(m.handleCreateApiKey))
+	mux.HandleFunc("GET /api/v4/Apps", m.authenticated(m.handleList("Apps")))
+	mux.HandleFunc("POST /api/v4/Apps", m.authenticated(m.handleCreateApp))
+	mux.HandleFunc("PUT /api/v4/Apps/{id}", m.authenticated(m.handleUpdateApp))
+	mux.HandleFunc("DELETE /api/v4/Apps/{id}", m.authenticated(m.handleDelete("Apps")))
+	mux.HandleFunc("GET /api/v4/AssetGroups", m.authenticated(m.handleList("AssetGroups")))
+	mux.HandleFunc("GET /api/v4/BusinessUnits", m.authenticated(m.handleList("BusinessUnits")))
+	mux.HandleFunc("GET /api/v4/User", m.authenticated(m.handleList("Users")))
+	mux.HandleFunc("PUT /api/v4/User/{id}", m.authenticated(m.handleUpdate("Users")))
+	mux.HandleFunc("GET /api/v4/Roles", m.authenticated(m.handleList("Roles")))
+
+	mux.HandleFunc("GET /api/v4/Issues/{scope}/{id}", m.authenticated(m.handleIssuesPath))
+	mux.HandleFunc("PUT /api/v4/Issues/Application/{id}", m.authenticated(m.handleUpdateIssues))
+	mux.HandleFunc("GET /api/v4/Issues/{id}", m.authenticated(m.handleGet("Issues")))
+
+	mux.HandleFunc("POST /api/v4/Reports/Security/{scope}/{id}", m.authenticated(m.handleCreateReport))
+	mux.HandleFunc("GET /api/v4/Reports", m.authenticated(m.handleList("Reports")))
+	mux.HandleFunc("GET /api/v4/Reports/{id}/Download", m.authenticated(m.handleDownloadReport))
+	mux.HandleFunc("DELETE /api/v4/Reports/{id}", m.authenticated(m.handleDelete("Reports")))
+
+	mux.HandleFunc("POST /api/v4/FileUpload", m.authenticated(m.handleFileUpload))
+	mux.HandleFunc("POST /api/v4/Scans/Dast", m.authenticated(m.handleCreateScan("DynamicAnalyzer")))
+	mux.HandleFunc("POST /api/v4/Scans/Sast", m.authenticated(m.handleCreateScan("StaticAnalyzer")))
+	mux.HandleFunc("GET /api/v4/Scans", m.authenticated(m.handleList("Scans")))
+	mux.HandleFunc("GET /api/v4/Scans/Dast/{id}", m.authenticated(m.handleGet("Scans")))
+	mux.HandleFunc("GET /api/v4/Scans/Sast/{id}", m.authenticated(m.handleGet("Scans")))
+	mux.HandleFunc("PUT /api/v4/Scans/{id}", m.authenticated(m.handleUpdateScan))
+	mux.HandleFunc("DELETE /api/v4/Scans/{id}", m.authenticated(m.handleDelete("Scans")))
+	mux.HandleFunc("POST /api/v4/Scans/{id}/Executions", m.authenticated(m.handleExecuteScan))
+	mux.HandleFunc("POST /api/v4/Scans/{id}/PromoteIssues", m.authenticated(m.handlePromoteIssues))
+	mux.HandleFunc("GET /api/v4/Scans/Execution/{id}", m.authenticated(m.handleGet("Executions")))
+	mux.HandleFunc("DELETE /api/v4/Scans/Execution/{id}", m.authenticated(m.handleDelete("Executions")))
+	mux.HandleFunc("GET /api/v4/Scans/DastExecution/{id}", m.authenticated(m.handleGet("Executions")))
+	mux.HandleFunc("GET /api/v4/Scans/SastExecution/{id}", m.authenticated(m.handleGet("Executions")))
+	mux.HandleFunc("GET /api/v4/Scans/ScanLogs/{id}", m.authenticated(m.handleArtifact("Scans")))
+	mux.HandleFunc("GET /api/v4/Scans/ExecutionRawResults/{id}", m.authenticated(m.handleArtifact("Executions")))
+	mux.HandleFunc("GET /api/v4/Scans/DastScanFile/{id}", m.authenticated(m.handleArtifact("Executions")))
+
+	mux.HandleFunc("GET /api/v4/Apps/{appId}/Policy", m.authenticated(m.handleListAppPolicies))
+	mux.HandleFunc("POST /api/v4/Apps/{appId}/Policy/{id}", m.authenticated(m.handleAssociatePolicy))
+	mux.HandleFunc("PUT /api/v4/Apps/{appId}/Policy/{id}", m.authenticated(m.handleConfigurePolicy))
+	mux.HandleFunc("DELETE /api/v4/Apps/{appId}/Policy/{id}", m.authenticated(m.handleDisassociatePolicy))
+	mux.HandleFunc("GET /api/v4/Presences", m.authenticated(m.handleList("Presences")))
+	mux.HandleFunc("GET /api/v4/Presences/{id}/NewKey", m.authenticated(m.handleNewPresenceKey))
+	mux.HandleFunc("GET /api/v4/Webhooks", m.authenticated(m.handleList("Webhooks")))
+	mux.HandleFunc("POST /api/v4/Webhooks", m.authenticated(m.handleSaveWebhook))
+	mux.HandleFunc("PUT /api/v4/Webhooks/{id}", m.authenticated(m.handleSaveWebhook))
+	mux.HandleFunc("DELETE /api/v4/Webhooks/{id}", m.authenticated(m.handleDelete("Webhooks")))
+	mux.HandleFunc("GET /api/v4/Webhooks/Associations/{id}", m.authenticated(m.handleWebhookAssociations))
+	mux.HandleFunc("POST /api/v4/Webhooks/Associations/{id}", m.authenticated(m.handleWebhookAssociations))
+	mux.HandleFunc("DELETE /api/v4/Webhooks/Associations/{id}", m.authenticated(m.handleWebhookAssociations))
+
+	mux.HandleFunc("GET /api/v4/Domains", m.authenticated(m.handleList("Domains")))
+	mux.HandleFunc("POST /api/v4/Domains/Allow", m.authenticated(m.handleSaveDomain))
+	mux.HandleFunc("PUT /api/v4/Domains/{id}", m.authenticated(m.handleSaveDomain))
+	mux.HandleFunc("DELETE /api/v4/Domains/DeleteDomains", m.authenticated(m.handleDeleteDomains))
+
+	mux.HandleFunc("POST /api/v4/CustomFields/{orgId}/customFields", m.authenticated(m.handleCreateCustomField))
+	mux.HandleFunc("GET /api/v4/CustomFields/organization/{orgId}/CustomFields", m.authenticated(m.handleCustomFields))
+	mux.HandleFunc("GET /api/v4/CustomFields/application/{appId}/CustomFields", m.authenticated(m.handleCustomFields))
+	mux.HandleFunc("POST /api/v4/CustomFields/Apps/{appId}/CustomFields", m.authenticated(m.handleSetCustomField))
+
+	// The API as served behind a reverse proxy, under another path.
+	mux.Handle("/proxied/", http.StripPrefix("/proxied", mux))
+
+	m.Server = httptest.NewServer(mux)
+	t.Cleanup(m.Close)
+	return m
+}
+
+const (
+	mockTenantID       = "66666666-6666-6666-6666-666666666666"
+	mockAssetGroupID   = "11111111-1111-1111-1111-111111111111"
+	mockBusinessUnitID = "33333333-3333-3333-3333-333333333333"
+
+	mockScanFileStartingURL = "https://scanfile.example.com/"
+)
+
+// add stores an entity, assigning it an Id when it has none.
+func (m *mockServer) add(collection string, e mockEntity) mockEntity {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := e["Id"]; !ok {
+		id, _ := uuid.GenerateUUID()
+		e["Id"] = id
+	}
+	m.collections[collection] = append(m.collections[collection], e)
+	return e
+}
+
+func (m *mockServer) find(collection, id string) mockEntity {
+	for _, e := range m.collections[collection] {
+		if strings.EqualFold(fmt.Sprint(e["Id"]), id) {
+			return e
+		}
+	}
+	return nil
+}
+
+func (m *mockServer) authenticated(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+mockToken {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		m.requests[r.Method+" "+r.URL.Path]++
+		for k, v := range m.headers {
+			w.Header()[k] = v
+		}
+		if m.quotedGUIDs && mockGUIDLiteralRegexp.MatchString(r.URL.Query().Get("$filter")) {
+			writeError(w, http.StatusBadRequest, "InvalidFilter", "The query specified in the URI is not valid.")
+			return
+		}
+		if m.lostResponses > 0 && r.Method != http.MethodGet {
+			m.lostResponses--
+			next(httptest.NewRecorder(), r)
+			conn, _, err := http.NewResponseController(w).Hijack()
+			if err == nil {
+				conn.Close()
+			}
+			return
+		}
+		next(w, r)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, key, message string) {
+	writeJSON(w, status, map[string]interface{}{"Key": key, "Message": message})
+}
+
+func decodeBody(r *http.Request) (mockEntity, error) {
+	var e mockEntity
+	err := json.NewDecoder(r.Body).Decode(&e)
+	return e, err
+}
+
+func (m *mockServer) handleLogin(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	m.requests[r.Method+" "+r.URL.Path]++
+	m.mu.Unlock()
+	body, err := decodeBody(r)
+	if err != nil || body["KeyId"] != mockKeyID || body["KeySecret"] != mockKeySecret {
+		writeError(w, http.StatusUnauthorized, "InvalidApiKey", "Invalid API key")
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"Token": mockToken, "Expire": mockTokenExpiry})
+}
+
+// created records an entity created through the API, omitted from the list
+// requests according to listingDelay.
+func (m *mockServer) created(e mockEntity) {
+	if m.listingDelay > 0 {
+		m.unlisted[fmt.Sprint(e["Id"])] = m.listingDelay
+	}
+}
+
+func (m *mockServer) handleList(collection string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		listed := []mockEntity{}
+		for _, e := range m.collections[collection] {
+			if id := fmt.Sprint(e["Id"]); m.unlisted[id] > 0 {
+				m.unlisted[id]--
+				continue
+			}
+			listed = append(listed, e)
+		}
+		items, count, err := mockQuery(listed, r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidFilter", err.Error())
+			return
+		}
+		if m.noCount {
+			writeJSON(w, http.StatusOK, map[string]interface{}{"Items": items})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"Items": items, "Count": count})
+	}
+}
+
+func (m *mockServer) handleGet(collection string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		e := m.find(collection, r.PathValue("id"))
+		if e == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "not found")
+			return
+		}
+		writeJSON(w, http.StatusOK, e)
+	}
+}
+
+func (m *mockServer) handleUpdate(collection string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		e := m.find(collection, r.PathValue("id"))
+		if e == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "not found")
+			return
+		}
+		body, err := decodeBody(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidBody", err.Error())
+			return
+		}
+		for k, v := range body {
+			e[k] = v
+		}
+		writeJSON(w, http.StatusOK, e)
+	}
+}
+
+// handleUpdateScan updates a scan, whose technology models name
+// EnableMailNotifications EnableMailNotification.
+func (m *mockServer) handleUpdateScan(w http.ResponseWriter, r *http.Request) {
+	m.handleUpdate("Scans")(w, r)
+	if e := m.find("Scans", r.PathValue("id")); e != nil {
+		if enabled, ok := e["EnableMailNotifications"]; ok {
+			e["EnableMailNotification"] = enabled
+		}
+	}
+}
+
+func (m *mockServer) handleDelete(collection string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		items := m.collections[collection]
+		for i, e := range items {
+			if strings.EqualFold(e["Id"].(string), id) {
+				m.collections[collection] = append(items[:i:i], items[i+1:]...)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+		}
+		writeError(w, http.StatusNotFound, "NotFound", "not found")
+	}
+}
+
+func (m *mockServer) handleCreateApp(w http.ResponseWriter, r *http.Request) {
+	body, err := decodeBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidBody", err.Error())
+		return
+	}
+	normalizeMockApp(body)
+	if name, _ := body["Name"].(string); name == "" {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+			"title":  "One or more validation errors occurred.",
+			"errors": map[string][]string{"Name": {"The Name field is required."}},
+		})
+		return
+	}
+	if m.find("AssetGroups", fmt.Sprint(body["AssetGroupId"])) == nil {
+		writeError(w, http.StatusBadRequest, "AssetGroupNotFound", "Asset group not found")
+		return
+	}
+	// Like the API, return null rather than omit the optional fields.
+	for _, f := range []string{"Description", "BusinessUnitId"} {
+		if _, ok := body[f]; !ok {
+			body[f] = nil
+		}
+	}
+	id, _ := uuid.GenerateUUID()
+	body["Id"] = id
+	body["DateCreated"] = time.Now().UTC().Format(time.RFC3339)
+	body["RiskRating"] = "Unknown"
+	body["MaxSeverity"] = "Undetermined"
+	for _, f := range []string{"CriticalIssues", "HighIssues", "MediumIssues", "LowIssues", "InformationalIssues", "OpenIssues", "IssuesInProgress", "TotalIssues"} {
+		body[f] = 0
+	}
+	m.collections["Apps"] = append(m.collections["Apps"], body)
+	m.created(body)
+	writeJSON(w, http.StatusCreated, body)
+}
+
+func (m *mockServer) handleUpdateApp(w http.ResponseWriter, r *http.Request) {
+	app := m.find("Apps", r.PathValue("id"))
+	if app == nil {
+		writeError(w, http.StatusNotFound, "NotFound", "not found")
+		return
+	}
+	body, err := decodeBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidBody", err.Error())
+		return
+	}
+	normalizeMockApp(body)
+	if group, ok := body["AssetGroupId"]; ok && group != app["AssetGroupId"] {
+		if m.rejectMoves {
+			writeError(w, http.StatusBadRequest, "MoveNotAllowed", "The application cannot be moved to this asset group")
+			return
+		}
+		if m.find("AssetGroups", fmt.Sprint(group)) == nil {
+			writeError(w, http.StatusBadRequest, "AssetGroupNotFound", "Asset group not found")
+			return
+		}
+	}
+	for k, v := range body {
+		app[k] = v
+	}
+	writeJSON(w, http.StatusOK, app)
+}
+
+// normalizeMockApp normalizes an ApplicationModel payload as the API does:
+// names and descriptions are trimmed, the business impact is title-cased.
+func normalizeMockApp(body map[string]interface{}) {
+	for _, f := range []string{"Name", "Description"} {
+		if v, ok := body[f].(string); ok {
+			body[f] = strings.TrimSpace(v)
+		}
+	}
+	if v, ok := body["BusinessImpact"].(string); ok && v != "" {
+		body["BusinessImpact"] = strings.ToUpper(v[:1]) + strings.ToLower(v[1:])
+	}
+}
+
+// handleIssuesPath serves the issues of an application and the comments of
+// an issue, whose paths the mux cannot tell apart.
+func (m *mockServer) handleIssuesPath(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.PathValue("scope") == "Application":
+		m.handleListIssues(w, r)
+	case r.PathValue("id") == "Comments":
+		m.handleListIssueComments(w, r)
+	default:
+		writeError(w, http.StatusNotFound, "NotFound", "not found")
+	}
+}
+
+// handleListIssues lists the issues of an application.
+func (m *mockServer) handleListIssues(w http.ResponseWriter, r *http.Request) {
+	var issues []mockEntity
+	for _, is := range m.collections["Issues"] {
+		if strings.EqualFold(fmt.Sprint(is["ApplicationId"]), r.PathValue("id")) {
+			issues = append(issues, is)
+		}
+	}
+	items, count, err := mockQuery(issues, r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidFilter", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"Items": items, "Count": count})
+}
+
+// handleUpdateIssues applies a status, and adds a comment, to the issues of
+// an application matching odataFilter.
+func (m *mockServer) handleUpdateIssues(w http.ResponseWriter, r *http.Request) {
+	body, err := decodeBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidBody", err.Error())
+		return
+	}
+	updated := 0
+	for _, is := range m.collections["Issues"] {
+		if !strings.EqualFold(fmt.Sprint(is["ApplicationId"]), r.PathValue("id")) {
+			continue
+		}
+		ok, err := mockMatch(is, r.URL.Query().Get("odataFilter"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidFilter", err.Error())
+			return
+		}
+		if !ok {
+			continue
+		}
+		if status, ok := body["Status"]; ok && status != nil {
+			is["Status"] = status
+		}
+		if comment, ok := body["Comment"]; ok && comment != nil {
+			m.collections["IssueComments"] = append(m.collections["IssueComments"], mockEntity{
+				"IssueId":     is["Id"],
+				"Comment":     comment,
+				"DateCreated": time.Now().UTC().Format(time.RFC3339Nano),
+				"CreatedBy":   map[string]interface{}{"UserName": "mock-user"},
+			})
+		}
+		updated++
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"NUpdatedIssues": updated})
+}
+
+// handleListIssueComments lists the comments of an issue.
+func (m *mockServer) handleListIssueComments(w http.ResponseWriter, r *http.Request) {
+	issueID := r.PathValue("scope")
+	if m.find("Issues", issueID) == nil {
+		writeError(w, http.StatusNotFound, "NotFound", "not found")
+		return
+	}
+	var comments []mockEntity
+	for _, c := range m.collections["IssueComments"] {
+		if strings.EqualFold(fmt.Sprint(c["IssueId"]), issueID) {
+			comments = append(comments, c)
+		}
+	}
+	items, count, err := mockQuery(comments, r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidFilter", err.Error())
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"Items": items, "Count": count})
+}
+
+func (m *mockServer) handleCreateReport(w http.ResponseWriter, r *http.Request) {
+	body, err := decodeBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidBody", err.Error())
+		return
+	}
+	configuration, _ := body["Configuration"].(map[string]interface{})
+	id, _ := uuid.GenerateUUID()
+	// Reports are generated instantly.
+	report := mockEntity{
+		"Id":             id,
+		"Name":           "Security report",
+		"Status":         "Ready",
+		"Progress":       100,
+		"DownloadLink":   m.URL + "/api/v4/Reports/" + id + "/Download",
+		"ReportFileType": configuration["ReportFileType"],
+		"OdataFilter":    body["OdataFilter"],
+		"Configuration":  configuration,
+	}
+	m.collections["Reports"] = append(m.collections["Reports"], report)
+	m.created(report)
+	writeJSON(w, http.StatusOK, report)
+}
+
+// handleDownloadReport returns a report holding its filter, if any.
+func (m *mockServer) handleDownloadReport(w http.ResponseWriter, r *http.Request) {
+	report := m.find("Reports", r.PathValue("id"))
+	if report == nil {
+		writeError(w, http.StatusNotFound, "NotFound", "not found")
+		return
+	}
+	filter, _ := report["OdataFilter"].(string)
+	switch report["ReportFileType"] {
+	case "Sarif":
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"version": "2.1.0",
+			"runs":    []mockEntity{{"tool": mockEntity{"driver": mockEntity{"name": "HCL AppScan"}}, "properties": mockEntity{"filter": filter}}},
+		})
+	case "Csv":
+		w.Header().Set("Content-Type", "text/csv")
+		fmt.Fprintf(w, "Id,Severity,Filter\n1,High,%s\n", filter)
+	default:
+		w.Write([]byte("%PDF-1.4 mock report"))
+	}
+}
+
+func (m *mockServer) handleFileUpload(w http.ResponseWriter, r *http.Request) {
+	f, _, err := r.FormFile("uploadedFile")
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidFile", err.Error())
+		return
+	}
+	content, err := io.ReadAll(f)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidFile", err.Error())
+		return
+	}
+	id, _ := uuid.GenerateUUID()
+	m.files[id] = content
+	writeJSON(w, http.StatusOK, map[string]interface{}{"FileId": id})
+}
+
+// handleCreateScan creates a scan whose single execution is already
+// complete, with one high severity issue, unless executionFailures says
+// otherwise.
+func (m *mockServer) handleCreateScan(technology string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, err := decodeBody(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidBody", err.Error())
+			return
+		}
+		for _, field := range []string{"ApplicationFileId", "ScanOrTemplateFileId", "LoginSequenceFileId"} {
+			if fileID, ok := body[field].(string); ok {
+				if _, ok := m.files[fileID]; !ok {
+					writeError(w, http.StatusBadRequest, "FileNotFound", "uploaded file not found")
+					return
+				}
+			}
+		}
+		scanID, _ := uuid.GenerateUUID()
+		scan := mockEntity{
+			"Id":                      scanID,
+			"Name":                    body["ScanName"],
+			"AppId":                   body["AppId"],
+			"Technology":              technology,
+			"IsPersonal":              body["Personal"] == true,
+			"EnableMailNotification":  body["EnableMailNotification"] == true,
+			"EnableMailNotifications": body["EnableMailNotification"] == true,
+			"CreatedAt":               time.Now().UTC().Format(time.RFC3339Nano),
+			"LatestExecution":         m.newExecution(technology, scanID),
+		}
+		if comment, ok := body["Comment"]; ok {
+			scan["LatestExecution"].(mockEntity)["Comment"] = comment
+		}
+		if _, ok := body["ScanOrTemplateFileId"]; ok {
+			// Scan files are not parsed: they all start from the same URL.
+			scan["ScanConfiguration"] = mockEntity{"StartingUrl": mockScanFileStartingURL}
+		}
+		if configuration, ok := body["ScanConfiguration"].(map[string]interface{}); ok {
+			c := mockEntity{}
+			if current, ok := scan["ScanConfiguration"].(mockEntity); ok {
+				c = current
+			}
+			if target, ok := configuration["Target"].(map[string]interface{}); ok {
+				c["StartingUrl"] = target["StartingUrl"]
+			}
+			if login, ok := configuration["Login"].(map[string]interface{}); ok {
+				c["LoginUser"] = login["UserName"]
+			}
+			scan["ScanConfiguration"] = c
+		}
+		if technology == "DynamicAnalyzer" {
+			switch {
+			case body["LoginSequenceFileId"] != nil:
+				scan["LoginConfigurationType"] = "LoginSequence"
+				scan["LoginSequenceFileId"] = body["LoginSequenceFileId"]
+			case scan["ScanConfiguration"] != nil && scan["ScanConfiguration"].(mockEntity)["LoginUser"] != nil:
+				scan["LoginConfigurationType"] = "AutomaticLogin"
+			default:
+				scan["LoginConfigurationType"] = "None"
+			}
+		}
+		m.collections["Scans"] = append(m.collections["Scans"], scan)
+		writeJSON(w, http.StatusCreated, scan)
+	}
+}
+
+// handleExecuteScan runs a scan again.
+func (m *mockServer) handleExecuteScan(w http.ResponseWriter, r *http.Request) {
+	scan := m.find("Scans", r.PathValue("id"))
+	if scan == nil {
+		writeError(w, http.StatusNotFound, "NotFound", "not found")
+		return
+	}
+	body, err := decodeBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidBody", err.Error())
+		return
+	}
+	if fileID, ok := body["FileId"].(string); ok {
+		if _, ok := m.files[fileID]; !ok {
+			writeError(w, http.StatusBadRequest, "FileNotFound", "uploaded file not found")
+			return
+		}
+	}
+	execution := m.newExecution(scan["Technology"].(string), scan["Id"].(string))
+	if comment, ok := body["Comment"]; ok {
+		execution["Comment"] = comment
+	}
+	scan["LatestExecution"] = execution
+	writeJSON(w, http.StatusCreated, execution)
+}
+
+// handlePromoteIssues publishes the issues of the latest execution of a
+// personal scan.
+func (m *mockServer) handlePromoteIssues(w http.ResponseWriter, r *http.Request) {
+	scan := m.find("Scans", r.PathValue("id"))
+	if scan == nil {
+		writeError(w, http.StatusNotFound, "NotFound", "not found")
+		return
+	}
+	if scan["IsPersonal"] != true {
+		writeError(w, http.StatusBadRequest, "ScanNotPersonal", "only the issues of personal scans can be promoted")
+		return
+	}
+	execution := scan["LatestExecution"].(mockEntity)
+	m.promotions = append(m.promotions, execution["Id"].(string))
+	w.WriteHeader(http.StatusOK)
+}
+
+// newExecution records a complete execution of a scan, or a failed one if
+// executionFailures is not empty.
+func (m *mockServer) newExecution(technology, scanID string) mockEntity {
+	execID, _ := uuid.GenerateUUID()
+	now := time.Now().UTC()
+	execution := mockEntity{
+		"Id":                   execID,
+		"ScanId":               scanID,
+		"Status":               "Ready",
+		"ExecutionProgress":    "Completed",
+		"NIssuesFound":         1,
+		"NHighIssues":          1,
+		"HasLogs":              true,
+		"IsScanFileAvailable":  technology == "DynamicAnalyzer",
+		"CreatedAt":            now.Add(-31 * time.Minute).Format(time.RFC3339Nano),
+		"ExecutedAt":           now.Add(-30 * time.Minute).Format(time.RFC3339Nano),
+		"ScanEndTime":          now.Format(time.RFC3339Nano),
+		"ExecutionDurationSec": 1800,
+		"QueuedDurationSec":    60,
+	}
+	switch technology {
+	case "DynamicAnalyzer":
+		execution["NVisitedPages"] = 120
+		execution["NUnvisitedPages"] = 8
+		execution["NEntities"] = 450
+		execution["NTestedEntities"] = 430
+		execution["NRequestsSent"] = 9000
+	case "StaticAnalyzer":
+		execution["NTotalLines"] = 25000
+		execution["Languages"] = []string{"Java", "JavaScript"}
+	}
+	if len(m.executionFailures) > 0 {
+		execution["Status"] = "Failed"
+		execution["ExecutionProgress"] = "Completed"
+		execution["UserMessage"] = m.executionFailures[0]
+		execution["NIssuesFound"] = 0
+		execution["NHighIssues"] = 0
+		m.executionFailures = m.executionFailures[1:]
+	}
+	m.collections["Executions"] = append(m.collections["Executions"], execution)
+	return execution
+}
+
+// handleCreateApiKey generates a key, revoking the previous one. The key the
+// tests log in with is left alone.
+func (m *mockServer) handleCreateApiKey(w http.ResponseWriter, r *http.Request) {
+	id, _ := uuid.GenerateUUID()
+	secret, _ := uuid.GenerateUUID()
+	key := mockEntity{"Id": id, "KeyId": id, "KeySecret": secret, "CreatedAt": time.Now().UTC().Format(time.RFC3339)}
+	m.collections["ApiKeys"] = []mockEntity{key}
+	writeJSON(w, http.StatusCreated, key)
+}
+
+// handleListAppPolicies lists the policies associated with an application,
+// kept in the PolicyAssociations collection with their AppId.
+func (m *mockServer) handleListAppPolicies(w http.ResponseWriter, r *http.Request) {
+	if m.find("Apps", r.PathValue("appId")) == nil {
+		writeError(w, http.StatusNotFound, "NotFound", "not found")
+		return
+	}
+	items := []mockEntity{}
+	for _, a := range m.collections["PolicyAssociations"] {
+		if strings.EqualFold(a["AppId"].(string), r.PathValue("appId")) {
+			items = append(items, a)
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"Items": items, "Count": len(items)})
+}
+
+// appPolicy returns the association of a policy with an application, nil
+// when the policy is not associated with it.
+func (m *mockServer) appPolicy(r *http.Request) mockEntity {
+	for _, a := range m.collections["PolicyAssociations"] {
+		if strings.EqualFold(a["AppId"].(string), r.PathValue("appId")) && strings.EqualFold(a["Id"].(string), r.PathValue("id")) {
+			return a
+		}
+	}
+	return nil
+}
+
+// handleAssociatePolicy associates a policy with an application, enabled,
+// with the NameValuePair parameters of the body.
+func (m *mockServer) handleAssociatePolicy(w http.ResponseWriter, r *http.Request) {
+	policy := m.find("Policies", r.PathValue("id"))
+	if policy == nil || m.find("Apps", r.PathValue("appId")) == nil {
+		writeError(w, http.StatusNotFound, "NotFound", "not found")
+		return
+	}
+	var parameters []mockEntity
+	if err := json.NewDecoder(r.Body).Decode(&parameters); err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidBody", err.Error())
+		return
+	}
+	association := m.appPolicy(r)
+	if association == nil {
+		association = mockEntity{"AppId": r.PathValue("appId"), "Id": policy["Id"], "Name": policy["Name"], "Category": policy["Category"]}
+		m.collections["PolicyAssociations"] = append(m.collections["PolicyAssociations"], association)
+	}
+	association["Enabled"] = true
+	association["Parameters"] = parameters
+	writeJSON(w, http.StatusOK, association)
+}
+
+// handleConfigurePolicy applies a PolicyConfigurationModel to an
+// association.
+func (m *mockServer) handleConfigurePolicy(w http.ResponseWriter, r *http.Request) {
+	association := m.appPolicy(r)
+	if association == nil {
+		writeError(w, http.StatusNotFound, "NotFound", "not found")
+		return
+	}
+	body, err := decodeBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidBody", err.Error())
+		return
+	}
+	association["Enabled"] = body["Enabled"]
+	association["Parameters"] = body["Parameters"]
+	writeJSON(w, http.StatusOK, association)
+}
+
+func (m *mockServer) handleDisassociatePolicy(w http.ResponseWriter, r *http.Request) {
+	items := m.collections["PolicyAssociations"]
+	for i, a := range items {
+		if strings.EqualFold(a["AppId"].(string), r.PathValue("appId")) && strings.EqualFold(a["Id"].(string), r.PathValue("id")) {
+			m.collections["PolicyAssociations"] = append(items[:i:i], items[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "NotFound", "not found")
+}
+
+// handleNewPresenceKey generates the key file of a presence, keeping it as
+// its Key.
+func (m *mockServer) handleNewPresenceKey(w http.ResponseWriter, r *http.Request) {
+	presence := m.find("Presences", r.PathValue("id"))
+	if presence == nil {
+		writeError(w, http.StatusNotFound, "NotFound", "not found")
+		return
+	}
+	secret, _ := uuid.GenerateUUID()
+	presence["Key"] = presence["Id"].(string) + ":" + secret
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Write([]byte(presence["Key"].(string)))
+}
+
+// handleSaveWebhook creates or updates a webhook, returning it as a
+// WebhookModel.
+func (m *mockServer) handleSaveWebhook(w http.ResponseWriter, r *http.Request) {
+	body, err := decodeBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidBody", err.Error())
+		return
+	}
+	webhook := mockEntity{}
+	if id := r.PathValue("id"); id != "" {
+		if webhook = m.find("Webhooks", id); webhook == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "not found")
+			return
+		}
+	} else {
+		webhook["Id"], _ = uuid.GenerateUUID()
+		webhook["Event"] = body["Event"]
+		if ag, ok := body["AssetGroupId"]; ok {
+			webhook["AssetGroup"] = mockEntity{"Id": ag}
+		}
+		m.collections["Webhooks"] = append(m.collections["Webhooks"], webhook)
+		m.created(webhook)
+	}
+	webhook["Uri"] = body["Uri"]
+	webhook["Global"] = body["Global"]
+	webhook["Presence"] = mockEntity{"Id": body["PresenceId"]}
+	writeJSON(w, http.StatusOK, webhook)
+}
+
+// handleWebhookAssociations lists, adds or removes the associations of a
+// webhook.
+func (m *mockServer) handleWebhookAssociations(w http.ResponseWriter, r *http.Request) {
+	webhook := m.find("Webhooks", r.PathValue("id"))
+	if webhook == nil {
+		writeError(w, http.StatusNotFound, "NotFound", "not found")
+		return
+	}
+	associations, _ := webhook["Associations"].([]mockEntity)
+	switch r.Method {
+	case "POST":
+		body, err := decodeBody(r)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, "InvalidBody", err.Error())
+			return
+		}
+		webhook["Associations"] = append(associations, body)
+		writeJSON(w, http.StatusOK, body)
+	case "DELETE":
+		query := r.URL.Query()
+		kept := []mockEntity{}
+		for _, a := range associations {
+			if a["Scope"] != query.Get("scope") || !strings.EqualFold(fmt.Sprint(a["ScopeId"]), query.Get("scopeId")) {
+				kept = append(kept, a)
+			}
+		}
+		webhook["Associations"] = kept
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		if associations == nil {
+			associations = []mockEntity{}
+		}
+		writeJSON(w, http.StatusOK, associations)
+	}
+}
+
+// handleSaveDomain allows a domain or updates it, returning an
+// AllowDomainResult or a DomainModel respectively. Allowed domains start
+// pending verification.
+func (m *mockServer) handleSaveDomain(w http.ResponseWriter, r *http.Request) {
+	body, err := decodeBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidBody", err.Error())
+		return
+	}
+	domain := mockEntity{}
+	id := r.PathValue("id")
+	if id != "" {
+		if domain = m.find("Domains", id); domain == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "not found")
+			return
+		}
+		domain["IncludeSubDomains"] = body["IncludeSubDomains"]
+		domain["Enabled"] = body["Enabled"]
+	} else {
+		next := 1
+		for _, e := range m.collections["Domains"] {
+			next = max(next, e["Id"].(int)+1)
+		}
+		domain = mockEntity{
+			"Id": next, "Domain": body["DomainUrl"], "UrlType": body["UrlType"],
+			"IncludeSubDomains": false, "Enabled": true, "Type": "Html", "Status": "Pending", "Key": "mock-verification-key",
+		}
+		m.collections["Domains"] = append(m.collections["Domains"], domain)
+	}
+	domain["Description"] = body["Description"]
+	assetGroups := []mockEntity{}
+	for _, ag := range body["AssetGroupIds"].([]interface{}) {
+		// Like the API, return the GUIDs in lower case.
+		assetGroups = append(assetGroups, mockEntity{"Id": strings.ToLower(ag.(string))})
+	}
+	domain["AssetGroups"] = assetGroups
+	if id != "" {
+		writeJSON(w, http.StatusOK, domain)
+		return
+	}
+	writeJSON(w, http.StatusOK, mockEntity{"Domain": domain, "Message": "NONE"})
+}
+
+// handleDeleteDomains deletes domains by ID, reporting unknown ones as
+// failed.
+func (m *mockServer) handleDeleteDomains(w http.ResponseWriter, r *http.Request) {
+	var ids []int
+	if err := json.NewDecoder(r.Body).Decode(&ids); err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidBody", err.Error())
+		return
+	}
+	deleted, failed := []mockEntity{}, []mockEntity{}
+	for _, id := range ids {
+		found := false
+		items := m.collections["Domains"]
+		for i, e := range items {
+			if e["Id"] == id {
+				m.collections["Domains"] = append(items[:i:i], items[i+1:]...)
+				found = true
+				break
+			}
+		}
+		if found {
+			deleted = append(deleted, mockEntity{"DomainId": id})
+		} else {
+			failed = append(failed, mockEntity{"DomainId": id, "Message": "not found"})
+		}
+	}
+	status := http.StatusOK
+	if len(failed) > 0 {
+		status = http.StatusMultiStatus
+	}
+	writeJSON(w, status, mockEntity{"Deleted": deleted, "Failed": failed})
+}
+
+// handleCreateCustomField defines a custom field for the organization.
+func (m *mockServer) handleCreateCustomField(w http.ResponseWriter, r *http.Request) {
+	if !strings.EqualFold(r.PathValue("orgId"), mockTenantID) {
+		writeError(w, http.StatusNotFound, "NotFound", "organization not found")
+		return
+	}
+	body, err := decodeBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidBody", err.Error())
+		return
+	}
+	body["Id"] = body["ColumnName"]
+	m.collections["CustomFields"] = append(m.collections["CustomFields"], body)
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleCustomFields lists the custom fields of the organization, or their
+// values for an application, which default to those of the definitions.
+func (m *mockServer) handleCustomFields(w http.ResponseWriter, r *http.Request) {
+	fields := m.collections["CustomFields"]
+	if len(fields) == 0 {
+		writeError(w, http.StatusNotFound, "NotFound", "no custom fields defined")
+		return
+	}
+	appID := r.PathValue("appId")
+	if appID == "" {
+		writeJSON(w, http.StatusOK, fields)
+		return
+	}
+	if m.find("Apps", appID) == nil {
+		writeError(w, http.StatusNotFound, "NotFound", "application not found")
+		return
+	}
+	values := m.find("AppCustomFields", appID)
+	list := []mockEntity{}
+	for _, f := range fields {
+		value, ok := values[f["ColumnName"].(string)]
+		if !ok {
+			value = f["DefaultValue"]
+		}
+		list = append(list, mockEntity{"ColumnName": f["ColumnName"], "Value": value})
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+// handleSetCustomField sets the value of a defined custom field of an
+// application.
+func (m *mockServer) handleSetCustomField(w http.ResponseWriter, r *http.Request) {
+	appID := r.PathValue("appId")
+	body, err := decodeBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidBody", err.Error())
+		return
+	}
+	name, _ := body["ColumnName"].(string)
+	if m.find("CustomFields", name) == nil {
+		writeError(w, http.StatusBadRequest, "InvalidCustomField", "custom field not found: "+name)
+		return
+	}
+	values := m.find("AppCustomFields", appID)
+	if values == nil {
+		values = mockEntity{"Id": appID}
+		m.collections["AppCustomFields"] = append(m.collections["AppCustomFields"], values)
+	}
+	values[name] = body["Value"]
+	w.WriteHeader(http.StatusOK)
+}
+
+func (m *mockServer) handleTenantInfo(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, m.collections["Tenants"][0])
+}
+
+// handleUpdateTenantInfo updates the settings of the TenantInfoModel.
+func (m *mockServer) handleUpdateTenantInfo(w http.ResponseWriter, r *http.Request) {
+	body, err := decodeBody(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "InvalidBody", err.Error())
+		return
+	}
+	tenant := m.collections["Tenants"][0]
+	for k, v := range body {
+		switch k {
+		case "TenantName", "ContactEmail", "SubscriptionTechnologies", "AutoDeleteExceededScansPerApp", "AllowAppManagerOverrideAutoDeleteSettings",
+			"EnableIssuesAutoClose", "EnableOverrideIssuesAutoClose", "ShowNonCompliantIssuesOnly":
+			tenant[k] = v
+		default:
+			writeError(w, http.StatusBadRequest, "InvalidBody", "unknown field "+k)
+			return
+		}
+	}
+	writeJSON(w, http.StatusOK, tenant)
+}
+
+// handleArtifact serves a downloadable file attached to an entity of
+// collection.
+func (m *mockServer) handleArtifact(collection string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if m.find(collection, r.PathValue("id")) == nil {
+			writeError(w, http.StatusNotFound, "NotFound", "not found")
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write([]byte("mock artifact content"))
+	}
+}
+
+// mockQuery applies $filter, $orderby, $top, $skip and $select to items. It also returns the
+// number of items matching the filter, as reported by $count.
+func mockQuery(items []mockEntity, r *http.Request) ([]mockEntity, int, error) {
+	query := r.URL.Query()
+	result := []mockEntity{}
+	for _, e := range items {
+		ok, err := mockMatch(e, query.Get("$filter"))
+		if err != nil {
+			return nil, 0, err
+		}
+		if ok {
+			result = append(result, e)
+		}
+	}
+	count := len(result)
+	if orderBy := query.Get("$orderby"); orderBy != "" {
+		keys := strings.Split(orderBy, ",")
+		// Values compare as strings, which suits dates; nulls come first.
+		value := func(e mockEntity, field string) string {
+			v, ok := mockField(e, field)
+			if !ok || v == nil {
+				return ""
+			}
+			return fmt.Sprint(v)
+		}
+		sort.SliceStable(result, func(i, j int) bool {
+			for _, key := range keys {
+				field, desc := strings.CutSuffix(key, " desc")
+				a, b := value(result[i], field), value(result[j], field)
+				if a != b {
+					return a < b != desc
+				}
+			}
+			return false
+		})
+	}
+	if skip, err := strconv.Atoi(query.Get("$skip")); err == nil {
+		if skip > len(result) {
+			skip = len(result)
+		}
+		result = result[skip:]
+	}
+	if top, err := strconv.Atoi(query.Get("$top")); err == nil && top < len(result) {
+		result = result[:top]
+	}
+	if fields := query.Get("$select"); fields != "" {
+		for i, e := range result {
+			selected := mockEntity{}
+			for _, f := range strings.Split(fields, ",") {
+				if v, ok := e[f]; ok {
+					selected[f] = v
+				}
+			}
+			result[i] = selected
+		}
+	}
+	return result, count, nil
+}
+
+var (
+	mockGUIDLiteralRegexp = regexp.MustCompile(`eq [0-9a-fA-F]{8}-`)
+	mockClauseRegexp      = regexp.MustCompile(`^([\w/]+) (eq|ne|ge|lt) ('(?:[^']|'')*'|[\w:.-]+)$`)
+	mockFunctionRegexp    = regexp.MustCompile(`^(contains|startswith)\(([\w/]+),'((?:[^']|'')*)'\)$`)
+)
+
+// mockMatch evaluates a filter made of `Field eq value` clauses joined by
+// `and`, each clause possibly being a parenthesized `or` group. Fields may be
+// paths such as LatestExecution/CreatedAt, and `ge` and `lt` compare values
+// as strings, which suits dates. Clauses may also be contains or startswith
+// calls, which ignore case.
+func mockMatch(e mockEntity, filter string) (bool, error) {
+	if filter == "" {
+		return true, nil
+	}
+	for _, group := range strings.Split(filter, " and ") {
+		if strings.HasPrefix(group, "(") {
+			group = strings.TrimSuffix(group[1:], ")")
+		}
+		matched := false
+		for _, clause := range strings.Split(group, " or ") {
+			if call := mockFunctionRegexp.FindStringSubmatch(clause); call != nil {
+				field, ok := mockField(e, call[2])
+				value := strings.ToLower(strings.ReplaceAll(call[3], "''", "'"))
+				text := strings.ToLower(fmt.Sprint(field))
+				if ok && (call[1] == "contains" && strings.Contains(text, value) || call[1] == "startswith" && strings.HasPrefix(text, value)) {
+					matched = true
+				}
+				continue
+			}
+			parts := mockClauseRegexp.FindStringSubmatch(clause)
+			if parts == nil {
+				return false, fmt.Errorf("unsupported filter clause: %s", clause)
+			}
+			value := parts[3]
+			if strings.HasPrefix(value, "'") {
+				value = strings.ReplaceAll(value[1:len(value)-1], "''", "'")
+			}
+			field, ok := mockField(e, parts[1])
+			switch {
+			case !ok:
+			case parts[2] == "eq" && strings.EqualFold(fmt.Sprint(field), value):
+				matched = true
+			case parts[2] == "ne" && !strings.EqualFold(fmt.Sprint(field), value):
+				matched = true
+			case parts[2] == "ge" && fmt.Sprint(field) >= value:
+				matched = true
+			case parts[2] == "lt" && fmt.Sprint(field) < value:
+				matched = true
+			}
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// mockField resolves a field path such as LatestExecution/CreatedAt.
+func mockField(e mockEntity, path string) (interface{}, bool) {
+	var v interface{} = e
+	for _, name := range strings.Split(path, "/") {
+		switch o := v.(type) {
+		case mockEntity:
+			v = o[name]
+		case map[string]interface{}:
+			v = o[name]
+		default:
+			return nil, false
+		}
+	}
+	return v, v != nil
+}
@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// The API only has email notification preferences at the scan level: the
+// users of the application are emailed when an execution of the scan
+// completes. There are no account-wide or per-issue preferences.
+
+func resourceAppScanNotificationSettings() *schema.Resource {
+	return &schema.Resource{
+		CreateWithoutTimeout: withContext(resourceAppScanNotificationSettingsCreate),
+		ReadWithoutTimeout:   withContext(resourceAppScanNotificationSettingsRead),
+		UpdateWithoutTimeout: withContext(resourceAppScanNotificationSettingsUpdate),
+		Delete:               resourceAppScanNotificationSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			State: importGUID,
+		},
+		Schema: map[string]*schema.Schema{
+			"scan_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The ID of the scan whose notifications are managed.",
+				ValidateFunc: validateGUID,
+			},
+			"email_on_scan_completion": {
+				Type:        schema.TypeBool,
+				Required:    true,
+				Description: "Whether an email is sent when an execution of the scan completes.",
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the scan.",
+			},
+		},
+	}
+}
+
+// appScanScanSettings holds the MinScanModel fields the notification
+// settings rely on.
+type appScanScanSettings struct {
+	Id                      string `json:"Id"`
+	Name                    string `json:"Name"`
+	FullyAutomatic          bool   `json:"FullyAutomatic"`
+	EnableMailNotifications *bool  `json:"EnableMailNotifications"`
+}
+
+func resourceAppScanNotificationSettingsCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	scanID := d.Get("scan_id").(string)
+	if err := updateNotificationSettings(ctx, d, m); err != nil {
+		return err
+	}
+	d.SetId(scanID)
+	return resourceAppScanNotificationSettingsRead(ctx, d, m)
+}
+
+func resourceAppScanNotificationSettingsRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	scan, err := getScanSettings(ctx, m.(*AppScanClient), d.Id())
+	if err != nil {
+		return err
+	}
+	if scan == nil {
+		d.SetId("")
+		return nil
+	}
+	d.Set("scan_id", scan.Id)
+	d.Set("email_on_scan_completion", scan.EnableMailNotifications != nil && *scan.EnableMailNotifications)
+	return nil
+}
+
+func resourceAppScanNotificationSettingsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if err := updateNotificationSettings(ctx, d, m); err != nil {
+		return err
+	}
+	return resourceAppScanNotificationSettingsRead(ctx, d, m)
+}
+
+// resourceAppScanNotificationSettingsDelete only forgets the settings: a
+// scan always has them, so the last applied ones are left in place.
+func resourceAppScanNotificationSettingsDelete(d *schema.ResourceData, m interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+// getScanSettings fetches the settings of a scan of any technology,
+// returning nil when it does not exist.
+func getScanSettings(ctx context.Context, client *AppScanClient, id string) (*appScanScanSettings, error) {
+	filterQuery, err := client.odataEqID(ctx, "Id", id)
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	query.Set("$filter", filterQuery)
+
+	var result struct {
+		Items []appScanScanSettings `json:"Items"`
+	}
+	if err := getODataPage(ctx, client, "Scans", query, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Items) == 0 {
+		return nil, nil
+	}
+	return &result.Items[0], nil
+}
+
+// updateNotificationSettings applies the configured preferences to the scan.
+func updateNotificationSettings(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	scanID := d.Get("scan_id").(string)
+
+	enabled := d.Get("email_on_scan_completion").(bool)
+	err := updateScanSettings(ctx, client, scanID, appScanScanSettingsUpdate{
+		EnableMailNotifications: &enabled,
+	})
+	if err != nil {
+		return err
+	}
+	client.Summary.record("notification_settings_updated", "appscan_notification_settings", scanID, map[string]string{
+		"email_on_scan_completion": fmt.Sprint(d.Get("email_on_scan_completion").(bool)),
+	})
+	return nil
+}
+
+// appScanScanSettingsUpdate is the UpdateDastScan payload updating the
+// settings of a scan of any technology. The settings omitted keep their
+// values.
+type appScanScanSettingsUpdate struct {
+	Name                    string `json:"Name,omitempty"`
+	EnableMailNotifications *bool  `json:"EnableMailNotifications,omitempty"`
+	// FullyAutomatic is not nullable, so it is sent back as is rather than
+	// reset by omission.
+	FullyAutomatic bool `json:"FullyAutomatic"`
+}
+
+// updateScanSettings sends settings to the scan of any technology.
+func updateScanSettings(ctx context.Context, client *AppScanClient, scanID string, settings appScanScanSettingsUpdate) error {
+	scan, err := getScanSettings(ctx, client, scanID)
+	if err != nil {
+		return err
+	}
+	if scan == nil {
+		return fmt.Errorf("no scan found with id: %s", scanID)
+	}
+
+	settings.FullyAutomatic = scan.FullyAutomatic
+	body, err := json.Marshal(settings)
+	if err != nil {
+		return err
+	}
+	urlStr := fmt.Sprintf("%s/Scans/%s", client.ApiBase, url.PathEscape(scanID))
+	req, err := client.newRequest(ctx, "PUT", urlStr, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+		return appscan.NewAPIError("update scan settings", resp)
+	}
+	return nil
+}
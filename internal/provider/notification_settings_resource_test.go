@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccNotificationSettingsResource(t *testing.T) {
+	m := newMockServer(t)
+	scan := m.add("Scans", mockEntity{"Name": "nightly", "AppId": mockApplicationID, "FullyAutomatic": true, "EnableMailNotifications": false})
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccNotificationSettingsConfig(m, scan["Id"].(string), true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_notification_settings.test", "email_on_scan_completion", "true"),
+					func(*terraform.State) error {
+						m.mu.Lock()
+						defer m.mu.Unlock()
+						if scan["FullyAutomatic"] != true {
+							return fmt.Errorf("FullyAutomatic was reset to %v", scan["FullyAutomatic"])
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config: testAccNotificationSettingsConfig(m, scan["Id"].(string), false),
+				Check:  resource.TestCheckResourceAttr("appscan_notification_settings.test", "email_on_scan_completion", "false"),
+			},
+			{
+				ResourceName:      "appscan_notification_settings.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccNotificationSettingsConfig(m *mockServer, scanID string, email bool) string {
+	return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_notification_settings" "test" {
+  scan_id                  = %q
+  email_on_scan_completion = %t
+}
+`, scanID, email)
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+)
+
+// getODataPage fetches a page of an OData collection into result.
+func getODataPage(ctx context.Context, client *AppScanClient, collection string, query url.Values, result interface{}) error {
+	return client.api().GetODataPage(ctx, collection, query, result)
+}
+
+// getEntity reads the entity at path, relative to the API base, into result.
+// It reports false when the entity does not exist, so that reads remove it
+// from the state, and returns the other errors, naming the entity what in
+// the message. Entities the API serves by ID are read this way rather than by
+// filtering their collection.
+func getEntity(ctx context.Context, client *AppScanClient, what, path string, result interface{}) (bool, error) {
+	return client.api().GetEntity(ctx, what, path, result)
+}
+
+// odataEqID builds a filter expression matching the GUID value of field,
+// quoting the GUID as a string when the API rejects GUID literals.
+func (c *AppScanClient) odataEqID(ctx context.Context, field, value string) (string, error) {
+	expr, err := appscan.ODataEqGUID(field, value)
+	if err != nil {
+		return "", err
+	}
+	literal, err := c.supports(ctx, capabilityGUIDLiteral)
+	if err != nil {
+		return "", err
+	}
+	if !literal {
+		return fmt.Sprintf("%s eq %s", field, appscan.ODataQuote(value)), nil
+	}
+	return expr, nil
+}
+
+// validateGUID checks at plan time that a string argument is a GUID, rather
+// than letting the API reject it with a 400 in the middle of an apply.
+func validateGUID(v interface{}, k string) ([]string, []error) {
+	value, ok := v.(string)
+	if !ok || !appscan.IsGUID(value) {
+		return nil, []error{fmt.Errorf("%q must be a GUID such as 11111111-2222-3333-4444-555555555555, got %q", k, v)}
+	}
+	return nil, nil
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ----------------------------------------------------------------
+// Data Source: appscan_odata_query (raw OData query, for collections the provider does not model)
+// ----------------------------------------------------------------
+
+// odataPathRegexp matches the collection paths appscan_odata_query accepts,
+// relative to the API base, e.g. Apps or Apps/{id}/Issues.
+var odataPathRegexp = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_-]*(/[A-Za-z0-9_-]+)*$`)
+
+func dataSourceODataQuery() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: withContext(dataSourceODataQueryRead),
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The path of the OData collection, relative to the API base (e.g. `Apps`, or `Apps/<id>/Issues`).",
+				ValidateFunc: validation.StringMatch(odataPathRegexp, "must be a path such as Apps or Apps/<id>/Issues, without leading slash or query"),
+			},
+			"filter": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The OData $filter expression, sent as is, e.g. `BusinessImpact eq 'High'`. Values are not escaped: quote them with `replace(value, \"'\", \"''\")` when they may contain single quotes.",
+			},
+			"select": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The fields to return ($select). By default, all of them.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"order_by": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The OData $orderby expression, e.g. `DateCreated desc`.",
+			},
+			"max_items": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1000,
+				ValidateFunc: validation.IntBetween(1, 100000),
+				Description:  "The maximum number of entries fetched, following the pages of the collection. Defaults to 1000.",
+			},
+			"result_json": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The entries as a JSON array, as returned by the API. Use jsondecode() to read them.",
+			},
+			"items": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The entries, each as a JSON object.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"total_count": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of entries matching the query, which may exceed the number fetched.",
+			},
+		},
+	}
+}
+
+func dataSourceODataQueryRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	path := d.Get("path").(string)
+	maxItems := d.Get("max_items").(int)
+
+	query := url.Values{}
+	if filter := d.Get("filter").(string); filter != "" {
+		query.Set("$filter", filter)
+	}
+	if fields := d.Get("select").([]interface{}); len(fields) > 0 {
+		names := make([]string, len(fields))
+		for i, f := range fields {
+			names[i], _ = f.(string)
+		}
+		query.Set("$select", strings.Join(names, ","))
+	}
+	if orderBy := d.Get("order_by").(string); orderBy != "" {
+		query.Set("$orderby", orderBy)
+	}
+	query.Set("$count", "true")
+
+	items := []json.RawMessage{}
+	count := 0
+	for skip := 0; len(items) < maxItems; skip += catalogPageSize {
+		top := min(catalogPageSize, maxItems-len(items))
+		query.Set("$top", strconv.Itoa(top))
+		query.Set("$skip", strconv.Itoa(skip))
+
+		var result struct {
+			Items []json.RawMessage `json:"Items"`
+			Count int               `json:"Count"`
+		}
+		if err := getODataPage(ctx, client, path, query, &result); err != nil {
+			return err
+		}
+		items = append(items, result.Items...)
+		count = result.Count
+		if len(result.Items) < top {
+			break
+		}
+	}
+	// Not every collection reports its count.
+	count = max(count, len(items))
+
+	resultJSON, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	list := make([]interface{}, len(items))
+	for i, item := range items {
+		list[i] = string(item)
+	}
+	d.Set("result_json", string(resultJSON))
+	if err := d.Set("items", list); err != nil {
+		return err
+	}
+	d.Set("total_count", count)
+
+	query.Del("$top")
+	query.Del("$skip")
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%s?%s&max=%d", path, query.Encode(), maxItems)))
+	d.SetId(hex.EncodeToString(sum[:]))
+	return nil
+}
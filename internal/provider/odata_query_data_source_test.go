@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccODataQueryDataSource(t *testing.T) {
+	m := newMockServer(t)
+	for i := 0; i < 3; i++ {
+		m.add("Apps", mockEntity{"Name": fmt.Sprintf("app-%d", i), "AssetGroupId": mockAssetGroupID, "BusinessImpact": "High"})
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + `
+data "appscan_odata_query" "test" {
+  path = "/api/v4/Apps?$top=1"
+}
+`,
+				ExpectError: regexp.MustCompile("must be a path such as Apps"),
+			},
+			{
+				Config: testAccProviderConfig(m) + `
+data "appscan_odata_query" "test" {
+  path      = "Apps"
+  filter    = "BusinessImpact eq 'High'"
+  select    = ["Id", "Name"]
+  max_items = 2
+}
+
+output "first" {
+  value = jsondecode(data.appscan_odata_query.test.result_json)[0].Name
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_odata_query.test", "total_count", "3"),
+					resource.TestCheckResourceAttr("data.appscan_odata_query.test", "items.#", "2"),
+					resource.TestCheckOutput("first", "app-0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// applicationModelFields are the fields of the ApplicationModel of the v4
+// API definition the provider was written against.
+var applicationModelFields = []string{
+	"Id", "Name", "RiskRating", "CriticalIssues", "HighIssues", "MediumIssues", "LowIssues",
+	"InformationalIssues", "IssuesInProgress", "MaxSeverity", "CorrelationState", "RR_MaxSeverity",
+	"AssetGroupId", "BusinessImpact", "Url", "Description", "BusinessUnit", "BusinessUnitId", "Type",
+	"Technology", "TestingStatus", "Hosts", "CollateralDamagePotential", "TargetDistribution",
+	"ConfidentialityRequirement", "IntegrityRequirement", "AvailabilityRequirement", "Tester",
+	"BusinessOwner", "DevelopmentContact", "PreferredOfferingType", "AssetGroupName", "DateCreated",
+	"LastUpdated", "LastComment", "CreatedBy", "NewIssues", "OpenIssues", "TotalIssues",
+	"OverallCompliance", "ComplianceStatuses", "CanBeDeleted", "LockedToSubscription", "TotalScans",
+	"NScanExecutions", "HasExceedingIssuesNumber", "HasExceedingScansNumber", "AutoDeleteExceededScans",
+	"Presences", "UseOnlyAppPresences", "AddedToAssetGroupBy", "AddedToAssetGroupAt", "ScanTechnologies",
+}
+
+// Values of the enumerations of the v4 API definition the provider was
+// written against.
+var (
+	businessImpacts       = []string{"Unspecified", "Low", "Medium", "High", "Critical"}
+	riskRatings           = []string{"Unknown", "Low", "Medium", "High", "Critical"}
+	maxSeverities         = []string{"Undetermined", "Informational", "Low", "Medium", "High", "Critical"}
+	scanExecutionStatuses = []string{"Running", "Stopping", "Pausing", "InQueue", "Paused", "Ready", "Failed"}
+	reportStatuses        = []string{"Pending", "Starting", "Running", "Failed", "Ready", "Deleted"}
+)
+
+// payloadWarnings checks an API payload against the model the provider was
+// written against. Fields the provider reads but the payload omits, and
+// fields the model does not define, are reported as warnings: the former
+// leave attributes at their zero value, the latter hint at API changes the
+// provider does not handle yet. Fields present with a null value are fine.
+func payloadWarnings(what string, payload map[string]interface{}, model, read []string) diag.Diagnostics {
+	var missing, unknown []string
+	for _, f := range read {
+		if _, ok := payload[f]; !ok {
+			missing = append(missing, f)
+		}
+	}
+	known := make(map[string]bool, len(model))
+	for _, f := range model {
+		known[f] = true
+	}
+	for f := range payload {
+		if !known[f] {
+			unknown = append(unknown, f)
+		}
+	}
+	sort.Strings(unknown)
+
+	var diags diag.Diagnostics
+	if len(missing) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Incomplete %s returned by the API", what),
+			Detail:   fmt.Sprintf("The API omitted %s; the corresponding attributes were set to empty values.", strings.Join(missing, ", ")),
+		})
+	}
+	if len(unknown) > 0 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("Unknown fields in %s returned by the API", what),
+			Detail:   fmt.Sprintf("The API returned fields the provider does not know: %s. A newer provider version may support them.", strings.Join(unknown, ", ")),
+		})
+	}
+	return diags
+}
+
+// checkEnum reports a value of an enumeration the provider does not know,
+// which the attribute is set to as is. It is a warning, or an error when
+// strict_mode is enabled, so that drift detection does not rely on values
+// whose meaning the provider cannot vouch for. Empty values are not checked.
+func (c *AppScanClient) checkEnum(what, field, value string, known []string) diag.Diagnostics {
+	if value == "" {
+		return nil
+	}
+	for _, k := range known {
+		if value == k {
+			return nil
+		}
+	}
+	severity := diag.Warning
+	if c.StrictMode {
+		severity = diag.Error
+	}
+	return diag.Diagnostics{{
+		Severity: severity,
+		Summary:  fmt.Sprintf("Unknown %s in %s returned by the API", field, what),
+		Detail:   fmt.Sprintf("The API returned %s %q, which is not one of %s. A newer provider version may support it.", field, value, strings.Join(known, ", ")),
+	}}
+}
+
+// validateEnum accepts the values of an enumeration, in any case. The API
+// has no endpoint listing the values it accepts, so other values are sent as
+// is with a warning rather than refused: the API may know values added since
+// the provider was written, and rejects the others when applying.
+func validateEnum(known []string) schema.SchemaValidateDiagFunc {
+	return func(v interface{}, path cty.Path) diag.Diagnostics {
+		value, ok := v.(string)
+		if !ok {
+			return diag.Diagnostics{{
+				Severity:      diag.Error,
+				Summary:       "Expected a string",
+				AttributePath: path,
+			}}
+		}
+		for _, k := range known {
+			if strings.EqualFold(value, k) {
+				return nil
+			}
+		}
+		return diag.Diagnostics{{
+			Severity:      diag.Warning,
+			Summary:       "Unknown value",
+			Detail:        fmt.Sprintf("%q is not one of %s, and is sent to the API as is. The API rejects it unless it was added after this provider version.", value, strings.Join(known, ", ")),
+			AttributePath: path,
+		}}
+	}
+}
+
+// diagnosticsError logs the warnings of diags and returns its first error,
+// for the operations returning a plain error.
+func diagnosticsError(diags diag.Diagnostics) error {
+	var err error
+	for _, d := range diags {
+		if d.Severity == diag.Error {
+			if err == nil {
+				err = fmt.Errorf("%s: %s", d.Summary, d.Detail)
+			}
+			continue
+		}
+		log.Printf("[WARN] %s: %s", d.Summary, d.Detail)
+	}
+	return err
+}
+
+// logDiagnostics logs warnings that an operation returning a plain error
+// cannot surface, e.g. those of the read following a create.
+func logDiagnostics(diags diag.Diagnostics) {
+	for _, d := range diags {
+		log.Printf("[WARN] %s: %s", d.Summary, d.Detail)
+	}
+}
+
+// jsonFields returns the fields a model struct decodes, by their JSON name,
+// i.e. the fields the provider reads from a payload decoded into it.
+func jsonFields(model interface{}) []string {
+	t := reflect.TypeOf(model)
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" {
+			name = f.Name
+		}
+		if name != "-" {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// stringField returns a string field of a payload, or "" when it is null,
+// missing or not a string.
+func stringField(payload map[string]interface{}, field string) string {
+	v, _ := payload[field].(string)
+	return v
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestPayloadWarnings(t *testing.T) {
+	payload := map[string]interface{}{
+		"Id":          "4444",
+		"Name":        "payments",
+		"Description": nil,
+		"Criticality": "High",
+		"Archived":    false,
+	}
+	diags := payloadWarnings("application 4444", payload, applicationModelFields, []string{"Name", "Description", "BusinessImpact"})
+	if len(diags) != 2 {
+		t.Fatalf("got %d diagnostics, want 2: %v", len(diags), diags)
+	}
+	if want := "The API omitted BusinessImpact; the corresponding attributes were set to empty values."; diags[0].Detail != want {
+		t.Errorf("missing fields: got %q, want %q", diags[0].Detail, want)
+	}
+	if want := "The API returned fields the provider does not know: Archived, Criticality. A newer provider version may support them."; diags[1].Detail != want {
+		t.Errorf("unknown fields: got %q, want %q", diags[1].Detail, want)
+	}
+	if diags.HasError() {
+		t.Error("payload warnings must not be errors")
+	}
+
+	if diags := payloadWarnings("application 4444", map[string]interface{}{"Name": nil}, applicationModelFields, []string{"Name"}); len(diags) != 0 {
+		t.Errorf("got %v for a complete payload, want none", diags)
+	}
+}
+
+func TestJSONFields(t *testing.T) {
+	got := jsonFields(struct {
+		Id       string `json:"Id"`
+		Name     string `json:"Name,omitempty"`
+		Skipped  string `json:"-"`
+		Untagged int
+		private  string
+	}{})
+	if want := []string{"Id", "Name", "Untagged"}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// A misspelt field would silently decode as its zero value.
+	for _, model := range []interface{}{appScanApplication{}, appScanApplicationRequest{}, appScanApplicationSummary{}, appScanApplicationMove{}} {
+		for _, field := range jsonFields(model) {
+			if !slices.Contains(applicationModelFields, field) {
+				t.Errorf("%T field %s is not in the ApplicationModel", model, field)
+			}
+		}
+	}
+}
+
+func TestScanRequestPayloads(t *testing.T) {
+	// The optional fields are omitted when empty, and the metadata is sent
+	// at the top level.
+	enabled := false
+	for _, c := range []struct {
+		payload  interface{}
+		expected string
+	}{
+		{
+			appScanDastScanRequest{AppId: "4444", ScanName: "nightly", Execute: true},
+			`{"AppId":"4444","ScanName":"nightly","Personal":false,"Execute":true}`,
+		},
+		{
+			appScanDastScanRequest{
+				AppId:               "4444",
+				ScanName:            "nightly",
+				ScanConfiguration:   &appScanDastScanConfiguration{Target: &appScanDastTarget{StartingUrl: "https://example.com"}},
+				appScanScanMetadata: appScanScanMetadata{EnableMailNotification: &enabled, Comment: "release"},
+			},
+			`{"AppId":"4444","ScanName":"nightly","Personal":false,"Execute":false,"ScanConfiguration":{"Target":{"StartingUrl":"https://example.com"}},"EnableMailNotification":false,"Comment":"release"}`,
+		},
+		{appScanExecutionRequest{}, `{}`},
+		{appScanScanSettingsUpdate{FullyAutomatic: true}, `{"FullyAutomatic":true}`},
+		{appScanIssueUpdate{Status: "Noise"}, `{"Status":"Noise"}`},
+	} {
+		body, err := json.Marshal(c.payload)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(body) != c.expected {
+			t.Errorf("%T: got %s, want %s", c.payload, body, c.expected)
+		}
+	}
+}
+
+func TestCheckEnum(t *testing.T) {
+	client := &AppScanClient{}
+	if diags := client.checkEnum("application 4444", "RiskRating", "High", riskRatings); len(diags) != 0 {
+		t.Errorf("got %v for a known value, want none", diags)
+	}
+	if diags := client.checkEnum("application 4444", "RiskRating", "", riskRatings); len(diags) != 0 {
+		t.Errorf("got %v for an empty value, want none", diags)
+	}
+
+	diags := client.checkEnum("application 4444", "RiskRating", "Extreme", riskRatings)
+	if len(diags) != 1 || diags.HasError() {
+		t.Fatalf("got %v for an unknown value, want one warning", diags)
+	}
+	if want := `The API returned RiskRating "Extreme", which is not one of Unknown, Low, Medium, High, Critical. A newer provider version may support it.`; diags[0].Detail != want {
+		t.Errorf("got %q, want %q", diags[0].Detail, want)
+	}
+	if err := diagnosticsError(diags); err != nil {
+		t.Errorf("got %v for a warning, want no error", err)
+	}
+
+	client.StrictMode = true
+	diags = client.checkEnum("application 4444", "RiskRating", "Extreme", riskRatings)
+	if !diags.HasError() {
+		t.Fatalf("got %v in strict mode, want an error", diags)
+	}
+	if err := diagnosticsError(diags); err == nil || err.Error() != "Unknown RiskRating in application 4444 returned by the API: "+diags[0].Detail {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestValidateEnum(t *testing.T) {
+	validate := validateEnum(businessImpacts)
+	path := cty.GetAttrPath("business_impact")
+	if diags := validate("high", path); len(diags) != 0 {
+		t.Errorf("got %v for a known value, want none", diags)
+	}
+
+	diags := validate("Severe", path)
+	if len(diags) != 1 || diags.HasError() {
+		t.Fatalf("got %v for an unknown value, want one warning", diags)
+	}
+	if want := `"Severe" is not one of Unspecified, Low, Medium, High, Critical, and is sent to the API as is. The API rejects it unless it was added after this provider version.`; diags[0].Detail != want {
+		t.Errorf("got %q, want %q", diags[0].Detail, want)
+	}
+	if !diags[0].AttributePath.Equals(path) {
+		t.Errorf("got path %v, want %v", diags[0].AttributePath, path)
+	}
+}
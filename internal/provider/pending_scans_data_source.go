@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ----------------------------------------------------------------
+// Data Source: appscan_pending_scans (scans stuck or failed for days, for cleanup)
+// ----------------------------------------------------------------
+
+// defaultPendingStatuses are the statuses of the latest execution of the
+// scans listed by default: failed, paused, or still queued.
+var defaultPendingStatuses = []string{"Failed", "Paused", "InQueue"}
+
+func dataSourcePendingScans() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"application_id": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "If set, only the scans of this application are listed.",
+			ValidateFunc: validation.Any(validation.StringIsEmpty, validateGUID),
+		},
+		"statuses": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Description: "The statuses of the latest execution of the scans listed. Allowed values: Running, Stopping, Pausing, InQueue, Paused, Ready, Failed. Defaults to Failed, Paused and InQueue.",
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice(scanExecutionStatuses, false),
+			},
+		},
+		"older_than_days": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      7,
+			Description:  "Only the scans whose latest execution was requested more than this many days ago are listed. Defaults to 7.",
+			ValidateFunc: validation.IntAtLeast(0),
+		},
+		"scans": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The scans, the oldest first.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The ID of the scan.",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the scan.",
+					},
+					"application_id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The ID of the application of the scan.",
+					},
+					"technology": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The technology of the scan.",
+					},
+					"latest_execution_id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The ID of the latest execution of the scan.",
+					},
+					"status": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The status of the latest execution.",
+					},
+					"user_message": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The message of the latest execution, e.g. why it failed.",
+					},
+					"created_at": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The date the latest execution was requested.",
+					},
+				},
+			},
+		},
+		"ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The IDs of the scans, e.g. to feed a cleanup script.",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+	}
+	for k, v := range listQuerySchema() {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		ReadWithoutTimeout: withContext(dataSourcePendingScansRead),
+		Description: "Lists the scans whose latest execution failed, is paused or is still queued since a given number of days, " +
+			"so that their cleanup can be automated instead of done by hand in the console.",
+		Schema: s,
+	}
+}
+
+func dataSourcePendingScansRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	var clauses []string
+	appID := d.Get("application_id").(string)
+	if appID != "" {
+		expr, err := client.odataEqID(ctx, "AppId", appID)
+		if err != nil {
+			return err
+		}
+		clauses = append(clauses, expr)
+	}
+	statuses := defaultPendingStatuses
+	if v := d.Get("statuses").([]interface{}); len(v) > 0 {
+		statuses = nil
+		for _, status := range v {
+			statuses = append(statuses, status.(string))
+		}
+	}
+	var exprs []string
+	for _, status := range statuses {
+		expr, err := appscan.ODataEqString("LatestExecution/Status", status)
+		if err != nil {
+			return err
+		}
+		exprs = append(exprs, expr)
+	}
+	cutoff := time.Now().UTC().AddDate(0, 0, -d.Get("older_than_days").(int))
+	clauses = append(clauses, appscan.ODataOr(exprs), "LatestExecution/CreatedAt lt "+cutoff.Format(time.RFC3339))
+
+	query := listQueryFor(d).values(appscan.ODataAnd(clauses...), "LatestExecution/CreatedAt", "Id", "LatestExecution")
+	var scans []appScanScan
+	for skip := 0; ; skip += catalogPageSize {
+		query.Set("$top", strconv.Itoa(catalogPageSize))
+		query.Set("$skip", strconv.Itoa(skip))
+
+		var result struct {
+			Items []appScanScan `json:"Items"`
+		}
+		if err := getODataPage(ctx, client, "Scans", query, &result); err != nil {
+			return err
+		}
+		scans = append(scans, result.Items...)
+		if len(result.Items) < catalogPageSize {
+			break
+		}
+	}
+
+	list := make([]interface{}, 0, len(scans))
+	ids := make([]string, 0, len(scans))
+	for _, scan := range scans {
+		item := map[string]interface{}{
+			"id":             scan.Id,
+			"name":           scan.Name,
+			"application_id": scan.AppId,
+			"technology":     scan.Technology,
+		}
+		if exec := scan.LatestExecution; exec != nil {
+			item["latest_execution_id"] = exec.Id
+			item["status"] = exec.Status
+			item["user_message"] = exec.UserMessage
+			item["created_at"] = exec.CreatedAt
+		}
+		list = append(list, item)
+		ids = append(ids, scan.Id)
+	}
+	if err := d.Set("scans", list); err != nil {
+		return err
+	}
+	if err := d.Set("ids", ids); err != nil {
+		return err
+	}
+
+	if appID != "" {
+		d.SetId(appID)
+	} else {
+		d.SetId(client.ApiEndpoint)
+	}
+	return nil
+}
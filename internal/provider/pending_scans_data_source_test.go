@@ -0,0 +1,59 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccPendingScansDataSource(t *testing.T) {
+	m := newMockServer(t)
+	daysAgo := func(n int) string {
+		return time.Now().UTC().AddDate(0, 0, -n).Format(time.RFC3339)
+	}
+	failed := m.add("Scans", mockEntity{"Name": "failed", "AppId": mockApplicationID, "Technology": "DynamicAnalyzer",
+		"LatestExecution": mockEntity{"Id": "99999999-9999-9999-9999-999999999991", "Status": "Failed", "UserMessage": "Login failed", "CreatedAt": daysAgo(10)}})
+	queued := m.add("Scans", mockEntity{"Name": "queued", "AppId": mockApplicationID, "Technology": "StaticAnalyzer",
+		"LatestExecution": mockEntity{"Status": "InQueue", "CreatedAt": daysAgo(30)}})
+	// Too recent, complete, or of another application.
+	m.add("Scans", mockEntity{"Name": "recent", "AppId": mockApplicationID, "LatestExecution": mockEntity{"Status": "Failed", "CreatedAt": daysAgo(1)}})
+	m.add("Scans", mockEntity{"Name": "ready", "AppId": mockApplicationID, "LatestExecution": mockEntity{"Status": "Ready", "CreatedAt": daysAgo(10)}})
+	m.add("Scans", mockEntity{"Name": "other", "AppId": "44444444-4444-4444-4444-444444444445", "LatestExecution": mockEntity{"Status": "Paused", "CreatedAt": daysAgo(10)}})
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + fmt.Sprintf(`
+data "appscan_pending_scans" "test" {
+  application_id = %q
+}
+`, mockApplicationID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_pending_scans.test", "scans.#", "2"),
+					// The oldest first.
+					resource.TestCheckResourceAttr("data.appscan_pending_scans.test", "ids.0", queued["Id"].(string)),
+					resource.TestCheckResourceAttr("data.appscan_pending_scans.test", "scans.0.status", "InQueue"),
+					resource.TestCheckResourceAttr("data.appscan_pending_scans.test", "scans.0.technology", "StaticAnalyzer"),
+					resource.TestCheckResourceAttr("data.appscan_pending_scans.test", "scans.1.id", failed["Id"].(string)),
+					resource.TestCheckResourceAttr("data.appscan_pending_scans.test", "scans.1.latest_execution_id", "99999999-9999-9999-9999-999999999991"),
+					resource.TestCheckResourceAttr("data.appscan_pending_scans.test", "scans.1.user_message", "Login failed"),
+				),
+			},
+			{
+				Config: testAccProviderConfig(m) + `
+data "appscan_pending_scans" "test" {
+  statuses        = ["Failed", "Paused"]
+  older_than_days = 0
+}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_pending_scans.test", "scans.#", "3"),
+					resource.TestCheckResourceAttr("data.appscan_pending_scans.test", "scans.2.name", "recent"),
+				),
+			},
+		},
+	})
+}
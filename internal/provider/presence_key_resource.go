@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ----------------------------------------------------------------
+// Resource: appscan_presence_key (key file of an AppScan Presence, rotated on demand)
+// ----------------------------------------------------------------
+
+// Like API keys, presence keys can only be regenerated, which invalidates
+// the previous one, and cannot be read back. appscan_presence_key keeps the
+// generated key in the state and rotates it by replacement, so that the
+// deployments of the presence can be replaced along with it.
+
+func resourceAppScanPresenceKey() *schema.Resource {
+	return &schema.Resource{
+		CreateWithoutTimeout: withContext(resourceAppScanPresenceKeyCreate),
+		ReadWithoutTimeout:   withContext(resourceAppScanPresenceKeyRead),
+		Delete:               resourceAppScanPresenceKeyDelete,
+		Description: "Generates a new key file for an AppScan Presence, which invalidates its previous key: the presence stops connecting until it is redeployed with the new key. " +
+			"Reference key from the deployment of the presence, or list this resource in its replace_triggered_by, so that rotating the key redeploys it. " +
+			"Destroying the resource only removes the key from the state.",
+		Schema: map[string]*schema.Schema{
+			"presence_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The ID of the AppScan Presence.",
+				ValidateFunc: validateGUID,
+			},
+			"rotation_triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary values that, when changed, generate a new key, e.g. a date to rotate the key on a schedule.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"key": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The content of the key file, to be saved as the presence.key file of the presence.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the key was generated.",
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "A random identifier of the key, which changes on each rotation.",
+			},
+		},
+	}
+}
+
+func resourceAppScanPresenceKeyCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	presenceID := d.Get("presence_id").(string)
+
+	urlStr := fmt.Sprintf("%s/Presences/%s/NewKey", client.ApiBase, url.PathEscape(presenceID))
+	req, err := client.newRequest(ctx, "GET", urlStr, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return appscan.NewAPIError("generate presence key", resp)
+	}
+
+	key, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if len(key) == 0 {
+		return fmt.Errorf("failed to retrieve the presence key from API response")
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		return err
+	}
+	d.SetId(id)
+	d.Set("key", string(key))
+	d.Set("created_at", time.Now().UTC().Format(time.RFC3339))
+	client.Summary.record("presence_key_generated", "appscan_presence_key", id, map[string]string{
+		"presence_id": presenceID,
+	})
+	return nil
+}
+
+// resourceAppScanPresenceKeyRead keeps the key as is, since the API cannot
+// read it back, but drops it when the presence no longer exists.
+func resourceAppScanPresenceKeyRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	presenceID := d.Get("presence_id").(string)
+
+	filterQuery, err := client.odataEqID(ctx, "Id", presenceID)
+	if err != nil {
+		return err
+	}
+	query := url.Values{}
+	query.Set("$filter", filterQuery)
+	query.Set("$select", "Id")
+	var result struct {
+		Items []struct {
+			Id string `json:"Id"`
+		} `json:"Items"`
+	}
+	if err := getODataPage(ctx, client, "Presences", query, &result); err != nil {
+		return err
+	}
+	if len(result.Items) == 0 {
+		d.SetId("")
+	}
+	return nil
+}
+
+// resourceAppScanPresenceKeyDelete only removes the key from the state: the
+// key stays valid until a new one is generated.
+func resourceAppScanPresenceKeyDelete(d *schema.ResourceData, m interface{}) error {
+	d.SetId("")
+	return nil
+}
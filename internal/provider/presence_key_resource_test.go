@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccPresenceKeyResource(t *testing.T) {
+	m := newMockServer(t)
+	m.add("Presences", mockEntity{"Id": mockPresenceID, "Name": "dmz"})
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccPresenceKeyConfig(m, "2026-Q1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMockPresenceKey(m, "appscan_presence_key.test"),
+					resource.TestCheckResourceAttrSet("appscan_presence_key.test", "created_at"),
+					testAccSaveID("appscan_presence_key.test", &id),
+				),
+			},
+			{
+				Config: testAccPresenceKeyConfig(m, "2026-Q2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckMockPresenceKey(m, "appscan_presence_key.test"),
+					testAccCheckID("appscan_presence_key.test", &id, false),
+				),
+			},
+			{
+				// A deleted presence takes its key along.
+				PreConfig: func() {
+					m.mu.Lock()
+					defer m.mu.Unlock()
+					m.collections["Presences"] = nil
+				},
+				Config:             testAccPresenceKeyConfig(m, "2026-Q2"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func testAccPresenceKeyConfig(m *mockServer, quarter string) string {
+	return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_presence_key" "test" {
+  presence_id = %q
+  rotation_triggers = {
+    quarter = %q
+  }
+}
+`, mockPresenceID, quarter)
+}
+
+// testAccCheckMockPresenceKey checks the resource holds the last key the
+// mock server generated for the presence.
+func testAccCheckMockPresenceKey(m *mockServer, name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		presence := m.find("Presences", mockPresenceID)
+		return resource.TestCheckResourceAttr(name, "key", presence["Key"].(string))(s)
+	}
+}
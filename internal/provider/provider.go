@@ -1,111 +1,573 @@
 package provider
 
 import (
-	"bytes"
-	"encoding/json"
+	"context"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
 
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // AppScanClient holds configuration for API communication.
 type AppScanClient struct {
 	ApiEndpoint string
-	ApiToken    string
-	Client      *http.Client
+	// ApiBase is the URL API paths are relative to, e.g.
+	// https://cloud.appscan.com/api/v4.
+	ApiBase         string
+	ApiToken        string
+	AcceptLanguage  string
+	UploadChunkSize int64
+	PollInterval    time.Duration
+	MaxWait         time.Duration
+	MaxRetries      int
+	Client          *http.Client
+	Summary         *runSummary
+	// Metrics counts the API calls of the run, reported by ReportMetrics.
+	Metrics *requestMetrics
+	// AutoTags are the custom attributes set on every application created.
+	AutoTags map[string]string
+	// StrictMode turns the values of enumerations the provider does not
+	// know into errors rather than warnings.
+	StrictMode bool
+	// DefaultAssetGroupId and DefaultBusinessUnitId apply to the resources
+	// that omit their asset_group_id or business_unit_id.
+	DefaultAssetGroupId   string
+	DefaultBusinessUnitId string
+	// LogDrift reports the arguments of the resources refreshed that
+	// changed outside Terraform.
+	LogDrift bool
+	// ApiTokenExpiry is when ApiToken expires, as returned by ApiKeyLogin;
+	// empty when the provider is configured with a bearer_token.
+	ApiTokenExpiry string
+
+	capabilities apiCapabilities
+	lookups      lookupCache
 }
 
-// providerConfigure authenticates via /api/v4/Account/ApiKeyLogin using key_id and key_secret.
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
-	endpoint := d.Get("api_endpoint").(string)
-	keyID := d.Get("key_id").(string)
-	keySecret := d.Get("key_secret").(string)
+// Version is the version of the provider, reported in the User-Agent of the
+// API calls. It is set by main.
+var Version = "dev"
 
-	// Construct payload for API key login.
-	payload := map[string]string{
-		"KeyId":     keyID,
-		"KeySecret": keySecret,
+// newRequest builds an API request. The bearer token and the headers every
+// call shares are added by the apiTransport of c.Client.
+// The request is cancelled with ctx, the context of the CRUD function.
+func (c *AppScanClient) newRequest(ctx context.Context, method, url string, body io.Reader) (*http.Request, error) {
+	return http.NewRequestWithContext(ctx, method, url, body)
+}
+
+// api returns the SDK client of c. It shares the HTTP client of c, so its
+// requests are authenticated, retried and counted as the others.
+func (c *AppScanClient) api() *appscan.Client {
+	return &appscan.Client{
+		BaseURL:     c.ApiBase,
+		HTTPClient:  c.Client,
+		Token:       c.ApiToken,
+		TokenExpiry: c.ApiTokenExpiry,
 	}
-	body, err := json.Marshal(payload)
-	if err != nil {
-		return nil, err
+}
+
+// providerConfigure returns the ConfigureContextFunc of p, which needs p to
+// build the User-Agent from the Terraform version.
+func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		client, err := configureClient(ctx, d, p.UserAgent("terraform-provider-appscan", Version))
+		if err != nil {
+			return nil, configureDiagnostics(err)
+		}
+		return client, nil
 	}
+}
 
-	loginURL := fmt.Sprintf("%s/api/v4/Account/ApiKeyLogin", endpoint)
-	req, err := http.NewRequest("POST", loginURL, bytes.NewBuffer(body))
+// configureClient builds the API client. It authenticates via
+// Account/ApiKeyLogin using key_id and key_secret, unless a
+// bearer_token obtained beforehand, or a token_exchange_url trading the OIDC
+// token of the CI job for one, is configured.
+func configureClient(ctx context.Context, d *schema.ResourceData, userAgent string) (*AppScanClient, error) {
+	endpoint, err := configuredEndpoint(d)
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Set("Content-Type", "application/json")
+	keyID := d.Get("key_id").(string)
+	keySecret := d.Get("key_secret").(string)
+	bearerToken := d.Get("bearer_token").(string)
+	exchangeURL := d.Get("token_exchange_url").(string)
+	acceptLanguage := d.Get("accept_language").(string)
+	apiBase := endpoint + d.Get("api_path_prefix").(string) + "/" + d.Get("api_version").(string)
 
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	transport, err := newHTTPTransport(d)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	base, err := url.Parse(apiBase)
+	if err != nil {
+		return nil, fmt.Errorf("invalid api_endpoint: %w", err)
+	}
+	metrics := newRequestMetrics(base.Path, d.Get("metrics_file").(string), d.Get("metrics_pushgateway_url").(string),
+		&http.Client{Transport: transport, Timeout: metricsPushTimeout})
+	var roundTripper http.RoundTripper = &metricsTransport{transport: transport, metrics: metrics}
+	var debug *debugTransport
+	if d.Get("debug_http").(bool) {
+		debug = newDebugTransport(ctx, roundTripper)
+		roundTripper = debug
+	}
+	rateLimit := newRateLimitTransport(roundTripper, d.Get("requests_per_second").(float64), d.Get("max_retries").(int))
+	rateLimit.metrics = metrics
+	if suffix := d.Get("user_agent_suffix").(string); suffix != "" {
+		userAgent += " " + suffix
+	}
+	extraHeaders := map[string]string{}
+	for k, v := range d.Get("extra_headers").(map[string]interface{}) {
+		extraHeaders[k] = v.(string)
+		if debug != nil {
+			debug.mask(v.(string))
+		}
+	}
+	api := &apiTransport{
+		transport:      rateLimit,
+		host:           base.Host,
+		userAgent:      userAgent,
+		acceptLanguage: acceptLanguage,
+		headers:        extraHeaders,
+	}
+	client := &http.Client{Transport: api}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to authenticate via API key, status: %s", resp.Status)
+	// An explicit key_secret_command wins over APPSCAN_KEY_SECRET.
+	if command := d.Get("key_secret_command").([]interface{}); len(command) > 0 {
+		argv := make([]string, len(command))
+		for i, v := range command {
+			argv[i], _ = v.(string)
+		}
+		if keySecret, err = runSecretCommand(ctx, argv); err != nil {
+			return nil, err
+		}
 	}
 
-	respBody, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
+	if debug != nil {
+		debug.mask(keySecret, bearerToken)
 	}
 
-	// The login endpoint now returns a "Token" field.
-	var authResp struct {
-		Token string `json:"Token"`
+	// key_id and key_secret may come from the environment, which the
+	// ConflictsWith validation of the schema does not see.
+	token, tokenExpiry := bearerToken, ""
+	credentials := []string{"bearer_token"}
+	switch {
+	case exchangeURL != "" && (bearerToken != "" || keyID != "" || keySecret != ""):
+		return nil, fmt.Errorf("token_exchange_url, bearer_token and key_id/key_secret are mutually exclusive")
+	case exchangeURL != "":
+		credentials = []string{"token_exchange_url"}
+		oidcToken, err := ciOIDCToken(client, d.Get("oidc_token").(string), d.Get("oidc_audience").(string))
+		if err != nil {
+			return nil, err
+		}
+		if debug != nil {
+			debug.mask(oidcToken)
+		}
+		token, tokenExpiry, err = exchangeToken(client, exchangeURL, oidcToken)
+		if err != nil {
+			return nil, err
+		}
+		if debug != nil {
+			debug.mask(token)
+		}
+	case bearerToken != "" && (keyID != "" || keySecret != ""):
+		return nil, fmt.Errorf("bearer_token and key_id/key_secret are mutually exclusive")
+	case bearerToken == "" && keyID != "" && keySecret == "":
+		return nil, fmt.Errorf("key_id requires key_secret or key_secret_command (or APPSCAN_KEY_SECRET)")
+	case bearerToken == "" && keyID == "" && keySecret != "":
+		return nil, fmt.Errorf("key_secret requires key_id (or APPSCAN_KEY_ID)")
+	case bearerToken == "" && (keyID == "" || keySecret == ""):
+		return nil, fmt.Errorf("either key_id and key_secret (or key_secret_command), bearer_token or token_exchange_url must be configured")
+	case bearerToken == "":
+		credentials = []string{"key_id", "key_secret"}
+		if err := checkCredentialsFormat(keyID, keySecret); err != nil {
+			return nil, err
+		}
+		login := func() (string, string, error) {
+			return appscan.APIKeyLogin(ctx, client, apiBase, keyID, keySecret)
+		}
+		if dir := d.Get("token_cache_dir").(string); dir != "" {
+			token, tokenExpiry, err = (&tokenCache{dir: dir}).get(apiBase, keyID, keySecret, login)
+		} else {
+			token, tokenExpiry, err = login()
+		}
+		if err != nil {
+			return nil, diagnoseRequestError(endpoint, credentials, err)
+		}
+		if debug != nil {
+			debug.mask(token)
+		}
 	}
-	if err := json.Unmarshal(respBody, &authResp); err != nil {
-		return nil, err
+	api.token = token
+
+	summaryRunID := d.Get("summary_run_id").(string)
+	if summaryRunID == "" {
+		summaryRunID = defaultSummaryRunID()
+	}
+
+	autoTags := map[string]string{}
+	for k, v := range d.Get("auto_tags").(map[string]interface{}) {
+		autoTags[k] = v.(string)
+	}
+
+	// Both durations passed validatePositiveDuration.
+	pollInterval, _ := time.ParseDuration(d.Get("poll_interval").(string))
+	maxWait, _ := time.ParseDuration(d.Get("max_wait").(string))
+
+	c := &AppScanClient{
+		ApiEndpoint:     endpoint,
+		ApiBase:         apiBase,
+		ApiToken:        token,
+		ApiTokenExpiry:  tokenExpiry,
+		AcceptLanguage:  acceptLanguage,
+		UploadChunkSize: int64(d.Get("upload_chunk_size_mb").(int)) << 20,
+		PollInterval:    pollInterval,
+		MaxWait:         maxWait,
+		MaxRetries:      d.Get("max_retries").(int),
+		StrictMode:      d.Get("strict_mode").(bool),
+		LogDrift:        d.Get("log_drift").(bool),
+		Client:          client,
+		Summary:         newRunSummary(d.Get("summary_file").(string), summaryRunID),
+		Metrics:         metrics,
+		AutoTags:        autoTags,
+
+		DefaultAssetGroupId:   d.Get("default_asset_group_id").(string),
+		DefaultBusinessUnitId: d.Get("default_business_unit_id").(string),
+	}
+	if d.Get("check_connection").(bool) {
+		if err := pingAPI(ctx, c); err != nil {
+			return nil, diagnoseRequestError(endpoint, credentials, err)
+		}
 	}
-	if authResp.Token == "" {
-		return nil, fmt.Errorf("failed to obtain token from API key login response")
+	if tenantID := d.Get("tenant_id").(string); tenantID != "" {
+		if err := checkTenantID(ctx, c, tenantID); err != nil {
+			return nil, err
+		}
 	}
+	return c, nil
+}
 
-	return &AppScanClient{
-		ApiEndpoint: endpoint,
-		ApiToken:    authResp.Token,
-		Client:      client,
-	}, nil
+// checkTenantID fails unless the credentials of c belong to the tenant
+// tenantID, so that a provider configuration, typically one alias per
+// tenant, never manages another tenant because it was given the wrong key.
+func checkTenantID(ctx context.Context, c *AppScanClient, tenantID string) error {
+	tenant, _, message := checkTenant(ctx, c)
+	if tenant == nil {
+		return fmt.Errorf("cannot check tenant_id: %s", message)
+	}
+	if !strings.EqualFold(tenant.TenantId, tenantID) {
+		return fmt.Errorf("the credentials belong to tenant %s (%s), not to tenant_id %s", tenant.TenantId, tenant.TenantName, tenantID)
+	}
+	return nil
 }
 
 // Provider returns the Terraform provider for AppScan.
 func Provider() *schema.Provider {
-	return &schema.Provider{
+	p := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"api_endpoint": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				DefaultFunc: schema.EnvDefaultFunc("APPSCAN_API_ENDPOINT", "https://cloud.appscan.com/"),
-				Description: "The API endpoint for the AppScan REST API.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("APPSCAN_API_ENDPOINT", "https://cloud.appscan.com/"),
+				ValidateFunc: validateEndpoint,
+				Description:  "The API endpoint for the AppScan REST API: an http or https URL, trailing slashes being ignored, or the region of AppScan on Cloud, us (https://cloud.appscan.com) or eu (https://eu.cloud.appscan.com). Defaults to https://cloud.appscan.com/, or to the endpoint of region when it is set.",
+			},
+			"region": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("APPSCAN_REGION", ""),
+				ValidateFunc: validation.Any(validation.StringIsEmpty, validation.StringInSlice(appscan.RegionNames(), true)),
+				Description:  "The data center of AppScan on Cloud the account lives in, setting the API endpoint and the API key login host: us (https://cloud.appscan.com) or eu (https://eu.cloud.appscan.com), for EU data residency. Conflicts with api_endpoint in the configuration. Can also be set with the APPSCAN_REGION environment variable, which an api_endpoint set in the configuration, e.g. for AppScan 360 or a proxy, wins over.",
+			},
+			"api_version": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("APPSCAN_API_VERSION", "v4"),
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^v[0-9]+$`), "must be a version such as v4"),
+				Description:  "The version of the REST API, e.g. v2 for older regional instances. The provider is written against v4; with other versions, list responses in the `value` shape of OData are handled too, but endpoints missing from the version fail. Defaults to v4.",
+			},
+			"api_path_prefix": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "/api",
+				ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(/[A-Za-z0-9._~-]+)*$`), "must be a path such as /api, without trailing slash"),
+				Description:  "The path of the REST API under api_endpoint, followed by api_version, e.g. /appscan/api behind a reverse proxy. Defaults to /api.",
 			},
 			"key_id": {
-				Type:        schema.TypeString,
-				Required:    true,
-				DefaultFunc: schema.EnvDefaultFunc("APPSCAN_KEY_ID", nil),
-				Description: "The API Key ID for authentication.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("APPSCAN_KEY_ID", nil),
+				ConflictsWith: []string{"bearer_token"},
+				Description:   "The API Key ID for authentication. Required unless bearer_token is set.",
 			},
 			"key_secret": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("APPSCAN_KEY_SECRET", nil),
+				ConflictsWith: []string{"bearer_token"},
+				Description:   "The API Key Secret for authentication. Required unless bearer_token or key_secret_command is set.",
+				Sensitive:     true,
+			},
+			"key_secret_command": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"key_secret", "bearer_token"},
+				Description:   "A program and its arguments printing the API Key Secret on its standard output, e.g. `[\"op\", \"read\", \"op://ci/appscan/secret\"]`, so the secret is fetched from a password manager or vault CLI rather than written to variables. The program is run without a shell and must complete within two minutes.",
+				Elem:          &schema.Schema{Type: schema.TypeString},
+			},
+			"token_cache_dir": {
 				Type:        schema.TypeString,
-				Required:    true,
-				DefaultFunc: schema.EnvDefaultFunc("APPSCAN_KEY_SECRET", nil),
-				Description: "The API Key Secret for authentication.",
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("APPSCAN_TOKEN_CACHE_DIR", nil),
+				Description: "A directory where the access tokens of key_id logins are stored, readable by their owner only, e.g. `.terraform/appscan-tokens`. Terraform runs each provider configuration, and the plan and the apply, in a separate process: with a token cache, the configurations using the same key, and the apply after the plan, reuse the token of a single login until 10 minutes before it expires. Can also be set with the APPSCAN_TOKEN_CACHE_DIR environment variable. Defaults to no cache, each configuration logging in.",
+			},
+			"bearer_token": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("APPSCAN_BEARER_TOKEN", nil),
+				ConflictsWith: []string{"key_id", "key_secret", "key_secret_command"},
+				Description:   "An access token obtained beforehand, e.g. by an earlier pipeline step, used instead of logging in with key_id and key_secret. Tokens expire, so the run must complete within the token's lifetime.",
+				Sensitive:     true,
+			},
+			"token_exchange_url": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("APPSCAN_TOKEN_EXCHANGE_URL", nil),
+				ConflictsWith: []string{"key_id", "key_secret", "key_secret_command", "bearer_token"},
+				ValidateFunc:  validation.IsURLWithHTTPorHTTPS,
+				Description:   "The URL of a token exchange service (OAuth 2.0 Token Exchange, RFC 8693), run by your organization with an AppScan API key, which trades the OIDC token of the CI job (see oidc_token) for an AppScan access token. Used instead of key_id and key_secret, so that pipelines carry no long-lived secret. The run must complete within the lifetime of the access token.",
+			},
+			"oidc_token": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("APPSCAN_OIDC_TOKEN", nil),
+				Description: "The OIDC token of the CI job sent to token_exchange_url, e.g. an id_tokens variable of GitLab CI. In GitHub Actions jobs with the id-token: write permission, it is requested from GitHub when omitted.",
 				Sensitive:   true,
 			},
+			"oidc_audience": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "appscan",
+				Description: "The audience of the OIDC token requested from GitHub Actions, which the token exchange service checks. Defaults to appscan.",
+			},
+			"accept_language": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("APPSCAN_ACCEPT_LANGUAGE", "en-US"),
+				Description: "The language requested from the API (Accept-Language header), so error messages and enum labels come back in a predictable language. Defaults to en-US.",
+			},
+			"extra_headers": {
+				Type:         schema.TypeMap,
+				Optional:     true,
+				Sensitive:    true,
+				ValidateFunc: validateExtraHeaders,
+				Description:  "Headers sent with every request to the API, the login included, e.g. the credentials of a gateway in front of it. They are not sent to the other hosts the API links to, such as download links. They cannot override the headers the provider sets, such as Authorization.",
+				Elem:         &schema.Schema{Type: schema.TypeString},
+			},
+			"user_agent_suffix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("APPSCAN_USER_AGENT_SUFFIX", ""),
+				Description: "Appended to the User-Agent of every request, e.g. the pipeline and job identifiers, so that the API calls of a run can be traced in the audit logs. Can also be set with the APPSCAN_USER_AGENT_SUFFIX environment variable.",
+			},
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("APPSCAN_PROXY_URL", ""),
+				Description: "The URL of the HTTP(S) proxy used to reach the API. When unset, the standard HTTPS_PROXY/NO_PROXY environment variables apply.",
+			},
+			"ca_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_file"},
+				Description:   "PEM-encoded CA certificate(s) trusted in addition to the system roots, e.g. for an internal CA.",
+			},
+			"ca_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"ca_cert_pem"},
+				Description:   "Path of a PEM file holding CA certificate(s) trusted in addition to the system roots.",
+			},
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Disable TLS certificate verification. Only meant for troubleshooting.",
+			},
+			"upload_chunk_size_mb": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      8,
+				ValidateFunc: validation.IntBetween(1, 1024),
+				Description:  "The size, in MiB, of the chunks file uploads (IRX, scan files...) are streamed and their progress logged in.",
+			},
+			"summary_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("APPSCAN_SUMMARY_FILE", ""),
+				Description: "Path of a JSON file the provider keeps updated with a machine-readable summary of the operations performed during the run, for consumption by run tasks or later pipeline stages. The events of every provider configuration, and of the plan and the apply, of the run set by summary_run_id are merged into the file; the first event of another run replaces them.",
+			},
+			"summary_run_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("APPSCAN_SUMMARY_RUN_ID", ""),
+				Description: "Identifies the run summary_file summarizes. Defaults to TFC_RUN_ID on Terraform Cloud, and otherwise to the Terraform command running the provider: the plan and the apply of `terraform apply` make one run, while `terraform plan -out` and the `terraform apply` of the plan make two, unless they are given the same summary_run_id, e.g. the ID of the pipeline.",
+			},
+			"metrics_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("APPSCAN_METRICS_FILE", ""),
+				Description: "Path of a JSON file the provider keeps updated with the number of API calls, retries and failures, and their latency, per endpoint, e.g. to find out why large plans are slow. The same metrics are logged at INFO level when Terraform stops the provider. Can also be set with the APPSCAN_METRICS_FILE environment variable.",
+			},
+			"metrics_pushgateway_url": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("APPSCAN_METRICS_PUSHGATEWAY_URL", ""),
+				ValidateFunc: validation.Any(validation.StringIsEmpty, validation.IsURLWithHTTPorHTTPS),
+				Description:  "URL of a Prometheus Pushgateway the API metrics are pushed to, under the terraform-provider-appscan job, when Terraform stops the provider at the end of each command. Can also be set with the APPSCAN_METRICS_PUSHGATEWAY_URL environment variable.",
+			},
+			"poll_interval": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validatePositiveDuration,
+				Description:  "How often asynchronous operations (scan executions, report generation...) are polled, as a duration such as `30s`. Resources can override it. Defaults to an interval suited to each operation.",
+			},
+			"max_wait": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validatePositiveDuration,
+				Description:  "How long to wait for asynchronous operations, as a duration such as `2h`. Resources can override it. Defaults to the create timeout of each resource.",
+			},
+			"requests_per_second": {
+				Type:         schema.TypeFloat,
+				Optional:     true,
+				Default:      10.0,
+				ValidateFunc: validation.FloatAtLeast(0),
+				Description:  "The maximum number of API requests sent per second, shared by all resources of the run. Set to 0 to disable rate limiting. Defaults to 10.",
+			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      3,
+				ValidateFunc: validation.IntBetween(0, 10),
+				Description:  "The number of times a request throttled by the API (HTTP 429) is retried, after the delay of its Retry-After header or with an exponential backoff starting at one second, with a random jitter. Reads and deletions are also retried on connection resets and timeouts, and a failed application creation is retried once the application is known not to exist. Defaults to 3.",
+			},
+			"strict_mode": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("APPSCAN_STRICT_MODE", false),
+				Description: "Fail when the API returns a value the provider does not know for an enumeration, such as a business impact, risk rating or status, instead of warning and storing it as is. Can also be set with the APPSCAN_STRICT_MODE environment variable.",
+			},
+			"auto_tags": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Custom attributes set on every application the provider creates, e.g. the Terraform workspace or the repository, so they can be traced back. Each attribute must be defined, e.g. with appscan_attribute_definition. The attributes of an application override them. They are set on creation only and not tracked afterwards. Scans have no attributes in the API and are left alone.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"default_asset_group_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("APPSCAN_DEFAULT_ASSET_GROUP_ID", ""),
+				Description:  "The asset group of the applications (appscan_application, appscan_applications_import) that do not set asset_group_id. Changing it moves those applications. Can also be set with the APPSCAN_DEFAULT_ASSET_GROUP_ID environment variable.",
+				ValidateFunc: validation.Any(validation.StringIsEmpty, validateGUID),
+			},
+			"default_business_unit_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("APPSCAN_DEFAULT_BUSINESS_UNIT_ID", ""),
+				Description:  "The business unit of the applications (appscan_application) that do not set business_unit_id. Can also be set with the APPSCAN_DEFAULT_BUSINESS_UNIT_ID environment variable.",
+				ValidateFunc: validation.Any(validation.StringIsEmpty, validateGUID),
+			},
+			"tenant_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("APPSCAN_TENANT_ID", ""),
+				ValidateFunc: validation.Any(validation.StringIsEmpty, validateGUID),
+				Description:  "The ID of the tenant the credentials must belong to. The provider fails to configure when they belong to another tenant, e.g. when managing several tenants with one aliased provider configuration, and API key, per tenant. Can also be set with the APPSCAN_TENANT_ID environment variable.",
+			},
+			"check_connection": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("APPSCAN_CHECK_CONNECTION", false),
+				Description: "Read the tenant information once authenticated, so that a wrong endpoint, rejected credentials or an unreachable API fail the provider configuration with a diagnostic on the argument at fault rather than the first resource operation. It also checks a bearer_token, which is not otherwise used before the first request. Can also be enabled with the APPSCAN_CHECK_CONNECTION environment variable.",
+			},
+			"log_drift": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("APPSCAN_LOG_DRIFT", false),
+				Description: "Log, at WARN level, the arguments of the managed resources that changed outside Terraform, e.g. in the AppScan console, with their old and new values, when they are refreshed, and record them as drift_detected events of summary_file. The values of sensitive arguments are redacted; computed attributes, such as the status of a scan, are left out. Can also be enabled with the APPSCAN_LOG_DRIFT environment variable.",
+			},
+			"debug_http": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("APPSCAN_DEBUG", false),
+				Description: "Log every API request (method, URL, status, latency and bodies with credentials and tokens redacted) at DEBUG level, e.g. with `TF_LOG_PROVIDER=DEBUG`. Can also be enabled with the APPSCAN_DEBUG environment variable.",
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"appscan_application": resourceAppScanApplication(),
+			"appscan_application":            resourceAppScanApplication(),
+			"appscan_issue_comment":          resourceAppScanIssueComment(),
+			"appscan_issue_rule":             resourceAppScanIssueRule(),
+			"appscan_issue_status":           resourceAppScanIssueStatus(),
+			"appscan_report":                 resourceAppScanReport(),
+			"appscan_dast_scan":              resourceAppScanDastScan(),
+			"appscan_dast_scan_config":       resourceAppScanDastScanConfig(),
+			"appscan_sast_scan":              resourceAppScanSastScan(),
+			"appscan_irx":                    resourceAppScanIRX(),
+			"appscan_scan_execution":         resourceAppScanScanExecution(),
+			"appscan_app_decommission":       resourceAppScanAppDecommission(),
+			"appscan_key":                    resourceAppScanKey(),
+			"appscan_webhook":                resourceAppScanWebhook(),
+			"appscan_notification_settings":  resourceAppScanNotificationSettings(),
+			"appscan_domain":                 resourceAppScanDomain(),
+			"appscan_attribute_definition":   resourceAppScanAttributeDefinition(),
+			"appscan_applications_import":    resourceAppScanApplicationsImport(),
+			"appscan_access_review_snapshot": resourceAppScanAccessReviewSnapshot(),
+			"appscan_user_asset_groups":      resourceAppScanUserAssetGroups(),
+			"appscan_presence_key":           resourceAppScanPresenceKey(),
+			"appscan_application_policy":     resourceAppScanApplicationPolicy(),
+			"appscan_account_settings":       resourceAppScanAccountSettings(),
+			"appscan_generic_request":        resourceAppScanGenericRequest(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"appscan_asset_groups":  dataSourceAssetGroups(),
-			"appscan_asset_group":   dataSourceAssetGroup(),
-			"appscan_business_unit": dataSourceBusinessUnit(),
+			"appscan_asset_groups":        dataSourceAssetGroups(),
+			"appscan_asset_group":         dataSourceAssetGroup(),
+			"appscan_business_unit":       dataSourceBusinessUnit(),
+			"appscan_business_units":      dataSourceBusinessUnits(),
+			"appscan_issues":              dataSourceIssues(),
+			"appscan_issue_statuses":      dataSourceIssueStatuses(),
+			"appscan_execution_artifacts": dataSourceExecutionArtifacts(),
+			"appscan_health":              dataSourceHealth(),
+			"appscan_counts":              dataSourceCounts(),
+			"appscan_subscription":        dataSourceSubscription(),
+			"appscan_api_quota":           dataSourceApiQuota(),
+			"appscan_scan":                dataSourceScan(),
+			"appscan_scan_statistics":     dataSourceScanStatistics(),
+			"appscan_odata_query":         dataSourceODataQuery(),
+			"appscan_generic_request":     dataSourceGenericRequest(),
+			"appscan_import_config":       dataSourceImportConfig(),
+			"appscan_applications":        dataSourceApplications(),
+			"appscan_compliance":          dataSourceCompliance(),
+			"appscan_scan_issue_export":   dataSourceScanIssueExport(),
+			"appscan_auth_token":          dataSourceAuthToken(),
+			"appscan_technologies":        dataSourceTechnologies(),
+			"appscan_application_metrics": dataSourceApplicationMetrics(),
+			"appscan_report_template":     dataSourceReportTemplate(),
+			"appscan_pending_scans":       dataSourcePendingScans(),
+			"appscan_scan_queue":          dataSourceScanQueue(),
+			"appscan_issue_types":         dataSourceIssueTypes(),
 		},
-		ConfigureFunc: providerConfigure,
 	}
+	for name, r := range p.ResourcesMap {
+		logDrift(name, r)
+	}
+	p.ConfigureContextFunc = providerConfigure(p)
+	return p
 }
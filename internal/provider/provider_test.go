@@ -0,0 +1,310 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// Acceptance tests run against the in-memory mock API (see
+// mock_server_test.go) rather than a live tenant, so they only need
+// TF_ACC=1 and a terraform binary.
+
+var testAccProviderFactories = map[string]func() (*schema.Provider, error){
+	"appscan": func() (*schema.Provider, error) {
+		return Provider(), nil
+	},
+}
+
+func TestProvider(t *testing.T) {
+	if err := Provider().InternalValidate(); err != nil {
+		t.Fatalf("err: %s", err)
+	}
+}
+
+func TestAccProvider_bearerToken(t *testing.T) {
+	m := newMockServer(t)
+	healthConfig := `
+data "appscan_health" "test" {}
+`
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "appscan" {
+  api_endpoint = %q
+  key_id       = %q
+  bearer_token = %q
+}
+`, m.URL, mockKeyID, mockToken) + healthConfig,
+				ExpectError: regexp.MustCompile(`conflicts with`),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "appscan" {
+  api_endpoint = %q
+  key_id       = %q
+}
+`, m.URL, mockKeyID) + healthConfig,
+				ExpectError: regexp.MustCompile(`key_id requires key_secret or key_secret_command`),
+			},
+			{
+				Config: fmt.Sprintf(`
+provider "appscan" {
+  api_endpoint = %q
+}
+`, m.URL) + healthConfig,
+				ExpectError: regexp.MustCompile(`either key_id and key_secret \(or key_secret_command\), bearer_token or token_exchange_url must be configured`),
+			},
+			{
+				// The last step is valid so that the test can destroy.
+				Config: fmt.Sprintf(`
+provider "appscan" {
+  api_endpoint = %q
+  bearer_token = %q
+}
+`, m.URL, mockToken) + healthConfig,
+				Check: resource.TestCheckResourceAttr("data.appscan_health.test", "authenticated", "true"),
+			},
+		},
+	})
+}
+
+func TestAccProvider_apiPath(t *testing.T) {
+	m := newMockServer(t)
+	config := func(version, prefix string) string {
+		return fmt.Sprintf(`
+provider "appscan" {
+  api_endpoint    = "%s//"
+  api_version     = %q
+  api_path_prefix = %q
+  key_id          = %q
+  key_secret      = %q
+}
+
+data "appscan_asset_groups" "test" {}
+`, m.URL, version, prefix, mockKeyID, mockKeySecret)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config("4", "/api"),
+				ExpectError: regexp.MustCompile(`must be a version such as v4`),
+			},
+			{
+				// The mock only serves v4.
+				Config:      config("v2", "/api"),
+				ExpectError: regexp.MustCompile(`failed to authenticate via API key, status: 404`),
+			},
+			{
+				Config: config("v4", "/proxied/api"),
+				Check:  resource.TestCheckResourceAttr("data.appscan_asset_groups.test", "asset_groups.#", "2"),
+			},
+		},
+	})
+}
+
+func TestAccProvider_region(t *testing.T) {
+	m := newMockServer(t)
+	t.Setenv("APPSCAN_REGION", "eu")
+	config := func(region string) string {
+		return fmt.Sprintf(`
+provider "appscan" {
+  api_endpoint = %q
+  key_id       = %q
+  key_secret   = %q
+  %s
+}
+
+data "appscan_health" "test" {}
+`, m.URL, mockKeyID, mockKeySecret, region)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+provider "appscan" {
+  region = "ap"
+}
+
+data "appscan_health" "test" {}
+`,
+				ExpectError: regexp.MustCompile(`expected region to be one of`),
+			},
+			{
+				Config:      config(`region = "us"`),
+				ExpectError: regexp.MustCompile(`api_endpoint and region are mutually exclusive`),
+			},
+			{
+				// The configured api_endpoint wins over APPSCAN_REGION.
+				Config: config(""),
+				Check:  resource.TestCheckResourceAttr("data.appscan_health.test", "authenticated", "true"),
+			},
+		},
+	})
+}
+
+func TestAccProvider_keySecretCommand(t *testing.T) {
+	m := newMockServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "appscan" {
+  api_endpoint       = %q
+  key_id             = %q
+  key_secret_command = ["echo", %q]
+}
+
+data "appscan_health" "test" {}
+`, m.URL, mockKeyID, mockKeySecret),
+				Check: resource.TestCheckResourceAttr("data.appscan_health.test", "authenticated", "true"),
+			},
+		},
+	})
+}
+
+func TestAccProvider_tenantID(t *testing.T) {
+	m := newMockServer(t)
+	config := func(tenantID string) string {
+		return fmt.Sprintf(`
+provider "appscan" {
+  alias        = "tenant_a"
+  api_endpoint = %q
+  key_id       = %q
+  key_secret   = %q
+  tenant_id    = %q
+}
+
+data "appscan_health" "test" {
+  provider = appscan.tenant_a
+}
+`, m.URL, mockKeyID, mockKeySecret, tenantID)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config("12345678-1234-1234-1234-123456789012"),
+				ExpectError: regexp.MustCompile(`the credentials belong to tenant ` + mockTenantID + ` \(Mock Tenant\), not to tenant_id 12345678-1234-1234-1234-123456789012`),
+			},
+			{
+				Config: config(mockTenantID),
+				Check:  resource.TestCheckResourceAttr("data.appscan_health.test", "tenant_id", mockTenantID),
+			},
+		},
+	})
+}
+
+func TestAccProvider_configureDiagnostics(t *testing.T) {
+	m := newMockServer(t)
+	config := func(endpoint, credentials string) string {
+		return fmt.Sprintf(`
+provider "appscan" {
+  api_endpoint     = %q
+  check_connection = true
+%s
+}
+
+data "appscan_health" "test" {}
+`, endpoint, credentials)
+	}
+	apiKey := func(keyID, keySecret string) string {
+		return fmt.Sprintf("  key_id     = %q\n  key_secret = %q", keyID, keySecret)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      config(m.URL, apiKey(mockKeyID, mockKeySecret+"\n")),
+				ExpectError: regexp.MustCompile(`Invalid key_secret`),
+			},
+			{
+				Config:      config(m.URL, apiKey(mockKeyID, "wrong-secret")),
+				ExpectError: regexp.MustCompile(`AppScan rejected the credentials(.|\n)*which of key_id and key_secret is wrong`),
+			},
+			{
+				Config:      config(m.URL, fmt.Sprintf("  bearer_token = %q", "expired-token")),
+				ExpectError: regexp.MustCompile(`AppScan rejected the credentials(.|\n)*Check bearer_token`),
+			},
+			{
+				Config:      config(m.URL+"/not-appscan", apiKey(mockKeyID, mockKeySecret)),
+				ExpectError: regexp.MustCompile(`api_endpoint does not serve the AppScan API`),
+			},
+			{
+				// Nothing listens on port 1.
+				Config:      config("http://127.0.0.1:1", apiKey(mockKeyID, mockKeySecret)),
+				ExpectError: regexp.MustCompile(`Cannot reach the AppScan API`),
+			},
+			{
+				Config: config(m.URL, apiKey(mockKeyID, mockKeySecret)),
+				Check:  resource.TestCheckResourceAttr("data.appscan_health.test", "authenticated", "true"),
+			},
+		},
+	})
+}
+
+// testAccProviderConfig returns the provider block pointing at the mock
+// server, to be prepended to each test configuration.
+func testAccProviderConfig(m *mockServer) string {
+	return fmt.Sprintf(`
+provider "appscan" {
+  api_endpoint  = %q
+  key_id        = %q
+  key_secret    = %q
+  poll_interval = "100ms"
+}
+`, m.URL, mockKeyID, mockKeySecret)
+}
+
+// testAccSaveID stores the ID of a resource for later comparison.
+func testAccSaveID(name string, id *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found", name)
+		}
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+// testAccCheckID checks whether a resource kept the ID saved by
+// testAccSaveID, i.e. whether it was updated in place or replaced.
+func testAccCheckID(name string, id *string, same bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found", name)
+		}
+		if (rs.Primary.ID == *id) != same {
+			return fmt.Errorf("resource %s: ID %s, previously %s", name, rs.Primary.ID, *id)
+		}
+		return nil
+	}
+}
+
+// testAccImportID returns the composite import ID prefix:<resource ID>.
+func testAccImportID(name, prefix string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return "", fmt.Errorf("resource %s not found", name)
+		}
+		return prefix + ":" + rs.Primary.ID, nil
+	}
+}
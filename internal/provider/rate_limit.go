@@ -0,0 +1,192 @@
+package provider
+
+import (
+	"errors"
+	"io"
+	"log"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// retryBaseDelay is the delay before the first retry of a throttled or
+// failed request; it doubles with every attempt.
+var retryBaseDelay = time.Second
+
+// maxRetryAfter caps the delay a throttled request waits when the API asks
+// for a longer one in its Retry-After header.
+const maxRetryAfter = 5 * time.Minute
+
+// rateLimitTransport spaces API calls out to at most requestsPerSecond and
+// retries the ones the API throttles (429 Too Many Requests) after the delay
+// of their Retry-After header or an exponential backoff, up to maxRetries
+// times. The delays are jittered, so that the runs throttled together do not
+// retry in lockstep. GET and DELETE requests are
+// also retried when the connection fails transiently; other methods are not,
+// as the API may have acted on them before the connection broke.
+type rateLimitTransport struct {
+	transport  http.RoundTripper
+	bucket     *tokenBucket
+	maxRetries int
+	// metrics, when set, counts the retries.
+	metrics *requestMetrics
+}
+
+// newRateLimitTransport wraps transport. A requestsPerSecond of 0 disables
+// rate limiting but keeps the retries.
+func newRateLimitTransport(transport http.RoundTripper, requestsPerSecond float64, maxRetries int) *rateLimitTransport {
+	t := &rateLimitTransport{transport: transport, maxRetries: maxRetries}
+	if requestsPerSecond > 0 {
+		t.bucket = newTokenBucket(requestsPerSecond)
+	}
+	return t
+}
+
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if err := t.bucket.wait(req); err != nil {
+			return nil, err
+		}
+		resp, err := t.transport.RoundTrip(req)
+		if attempt >= t.maxRetries {
+			return resp, err
+		}
+		reason := "throttled by the API"
+		if err != nil {
+			if !isIdempotent(req.Method) || req.Context().Err() != nil || !isTransientNetworkError(err) {
+				return nil, err
+			}
+			reason = err.Error()
+		} else if resp.StatusCode != http.StatusTooManyRequests {
+			return resp, nil
+		}
+		// Streamed bodies (file uploads) cannot be sent again.
+		if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+			return resp, err
+		}
+		delay := retryDelay(attempt, resp)
+		if resp != nil {
+			resp.Body.Close()
+		}
+
+		t.metrics.retry(req)
+		log.Printf("[WARN] %s %s %s, retrying in %s (%d/%d)", req.Method, req.URL.Path, reason, delay, attempt+1, t.maxRetries)
+		select {
+		case <-time.After(delay):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+	}
+}
+
+// retryDelay returns how long to wait before retrying a request for the
+// attempt-th time: the delay asked by the Retry-After header of resp, if
+// any, up to maxRetryAfter, else retryBaseDelay doubled for every attempt.
+// Both are jittered.
+func retryDelay(attempt int, resp *http.Response) time.Duration {
+	delay := retryBaseDelay << attempt
+	if resp != nil {
+		if after, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok {
+			delay = min(after, maxRetryAfter)
+		}
+	}
+	return delay + jitter(delay)
+}
+
+// parseRetryAfter parses a Retry-After header, either a number of seconds
+// or an HTTP date.
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+	return 0, false
+}
+
+// jitter returns a random delay of up to a fifth of d, to add to d.
+func jitter(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(d)/5 + 1))
+}
+
+// isIdempotent tells whether a request with method may be sent again
+// without risk when its outcome is unknown.
+func isIdempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodDelete
+}
+
+// isTransientNetworkError tells whether err is a connection failure worth a
+// retry: a reset or refused connection, a connection closed before the
+// response, or a network timeout.
+func isTransientNetworkError(err error) bool {
+	if errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// tokenBucket lets bursts of up to rate requests through, then one every
+// 1/rate second.
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64) *tokenBucket {
+	burst := max(rate, 1)
+	return &tokenBucket{rate: rate, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// wait blocks until a token is available or the request is canceled. A nil
+// bucket never blocks.
+func (b *tokenBucket) wait(req *http.Request) error {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens = min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+	// Take the token now, even if that leaves the bucket in debt, so that
+	// concurrent callers queue up behind each other.
+	b.tokens--
+	var delay time.Duration
+	if b.tokens < 0 {
+		delay = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	b.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+	select {
+	case <-time.After(delay):
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
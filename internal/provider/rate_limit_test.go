@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRateLimitTransportRetries(t *testing.T) {
+	defer func(d time.Duration) { retryBaseDelay = d }(retryBaseDelay)
+	retryBaseDelay = time.Millisecond
+
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) < 3 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newRateLimitTransport(http.DefaultTransport, 0, 3)}
+	resp, err := client.Post(server.URL, "application/json", bytes.NewBufferString(`{"Name":"app"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", resp.StatusCode)
+	}
+	for i, b := range bodies {
+		if b != `{"Name":"app"}` {
+			t.Errorf("attempt %d sent body %q", i+1, b)
+		}
+	}
+
+	// Retries are bounded by maxRetries.
+	bodies = nil
+	client = &http.Client{Transport: newRateLimitTransport(http.DefaultTransport, 0, 1)}
+	resp, err = client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusTooManyRequests || len(bodies) != 2 {
+		t.Errorf("got status %d after %d attempts, want 429 after 2", resp.StatusCode, len(bodies))
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	defer func(d time.Duration) { retryBaseDelay = d }(retryBaseDelay)
+	retryBaseDelay = time.Second
+
+	throttled := func(retryAfter string) *http.Response {
+		resp := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+		if retryAfter != "" {
+			resp.Header.Set("Retry-After", retryAfter)
+		}
+		return resp
+	}
+	for _, tc := range []struct {
+		name     string
+		attempt  int
+		resp     *http.Response
+		min, max time.Duration
+	}{
+		{"backoff", 2, nil, 4 * time.Second, 4800 * time.Millisecond},
+		{"no header", 0, throttled(""), time.Second, 1200 * time.Millisecond},
+		{"seconds", 0, throttled("10"), 10 * time.Second, 12 * time.Second},
+		{"date", 0, throttled(time.Now().Add(time.Minute).UTC().Format(http.TimeFormat)), 58 * time.Second, 72 * time.Second},
+		{"past date", 1, throttled("Mon, 02 Jan 2006 15:04:05 GMT"), 0, 0},
+		{"capped", 0, throttled("3600"), maxRetryAfter, maxRetryAfter + maxRetryAfter/5},
+		{"invalid", 1, throttled("soon"), 2 * time.Second, 2400 * time.Millisecond},
+	} {
+		if got := retryDelay(tc.attempt, tc.resp); got < tc.min || got > tc.max {
+			t.Errorf("%s: got %s, want between %s and %s", tc.name, got, tc.min, tc.max)
+		}
+	}
+}
+
+func TestRateLimitTransportRetriesNetworkErrors(t *testing.T) {
+	defer func(d time.Duration) { retryBaseDelay = d }(retryBaseDelay)
+	retryBaseDelay = time.Millisecond
+
+	var attempts atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			// Drop the connection without a response.
+			conn, _, _ := http.NewResponseController(w).Hijack()
+			conn.Close()
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newRateLimitTransport(http.DefaultTransport, 0, 3)}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if n := attempts.Load(); n != 3 {
+		t.Errorf("GET sent %d times, want 3", n)
+	}
+
+	// A POST may have been acted on: it is not sent again.
+	attempts.Store(0)
+	_, err = client.Post(server.URL, "application/json", bytes.NewBufferString(`{"Name":"app"}`))
+	if n := attempts.Load(); err == nil || n != 1 {
+		t.Errorf("POST sent %d times, err = %v; want 1 failed attempt", n, err)
+	}
+}
+
+func TestTokenBucket(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	bucket := newTokenBucket(20)
+
+	// The first 20 requests go through at once, the next 10 take 0.5s.
+	start := time.Now()
+	for i := 0; i < 30; i++ {
+		if err := bucket.wait(req); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 450*time.Millisecond || elapsed > 2*time.Second {
+		t.Errorf("30 requests at 20/s took %s, want about 500ms", elapsed)
+	}
+}
@@ -0,0 +1,135 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// namedEntity is the minimal view of a catalog entry (asset group, business
+// unit...) used to validate references.
+type namedEntity = appscan.NamedEntity
+
+// catalogPageSize is the number of entries requested per page ($top).
+const catalogPageSize = 500
+
+// listNamedEntities returns the Id and Name of every entry of an OData
+// collection such as "AssetGroups" or "BusinessUnits".
+func listNamedEntities(ctx context.Context, client *AppScanClient, collection string) ([]namedEntity, error) {
+	var entities []namedEntity
+	for skip := 0; ; skip += catalogPageSize {
+		query := url.Values{}
+		query.Set("$select", "Id,Name")
+		query.Set("$top", strconv.Itoa(catalogPageSize))
+		query.Set("$skip", strconv.Itoa(skip))
+
+		urlStr := fmt.Sprintf("%s/%s?%s", client.ApiBase, collection, query.Encode())
+		req, err := client.newRequest(ctx, "GET", urlStr, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.Client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		respBody, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, appscan.APIErrorFromBody("list "+collection, resp, respBody)
+		}
+
+		var result struct {
+			Items []namedEntity `json:"Items"`
+		}
+		if err := appscan.DecodeODataPage(resp, respBody, &result); err != nil {
+			return nil, err
+		}
+		entities = append(entities, result.Items...)
+		if len(result.Items) < catalogPageSize {
+			return entities, nil
+		}
+	}
+}
+
+// validateReference checks that id is one of the catalog entries. When it is
+// not, the error suggests the entry whose ID is closest to it, which catches
+// the usual copy/paste typo.
+func validateReference(attr, kind, id string, catalog []namedEntity) error {
+	var best *namedEntity
+	bestDistance := -1
+	for i, e := range catalog {
+		if strings.EqualFold(e.Id, id) {
+			return nil
+		}
+		if dist := levenshtein(strings.ToLower(e.Id), strings.ToLower(id)); bestDistance < 0 || dist < bestDistance {
+			best, bestDistance = &catalog[i], dist
+		}
+	}
+	if best != nil && bestDistance <= len(id)/4 {
+		return fmt.Errorf("%s %q does not match any %s; did you mean %q (%s)?", attr, id, kind, best.Id, best.Name)
+	}
+	return fmt.Errorf("%s %q does not match any %s", attr, id, kind)
+}
+
+// levenshtein returns the edit distance between a and b.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+// customizeDiffValidateReferences validates asset_group_id and
+// business_unit_id against the tenant's catalogs at plan time, when the
+// values are known and changing.
+func customizeDiffValidateReferences(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	client, ok := m.(*AppScanClient)
+	if !ok || client == nil {
+		return nil
+	}
+	for _, ref := range []struct{ attr, collection, kind string }{
+		{"asset_group_id", "AssetGroups", "asset group"},
+		{"business_unit_id", "BusinessUnits", "business unit"},
+	} {
+		if !d.NewValueKnown(ref.attr) || !d.HasChange(ref.attr) {
+			continue
+		}
+		id, _ := d.Get(ref.attr).(string)
+		if id == "" {
+			continue
+		}
+		catalog, err := listNamedEntities(ctx, client, ref.collection)
+		if err != nil {
+			return err
+		}
+		if err := validateReference(ref.attr, ref.kind, id, catalog); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,458 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceAppScanReport() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"scope": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			Description:  "The scope of the report. Allowed values: Application, Scan, ScanExecution.",
+			ValidateFunc: validation.StringInSlice(reportScopes, false),
+		},
+		"scope_id": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			Description:  "The ID of the application, scan or scan execution to report on.",
+			ValidateFunc: validateGUID,
+		},
+		"file_type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Default:      "Pdf",
+			Description:  "The format of the report. Allowed values: Pdf, Html, Xml, Csv, Sarif.",
+			ValidateFunc: validation.StringInSlice(reportFileTypes, false),
+		},
+		"sections": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "The sections the report includes, e.g. from `appscan_report_template`. Allowed values: Summary, Details, Discussion, Overview, TableOfContent, Advisories, FixRecommendation, History, Coverage, MinimizeDetails, Articles. Defaults to Summary, Details, Discussion, Overview, TableOfContent, Advisories and FixRecommendation.",
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice(reportSections, false),
+			},
+		},
+		"severities": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "If provided, the report only covers the issues with one of these severities. Allowed values: Undetermined, Informational, Low, Medium, High, Critical.",
+			Elem: &schema.Schema{
+				Type:         schema.TypeString,
+				ValidateFunc: validation.StringInSlice(issueSeverities, false),
+			},
+		},
+		"locale": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "The language of the report, e.g. fr-FR. Defaults to the accept_language of the provider.",
+		},
+		"title": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "The title of the report.",
+		},
+		"notes": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "Notes included in the report.",
+		},
+		"output_path": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "If provided, the generated report is downloaded to this local path. The report is generated again if the file goes missing.",
+		},
+		"status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The generation status of the report.",
+		},
+		"download_url": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Sensitive:   true,
+			Description: "The link the generated report can be downloaded from.",
+		},
+		"valid_until": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The date after which the report is no longer available for download.",
+		},
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The unique identifier of the report.",
+		},
+	}
+	for k, v := range waitSchema() {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		CreateWithoutTimeout: withContext(resourceAppScanReportCreate),
+		ReadWithoutTimeout:   withContext(resourceAppScanReportRead),
+		UpdateWithoutTimeout: withContext(resourceAppScanReportUpdate),
+		DeleteWithoutTimeout: withContext(resourceAppScanReportDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceAppScanReportImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: s,
+	}
+}
+
+// reportScopes are the kinds of objects a report can cover.
+var reportScopes = []string{"Application", "Scan", "ScanExecution"}
+
+// reportPollInterval is the default interval report generation is polled at.
+const reportPollInterval = 5 * time.Second
+
+// appScanReportStatus mirrors the ReportStatusModel returned by the API.
+type appScanReportStatus struct {
+	Id             string `json:"Id"`
+	Name           string `json:"Name"`
+	Status         string `json:"Status"`
+	Progress       int    `json:"Progress"`
+	ValidUntil     string `json:"ValidUntil"`
+	DownloadLink   string `json:"DownloadLink"`
+	ReportFileType string `json:"ReportFileType"`
+}
+
+func resourceAppScanReportCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	scope := d.Get("scope").(string)
+	scopeID := d.Get("scope_id").(string)
+
+	sections := fullReportSections
+	if v, ok := d.GetOk("sections"); ok {
+		sections = nil
+		for _, section := range v.(*schema.Set).List() {
+			sections = append(sections, section.(string))
+		}
+	}
+	configuration := reportSectionsConfiguration(d.Get("file_type").(string), sections)
+	if v, ok := d.GetOk("locale"); ok {
+		configuration["Locale"] = v.(string)
+	}
+	if v, ok := d.GetOk("title"); ok {
+		configuration["Title"] = v.(string)
+	}
+	if v, ok := d.GetOk("notes"); ok {
+		configuration["Notes"] = v.(string)
+	}
+	var severities []string
+	for _, v := range d.Get("severities").(*schema.Set).List() {
+		expr, err := appscan.ODataEqString("Severity", v.(string))
+		if err != nil {
+			return err
+		}
+		severities = append(severities, expr)
+	}
+	report, err := generateReport(ctx, client, scope, scopeID, appscan.ODataOr(severities), configuration, client.waitSettingsFor(d, reportPollInterval))
+	if report != nil {
+		d.SetId(report.Id)
+	}
+	if err != nil {
+		return err
+	}
+
+	if path, ok := d.GetOk("output_path"); ok {
+		if err := downloadReport(ctx, client, d.Id(), path.(string)); err != nil {
+			return err
+		}
+	}
+	client.Summary.record("report_generated", "appscan_report", d.Id(), map[string]string{
+		"scope":    scope,
+		"scope_id": scopeID,
+	})
+	return resourceAppScanReportRead(ctx, d, m)
+}
+
+func resourceAppScanReportRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	report, err := getReportStatus(ctx, client, d.Id())
+	if err != nil {
+		return err
+	}
+	if report == nil || report.Status == "Deleted" {
+		d.SetId("")
+		return nil
+	}
+	if err := diagnosticsError(client.checkEnum("report "+report.Id, "Status", report.Status, reportStatuses)); err != nil {
+		return err
+	}
+	if path, ok := d.GetOk("output_path"); ok {
+		if _, err := os.Stat(path.(string)); os.IsNotExist(err) {
+			d.SetId("")
+			return nil
+		}
+	}
+	if report.ReportFileType != "" {
+		d.Set("file_type", report.ReportFileType)
+	}
+	d.Set("status", report.Status)
+	d.Set("download_url", report.DownloadLink)
+	d.Set("valid_until", report.ValidUntil)
+	return nil
+}
+
+// resourceAppScanReportUpdate only handles poll_interval and max_wait, which
+// affect the provider's behavior and are not sent to the API.
+func resourceAppScanReportUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	return resourceAppScanReportRead(ctx, d, m)
+}
+
+// resourceAppScanReportImport imports a report by scope:scope_id:report_id,
+// since the API does not return what a report covers. The title, notes,
+// sections, severities, locale and output_path cannot be read back.
+func resourceAppScanReportImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts, err := parseImportID(d.Id(), "scope:scope_id:report_id", 3)
+	if err != nil {
+		return nil, err
+	}
+	scope, scopeID, reportID := parts[0], parts[1], parts[2]
+	if !slices.Contains(reportScopes, scope) {
+		return nil, fmt.Errorf("invalid import scope %q: must be one of %s", scope, strings.Join(reportScopes, ", "))
+	}
+	if err := validateImportGUID("scope_id", scopeID); err != nil {
+		return nil, err
+	}
+	if err := validateImportGUID("report_id", reportID); err != nil {
+		return nil, err
+	}
+
+	report, err := getReportStatus(ctx, m.(*AppScanClient), reportID)
+	if err != nil {
+		return nil, err
+	}
+	if report == nil || report.Status == "Deleted" {
+		return nil, fmt.Errorf("no report found with id: %s", reportID)
+	}
+	d.SetId(reportID)
+	d.Set("scope", scope)
+	d.Set("scope_id", scopeID)
+	d.Set("file_type", "Pdf")
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceAppScanReportDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	if err := deleteReport(ctx, m.(*AppScanClient), d.Id()); err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
+
+// deleteReport deletes a generated report.
+func deleteReport(ctx context.Context, client *AppScanClient, id string) error {
+	urlStr := fmt.Sprintf("%s/Reports/%s", client.ApiBase, url.PathEscape(id))
+	req, err := client.newRequest(ctx, "DELETE", urlStr, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return appscan.NewAPIError("delete report", resp)
+	}
+	return nil
+}
+
+// reportConfiguration returns the configuration of a full security report.
+func reportConfiguration(fileType string) map[string]interface{} {
+	return reportSectionsConfiguration(fileType, fullReportSections)
+}
+
+// reportSectionsConfiguration returns the configuration of a security report
+// including sections.
+func reportSectionsConfiguration(fileType string, sections []string) map[string]interface{} {
+	configuration := map[string]interface{}{
+		"ReportFileType": fileType,
+	}
+	for _, section := range sections {
+		configuration[section] = true
+	}
+	return configuration
+}
+
+// generateReport requests a security report on scope/scopeID, limited to the
+// issues matching the OData filter if not empty, and waits for it to be
+// generated. The returned report is non-nil as soon as the API accepted the
+// request, even if generation then failed.
+func generateReport(ctx context.Context, client *AppScanClient, scope, scopeID, filter string, configuration map[string]interface{}, settings waitSettings) (*appScanReportStatus, error) {
+	job := map[string]interface{}{
+		"Configuration": configuration,
+	}
+	if filter != "" {
+		job["OdataFilter"] = filter
+	}
+	body, err := json.Marshal(job)
+	if err != nil {
+		return nil, err
+	}
+
+	urlStr := fmt.Sprintf("%s/Reports/Security/%s/%s", client.ApiBase, scope, url.PathEscape(scopeID))
+	req, err := client.newRequest(ctx, "POST", urlStr, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, appscan.NewAPIError("request report", resp)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var report appScanReportStatus
+	if err := appscan.DecodeJSON(resp, respBody, &report); err != nil {
+		return nil, err
+	}
+	if report.Id == "" {
+		return nil, fmt.Errorf("failed to retrieve report ID from API response")
+	}
+
+	if err := client.awaitListed(ctx, "report "+report.Id, func() (bool, error) {
+		status, err := getReportStatus(ctx, client, report.Id)
+		return status != nil, err
+	}); err != nil {
+		return &report, err
+	}
+
+	// Wait for the report to be generated.
+	_, err = client.waitFor(ctx, "report "+report.Id, settings, []string{"Pending", "Starting", "Running"}, []string{"Ready"},
+		func() (interface{}, string, int, error) {
+			status, err := getReportStatus(ctx, client, report.Id)
+			if err != nil {
+				return nil, "", -1, err
+			}
+			if status == nil {
+				return nil, "", -1, fmt.Errorf("report %s disappeared while being generated", report.Id)
+			}
+			if status.Status == "Failed" {
+				return status, status.Status, status.Progress, fmt.Errorf("report generation failed")
+			}
+			return status, status.Status, status.Progress, nil
+		})
+	return &report, err
+}
+
+// getReportStatus fetches the status of a report, returning nil when the
+// report does not exist.
+func getReportStatus(ctx context.Context, client *AppScanClient, id string) (*appScanReportStatus, error) {
+	filterQuery, err := client.odataEqID(ctx, "Id", id)
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	query.Set("$filter", filterQuery)
+
+	urlStr := fmt.Sprintf("%s/Reports?%s", client.ApiBase, query.Encode())
+	req, err := client.newRequest(ctx, "GET", urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, appscan.NewAPIError("read report", resp)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var result struct {
+		Items []appScanReportStatus `json:"Items"`
+	}
+	if err := appscan.DecodeODataPage(resp, respBody, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Items) == 0 {
+		return nil, nil
+	}
+	return &result.Items[0], nil
+}
+
+// downloadReport streams a generated report to path. A failed download
+// leaves no file behind, so that it is not mistaken for the report.
+func downloadReport(ctx context.Context, client *AppScanClient, id, path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	err = copyReport(ctx, client, id, f)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(path)
+	}
+	return err
+}
+
+// copyReport streams the content of a generated report to w.
+func copyReport(ctx context.Context, client *AppScanClient, id string, w io.Writer) error {
+	urlStr := fmt.Sprintf("%s/Reports/%s/Download", client.ApiBase, url.PathEscape(id))
+	req, err := client.newRequest(ctx, "GET", urlStr, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return appscan.NewAPIError("download report", resp)
+	}
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
@@ -0,0 +1,56 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccReportResource(t *testing.T) {
+	m := newMockServer(t)
+	output := filepath.Join(t.TempDir(), "report.pdf")
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_report" "test" {
+  scope       = "Application"
+  scope_id    = %q
+  title       = "Security report"
+  output_path = %q
+}
+`, mockApplicationID, output),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_report.test", "status", "Ready"),
+					resource.TestCheckResourceAttrSet("appscan_report.test", "download_url"),
+					func(*terraform.State) error {
+						if _, err := os.Stat(output); err != nil {
+							return fmt.Errorf("report was not downloaded: %w", err)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				ResourceName:            "appscan_report.test",
+				ImportState:             true,
+				ImportStateIdFunc:       testAccImportID("appscan_report.test", "Application:"+mockApplicationID),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"title", "output_path"},
+			},
+			{
+				ResourceName:  "appscan_report.test",
+				ImportState:   true,
+				ImportStateId: mockApplicationID,
+				ExpectError:   regexp.MustCompile(`expected scope:scope_id:report_id`),
+			},
+		},
+	})
+}
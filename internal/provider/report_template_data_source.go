@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ----------------------------------------------------------------
+// Data Source: appscan_report_template (report presets and formats)
+// ----------------------------------------------------------------
+
+// The API has no report templates: every report request carries its format
+// and the sections it includes. The templates are presets of those options,
+// to pass to appscan_report instead of repeating them across modules.
+
+var (
+	reportFileTypes = []string{"Pdf", "Html", "Xml", "Csv", "Sarif"}
+	reportSections  = []string{"Summary", "Details", "Discussion", "Overview", "TableOfContent", "Advisories",
+		"FixRecommendation", "History", "Coverage", "MinimizeDetails", "Articles"}
+)
+
+// reportTemplate is a preset of report options.
+type reportTemplate struct {
+	fileType   string
+	sections   []string
+	severities []string
+}
+
+// fullReportSections are the sections of the reports the provider generates
+// by default.
+var fullReportSections = []string{"Summary", "Details", "Discussion", "Overview", "TableOfContent", "Advisories", "FixRecommendation"}
+
+var reportTemplates = map[string]reportTemplate{
+	// The report appscan_report generates by default.
+	"full": {fileType: "Pdf", sections: fullReportSections},
+	// A short report of the most severe issues, for management.
+	"executive": {fileType: "Pdf", sections: []string{"Summary", "Overview", "TableOfContent", "History"}, severities: []string{"High", "Critical"}},
+	// The details and fixes of the issues, for the developers fixing them.
+	"developer": {fileType: "Html", sections: []string{"Details", "Discussion", "Advisories", "FixRecommendation", "Articles"}},
+	// Every issue in the SARIF format of code scanning tools.
+	"sarif": {fileType: "Sarif", sections: []string{"Details"}},
+}
+
+// reportTemplateNames returns the names of the templates, sorted.
+func reportTemplateNames() []string {
+	names := make([]string, 0, len(reportTemplates))
+	for name := range reportTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func dataSourceReportTemplate() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceReportTemplateRead,
+		Description: "Looks up a preset of report options, to pass to `appscan_report`: full (the default report), executive (summary of the high and critical issues), " +
+			"developer (details and fixes, in HTML) or sarif. Also lists the formats and sections reports accept.",
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The name of the template: full, executive, developer or sarif.",
+				ValidateFunc: validation.StringInSlice(reportTemplateNames(), false),
+			},
+			"file_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The format of the template, for the `file_type` of `appscan_report`.",
+			},
+			"sections": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The sections the template includes, for the `sections` of `appscan_report`.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"severities": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The severities the template is limited to, for the `severities` of `appscan_report`. Empty when it covers all of them.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"file_types": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The formats reports can be generated in.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"all_sections": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The sections reports can include.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceReportTemplateRead(d *schema.ResourceData, m interface{}) error {
+	name := d.Get("name").(string)
+	template := reportTemplates[name]
+
+	d.Set("file_type", template.fileType)
+	if err := d.Set("sections", template.sections); err != nil {
+		return err
+	}
+	if err := d.Set("severities", template.severities); err != nil {
+		return err
+	}
+	if err := d.Set("file_types", reportFileTypes); err != nil {
+		return err
+	}
+	if err := d.Set("all_sections", reportSections); err != nil {
+		return err
+	}
+	d.SetId(name)
+	return nil
+}
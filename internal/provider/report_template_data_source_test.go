@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccReportTemplateDataSource(t *testing.T) {
+	m := newMockServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + fmt.Sprintf(`
+data "appscan_report_template" "executive" {
+  name = "executive"
+}
+
+resource "appscan_report" "test" {
+  scope      = "Application"
+  scope_id   = %q
+  file_type  = data.appscan_report_template.executive.file_type
+  sections   = data.appscan_report_template.executive.sections
+  severities = data.appscan_report_template.executive.severities
+  locale     = "fr-FR"
+}
+`, mockApplicationID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_report_template.executive", "file_type", "Pdf"),
+					resource.TestCheckResourceAttr("data.appscan_report_template.executive", "sections.#", "4"),
+					resource.TestCheckResourceAttr("data.appscan_report_template.executive", "severities.#", "2"),
+					resource.TestCheckResourceAttr("data.appscan_report_template.executive", "file_types.#", "5"),
+					resource.TestCheckResourceAttr("data.appscan_report_template.executive", "all_sections.#", "11"),
+					resource.TestCheckResourceAttr("appscan_report.test", "sections.#", "4"),
+					func(s *terraform.State) error {
+						m.mu.Lock()
+						defer m.mu.Unlock()
+						report := m.find("Reports", s.RootModule().Resources["appscan_report.test"].Primary.ID)
+						if filter := report["OdataFilter"]; filter != "(Severity eq 'Critical' or Severity eq 'High')" && filter != "(Severity eq 'High' or Severity eq 'Critical')" {
+							return fmt.Errorf("report filter = %v", filter)
+						}
+						want := map[string]interface{}{
+							"ReportFileType": "Pdf",
+							"Locale":         "fr-FR",
+							"Summary":        true,
+							"Overview":       true,
+							"TableOfContent": true,
+							"History":        true,
+						}
+						if got := report["Configuration"]; !reflect.DeepEqual(got, want) {
+							return fmt.Errorf("report configuration = %v, want %v", got, want)
+						}
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
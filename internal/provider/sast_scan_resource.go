@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceAppScanSastScan() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"application_id": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			Description:  "The ID of the application the scan belongs to.",
+			ValidateFunc: validateGUID,
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The name of the scan. Changing it renames the scan in place.",
+		},
+		"irx_file": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The path of the IRX file (generated by SAClientUtil) to upload and scan. A new scan is launched when the content of the file changes, not when only its path or modification time does.",
+		},
+		"irx_file_sha256": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The SHA256 of the uploaded IRX file.",
+		},
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The unique identifier of the scan.",
+		},
+	}
+	for k, v := range scanExecutionSchema() {
+		s[k] = v
+	}
+	for k, v := range scanDestroySchema() {
+		s[k] = v
+	}
+	for k, v := range scanMetadataSchema() {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		CreateWithoutTimeout: withContext(resourceAppScanSastScanCreate),
+		ReadWithoutTimeout:   withContext(resourceAppScanSastScanRead),
+		UpdateWithoutTimeout: withContext(resourceAppScanSastScanUpdate),
+		DeleteWithoutTimeout: withContext(resourceAppScanSastScanDelete),
+		Importer: &schema.ResourceImporter{
+			StateContext: importScan("Sast"),
+		},
+		CustomizeDiff: customdiff.All(
+			customizeDiffFileChecksum("irx_file", "irx_file_sha256"),
+			customizeDiffRescan,
+			customizeDiffPublish,
+		),
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Hour),
+		},
+		Schema: s,
+	}
+}
+
+// appScanSastScanRequest is the payload creating a SAST scan.
+type appScanSastScanRequest struct {
+	AppId             string `json:"AppId"`
+	ScanName          string `json:"ScanName"`
+	ApplicationFileId string `json:"ApplicationFileId"`
+	Personal          bool   `json:"Personal"`
+	Execute           bool   `json:"Execute"`
+	appScanScanMetadata
+}
+
+func resourceAppScanSastScanCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	if err := checkPublish(d); err != nil {
+		return err
+	}
+	fileID, checksum, err := uploadFile(ctx, client, d.Get("irx_file").(string), "")
+	if err != nil {
+		return err
+	}
+
+	body, err := json.Marshal(appScanSastScanRequest{
+		AppId:               d.Get("application_id").(string),
+		ScanName:            d.Get("name").(string),
+		ApplicationFileId:   fileID,
+		Personal:            d.Get("personal").(bool),
+		Execute:             true,
+		appScanScanMetadata: scanCreationMetadata(d),
+	})
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/Scans/Sast", client.ApiBase)
+	req, err := client.newRequest(ctx, "POST", url, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return appscan.NewAPIError("create SAST scan", resp)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	var scan appScanScan
+	if err := appscan.DecodeJSON(resp, respBody, &scan); err != nil {
+		return err
+	}
+	if scan.Id == "" {
+		return fmt.Errorf("failed to retrieve scan ID from API response")
+	}
+	d.SetId(scan.Id)
+	d.Set("irx_file_sha256", checksum)
+	client.Summary.record("scan_launched", "appscan_sast_scan", scan.Id, map[string]string{
+		"application_id": d.Get("application_id").(string),
+		"name":           d.Get("name").(string),
+	})
+
+	var gate error
+	if d.Get("wait_for_completion").(bool) {
+		if err := waitForScanWithRetries(ctx, client, d, "Sast", scan.Id, scanExecution(d, fileID)); err != nil {
+			if gate = scanCreateWarning(d, err); gate == nil {
+				return err
+			}
+		}
+	}
+	if err := publishScan(ctx, client, d, "Sast"); err != nil {
+		return err
+	}
+	if err := resourceAppScanSastScanRead(ctx, d, m); err != nil {
+		return err
+	}
+	return gate
+}
+
+func resourceAppScanSastScanRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	scan, err := getScan(ctx, client, "Sast", d.Id())
+	if err != nil {
+		return err
+	}
+	if scan == nil {
+		d.SetId("")
+		return nil
+	}
+	d.Set("application_id", scan.AppId)
+	d.Set("name", scan.Name)
+	d.Set("personal", scan.IsPersonal)
+	d.Set("enable_mail_notification", scan.EnableMailNotification)
+	return setScanExecutionAttributes(client, d, scan)
+}
+
+// resourceAppScanSastScanUpdate renames the scan and applies
+// enable_mail_notification, runs the scan again when rescan_triggers change,
+// uploading irx_file again since uploaded files expire, and publishes its
+// latest execution when publish is set. The other arguments it handles,
+// wait_for_completion and the polling settings, affect the provider's
+// behavior and are not sent to the API, and irx_file may only move to a path
+// holding the same content.
+func resourceAppScanSastScanUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	if err := checkPublish(d); err != nil {
+		return err
+	}
+	if err := updateScanMetadata(ctx, client, d, "appscan_sast_scan"); err != nil {
+		return err
+	}
+
+	if d.HasChange("rescan_triggers") {
+		fileID, _, err := uploadFile(ctx, client, d.Get("irx_file").(string), "")
+		if err != nil {
+			return err
+		}
+		if err := rescan(ctx, client, d, "Sast", scanExecution(d, fileID)); err != nil {
+			return err
+		}
+	}
+	if err := publishScan(ctx, client, d, "Sast"); err != nil {
+		return err
+	}
+	return resourceAppScanSastScanRead(ctx, d, m)
+}
+
+func resourceAppScanSastScanDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	if err := prepareScanDelete(ctx, client, d, "appscan_sast_scan"); err != nil {
+		return err
+	}
+	if err := deleteScan(ctx, client, d.Id()); err != nil {
+		return err
+	}
+	d.SetId("")
+	return nil
+}
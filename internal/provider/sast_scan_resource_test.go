@@ -0,0 +1,326 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccSastScanResource(t *testing.T) {
+	m := newMockServer(t)
+	dir := t.TempDir()
+	writeIRX := func(name, content string) func() {
+		return func() {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	var scanID string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: writeIRX("app.irx", "mock irx content"),
+				Config:    testAccSastScanConfig(m, filepath.Join(dir, "app.irx")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_sast_scan.test", "status", "Ready"),
+					resource.TestCheckResourceAttrSet("appscan_sast_scan.test", "latest_execution_id"),
+					resource.TestCheckResourceAttr("appscan_sast_scan.test", "issue_counts.0.total", "1"),
+					// sha256("mock irx content")
+					resource.TestCheckResourceAttr("appscan_sast_scan.test", "irx_file_sha256", "a7cf87b0fdf62ec785753189291e6412356e4183c0d3e2fbdb93f2abe9d4bf87"),
+					testAccSaveID("appscan_sast_scan.test", &scanID),
+				),
+			},
+			{
+				// Same content under another path: no new scan.
+				PreConfig: writeIRX("renamed.irx", "mock irx content"),
+				Config:    testAccSastScanConfig(m, filepath.Join(dir, "renamed.irx")),
+				Check:     testAccCheckID("appscan_sast_scan.test", &scanID, true),
+			},
+			{
+				PreConfig: writeIRX("renamed.irx", "new mock irx content"),
+				Config:    testAccSastScanConfig(m, filepath.Join(dir, "renamed.irx")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckID("appscan_sast_scan.test", &scanID, false),
+					testAccSaveID("appscan_sast_scan.test", &scanID),
+				),
+			},
+			{
+				// The IRX file is uploaded again for the new execution.
+				Config: testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_sast_scan" "test" {
+  application_id  = %q
+  name            = "main"
+  irx_file        = %q
+  rescan_triggers = {
+    commit = "4d5e6f7"
+  }
+}
+`, mockApplicationID, filepath.Join(dir, "renamed.irx")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckID("appscan_sast_scan.test", &scanID, true),
+					testAccCheckMockExecutions(m, 3),
+				),
+			},
+			{
+				ResourceName:            "appscan_sast_scan.test",
+				ImportState:             true,
+				ImportStateIdFunc:       testAccImportID("appscan_sast_scan.test", mockApplicationID),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"irx_file", "irx_file_sha256", "rescan_triggers"},
+			},
+		},
+	})
+}
+
+func TestAccSastScanResource_destroy(t *testing.T) {
+	m := newMockServer(t)
+	dir := t.TempDir()
+	irx := filepath.Join(dir, "app.irx")
+	if err := os.WriteFile(irx, []byte("mock irx content"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	reports := filepath.Join(dir, "evidence")
+	config := func(protected bool) string {
+		return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_sast_scan" "test" {
+  application_id         = %q
+  name                   = "main"
+  irx_file               = %q
+  deletion_protection    = %t
+  final_report_directory = %q
+}
+`, mockApplicationID, irx, protected, reports)
+	}
+	var scanID string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config(true),
+				Check:  testAccSaveID("appscan_sast_scan.test", &scanID),
+			},
+			{
+				Config:      testAccProviderConfig(m),
+				ExpectError: regexp.MustCompile(`deletion_protection is set`),
+			},
+			{
+				Config: config(false),
+				Check:  testAccCheckID("appscan_sast_scan.test", &scanID, true),
+			},
+			{
+				Config: testAccProviderConfig(m),
+				Check: func(*terraform.State) error {
+					if _, err := os.Stat(filepath.Join(reports, fmt.Sprintf("final-report-%s.pdf", scanID))); err != nil {
+						return fmt.Errorf("final report was not exported: %w", err)
+					}
+					m.mu.Lock()
+					defer m.mu.Unlock()
+					if m.find("Scans", scanID) != nil {
+						return fmt.Errorf("scan %s was not deleted", scanID)
+					}
+					if reports := len(m.collections["Reports"]); reports != 0 {
+						return fmt.Errorf("%d final reports were left in the tenant", reports)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccSastScanResource_failOn(t *testing.T) {
+	m := newMockServer(t)
+	dir := t.TempDir()
+	irx := filepath.Join(dir, "app.irx")
+	if err := os.WriteFile(irx, []byte("mock irx content"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	summaryFile := filepath.Join(dir, "summary.json")
+	// Every execution of the mock finds 1 high issue.
+	config := func(commit string, maxHigh int, taintOnCreate bool) string {
+		return fmt.Sprintf(`
+provider "appscan" {
+  api_endpoint  = %q
+  key_id        = %q
+  key_secret    = %q
+  poll_interval = "100ms"
+  summary_file  = %q
+}
+
+resource "appscan_sast_scan" "test" {
+  application_id      = %q
+  name                = "main"
+  irx_file            = %q
+  wait_for_completion = true
+  rescan_triggers = {
+    commit = %q
+  }
+  fail_on {
+    max_high        = %d
+    max_total       = 1
+    taint_on_create = %t
+  }
+}
+`, m.URL, mockKeyID, mockKeySecret, summaryFile, mockApplicationID, irx, commit, maxHigh, taintOnCreate)
+	}
+	checkSummary := func(exceeded, passed int) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			content, err := os.ReadFile(summaryFile)
+			if err != nil {
+				return err
+			}
+			var summary summaryDocument
+			if err := json.Unmarshal(content, &summary); err != nil {
+				return err
+			}
+			if summary.Counts["scan_threshold_exceeded"] != exceeded || summary.Counts["scan_threshold_passed"] != passed {
+				return fmt.Errorf("summary counts = %v, want %d scan_threshold_exceeded and %d scan_threshold_passed", summary.Counts, exceeded, passed)
+			}
+			return nil
+		}
+	}
+	var scanID string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				// With taint_on_create, a new scan failing the gate fails
+				// the apply, and is tainted.
+				Config:      config("1a2b3c4", 0, true),
+				ExpectError: regexp.MustCompile(`exceeds fail_on: it found 1 high issues, more than 0`),
+			},
+			{
+				// The tainted scan is replaced.
+				Config: config("1a2b3c4", 1, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_sast_scan.test", "status", "Ready"),
+					testAccSaveID("appscan_sast_scan.test", &scanID),
+					checkSummary(1, 1),
+					func(*terraform.State) error {
+						m.mu.Lock()
+						defer m.mu.Unlock()
+						if scans := len(m.collections["Scans"]); scans != 1 {
+							return fmt.Errorf("%d scans, want the tainted one replaced", scans)
+						}
+						return nil
+					},
+				),
+			},
+			{
+				Config:      config("4d5e6f7", 0, false),
+				ExpectError: regexp.MustCompile(`exceeds fail_on`),
+			},
+			{
+				// A rescan failing the gate keeps the scan.
+				Config: config("4d5e6f7", 1, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckID("appscan_sast_scan.test", &scanID, true),
+					checkSummary(2, 1),
+				),
+			},
+			{
+				// Without taint_on_create, a new scan failing the gate only
+				// warns: it is kept.
+				Taint:  []string{"appscan_sast_scan.test"},
+				Config: config("4d5e6f7", 0, false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckID("appscan_sast_scan.test", &scanID, false),
+					resource.TestCheckResourceAttr("appscan_sast_scan.test", "issue_counts.0.high", "1"),
+					checkSummary(3, 1),
+				),
+			},
+		},
+	})
+}
+
+func TestAccSastScanResource_metadata(t *testing.T) {
+	m := newMockServer(t)
+	irx := filepath.Join(t.TempDir(), "app.irx")
+	if err := os.WriteFile(irx, []byte("mock irx content"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	config := func(name string, mail bool, comment, commit string) string {
+		return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_sast_scan" "test" {
+  application_id           = %q
+  name                     = %q
+  irx_file                 = %q
+  enable_mail_notification = %t
+  comment                  = %q
+  rescan_triggers = {
+    commit = %q
+  }
+}
+`, mockApplicationID, name, irx, mail, comment, commit)
+	}
+	var scanID string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: config("main", true, "nightly build", "1a2b3c4"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_sast_scan.test", "enable_mail_notification", "true"),
+					testAccSaveID("appscan_sast_scan.test", &scanID),
+					testAccCheckMockScan(m, &scanID, "main", true, "nightly build"),
+				),
+			},
+			{
+				// Renamed in place; the comment applies to the next execution.
+				Config: config("release", false, "release build", "1a2b3c4"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckID("appscan_sast_scan.test", &scanID, true),
+					resource.TestCheckResourceAttr("appscan_sast_scan.test", "name", "release"),
+					resource.TestCheckResourceAttr("appscan_sast_scan.test", "enable_mail_notification", "false"),
+					testAccCheckMockScan(m, &scanID, "release", false, "nightly build"),
+				),
+			},
+			{
+				Config: config("release", false, "release build", "4d5e6f7"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckID("appscan_sast_scan.test", &scanID, true),
+					testAccCheckMockScan(m, &scanID, "release", false, "release build"),
+				),
+			},
+		},
+	})
+}
+
+// testAccCheckMockScan checks the name and mail notifications of the mock
+// scan *id, and the comment of its latest execution.
+func testAccCheckMockScan(m *mockServer, id *string, name string, mail bool, comment string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		scan := m.find("Scans", *id)
+		if scan == nil || scan["Name"] != name || scan["EnableMailNotification"] != mail {
+			return fmt.Errorf("scan %s = %v, want name %q and mail notifications %t", *id, scan, name, mail)
+		}
+		if actual := scan["LatestExecution"].(mockEntity)["Comment"]; actual != comment {
+			return fmt.Errorf("latest execution of scan %s has comment %v, want %q", *id, actual, comment)
+		}
+		return nil
+	}
+}
+
+func testAccSastScanConfig(m *mockServer, irx string) string {
+	return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_sast_scan" "test" {
+  application_id = %q
+  name           = "main"
+  irx_file       = %q
+}
+`, mockApplicationID, irx)
+}
@@ -0,0 +1,729 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Helpers shared by the scan resources (appscan_dast_scan, appscan_sast_scan).
+
+// scanPollInterval is the default interval scan executions are polled at.
+const scanPollInterval = 10 * time.Second
+
+// defaultRetryFailurePattern matches the messages of executions failing
+// because of the scanning infrastructure rather than the scan: lost
+// presences and agents, network errors and timeouts.
+const defaultRetryFailurePattern = `(?i)presence|agent|connect|network|timed? ?out|infrastructure|internal error`
+
+// appScanScan holds the scan fields the provider relies on. The API returns
+// a technology-specific model; all of them share these fields.
+type appScanScan = appscan.Scan
+
+// appScanDastConfiguration holds the DAST configuration fields the provider
+// reads back.
+type appScanDastConfiguration = appscan.DastConfiguration
+
+// appScanExecution holds the scan execution fields the provider relies on.
+type appScanExecution = appscan.Execution
+
+// scanExecutionSchema returns the attributes every scan resource exposes
+// about its execution.
+func scanExecutionSchema() map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"wait_for_completion": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "If true, apply blocks until the scan execution finishes (bounded by the create timeout) and fails if the execution fails.",
+		},
+		"execution_retries": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      0,
+			ValidateFunc: validation.IntBetween(0, 5),
+			Description:  "How many times an execution failing because of the scanning infrastructure (see retry_failure_pattern) is run again before the apply fails. Only used with wait_for_completion. Defaults to 0.",
+		},
+		"retry_failure_pattern": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      defaultRetryFailurePattern,
+			ValidateFunc: validation.StringIsValidRegExp,
+			Description:  "The regular expression matching the messages (or message keys) of the failed executions that execution_retries applies to. Defaults to a pattern matching presence and agent disconnects, network errors and timeouts; failures of the scan configuration, such as a rejected login, are not retried.",
+		},
+		"rescan_triggers": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Description: "Arbitrary values, such as the commit SHA of the deployed version, whose change runs the scan again rather than replacing it. Waits for the new execution like the creation does when wait_for_completion is set.",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"personal": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			ForceNew:    true,
+			Description: "If true, the scan is personal: its issues are only visible to its owner, in the scan, until they are published (see publish). Defaults to false, the issues being added to the application.",
+		},
+		"publish": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "If true, the issues of the latest execution of the personal scan are promoted to the application once the execution is Ready: during the apply with wait_for_completion, otherwise during the first apply after the execution finishes. Executions run through rescan_triggers are published too. Requires personal.",
+		},
+		"published_execution_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the execution whose issues were last promoted to the application through publish.",
+		},
+		"latest_execution_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the latest execution of the scan.",
+		},
+		"status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The status of the latest execution of the scan.",
+		},
+		"issue_counts": issueCountsSchema("The number of issues found by the latest execution, per severity."),
+		"fail_on":      failOnSchema(),
+	}
+	for k, v := range waitSchema() {
+		s[k] = v
+	}
+	return s
+}
+
+// issueCountsSchema returns the computed issue_counts attribute of an
+// execution, set by executionIssueCounts.
+func issueCountsSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: description,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"total": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The total number of issues.",
+				},
+				"critical": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The number of critical issues.",
+				},
+				"high": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The number of high severity issues.",
+				},
+				"medium": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The number of medium severity issues.",
+				},
+				"low": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The number of low severity issues.",
+				},
+				"informational": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The number of informational issues.",
+				},
+			},
+		},
+	}
+}
+
+// scanThresholds are the limits of fail_on, with the number of issues of an
+// execution each limits.
+var scanThresholds = []struct {
+	name  string
+	found func(*appScanExecution) int
+}{
+	{"critical", func(e *appScanExecution) int { return e.NCriticalIssues }},
+	{"high", func(e *appScanExecution) int { return e.NHighIssues }},
+	{"medium", func(e *appScanExecution) int { return e.NMediumIssues }},
+	{"low", func(e *appScanExecution) int { return e.NLowIssues }},
+	{"informational", func(e *appScanExecution) int { return e.NInfoIssues }},
+	{"total", func(e *appScanExecution) int { return e.NIssuesFound }},
+}
+
+// scanMetadataSchema returns the arguments of the scan resources describing
+// the scan rather than what it scans, which change without replacing it.
+func scanMetadataSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"enable_mail_notification": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Computed:    true,
+			Description: "Whether the users of the application are emailed when an execution of the scan completes. When unset, the value of the API is kept, e.g. as managed by appscan_notification_settings.",
+		},
+		"comment": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The comment of the executions of the scan. The API does not return it: a change applies from the next execution, e.g. run through rescan_triggers.",
+		},
+	}
+}
+
+// appScanExecutionRequest is the body of a new execution of a scan. The
+// file is that of SAST scans, and both fields are omitted when empty.
+type appScanExecutionRequest struct {
+	FileId  string `json:"FileId,omitempty"`
+	Comment string `json:"Comment,omitempty"`
+}
+
+// scanExecution returns the body of a new execution of the scan of d, with
+// its comment argument, if any, and fileID.
+func scanExecution(d *schema.ResourceData, fileID string) appScanExecutionRequest {
+	return appScanExecutionRequest{FileId: fileID, Comment: d.Get("comment").(string)}
+}
+
+// appScanScanMetadata holds the metadata fields of the bodies creating a
+// scan. enable_mail_notification is only sent when configured, leaving the
+// default of the API otherwise.
+type appScanScanMetadata struct {
+	EnableMailNotification *bool  `json:"EnableMailNotification,omitempty"`
+	Comment                string `json:"Comment,omitempty"`
+}
+
+// scanCreationMetadata returns the metadata arguments of d for the body
+// creating a scan.
+func scanCreationMetadata(d *schema.ResourceData) appScanScanMetadata {
+	metadata := appScanScanMetadata{Comment: d.Get("comment").(string)}
+	if config := d.GetRawConfig(); !config.IsNull() && !config.GetAttr("enable_mail_notification").IsNull() {
+		enabled := d.Get("enable_mail_notification").(bool)
+		metadata.EnableMailNotification = &enabled
+	}
+	return metadata
+}
+
+// updateScanMetadata renames the scan and applies enable_mail_notification
+// when they change.
+func updateScanMetadata(ctx context.Context, client *AppScanClient, d *schema.ResourceData, resourceType string) error {
+	if !d.HasChanges("name", "enable_mail_notification") {
+		return nil
+	}
+	enabled := d.Get("enable_mail_notification").(bool)
+	err := updateScanSettings(ctx, client, d.Id(), appScanScanSettingsUpdate{
+		Name:                    d.Get("name").(string),
+		EnableMailNotifications: &enabled,
+	})
+	if err != nil {
+		return err
+	}
+	client.Summary.record("scan_updated", resourceType, d.Id(), map[string]string{
+		"name":                     d.Get("name").(string),
+		"enable_mail_notification": fmt.Sprint(d.Get("enable_mail_notification").(bool)),
+	})
+	return nil
+}
+
+// failOnSchema returns the fail_on argument of the scan resources, checked
+// by checkScanThresholds.
+func failOnSchema() *schema.Schema {
+	thresholds := map[string]*schema.Schema{}
+	for _, t := range scanThresholds {
+		description := fmt.Sprintf("The maximum number of %s issues the execution may find. Defaults to -1, no limit.", t.name)
+		if t.name == "total" {
+			description = "The maximum number of issues the execution may find, whatever their severity. Defaults to -1, no limit."
+		}
+		thresholds["max_"+t.name] = &schema.Schema{
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Default:      -1,
+			ValidateFunc: validation.IntAtLeast(-1),
+			Description:  description,
+		}
+	}
+	thresholds["taint_on_create"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		Default:  false,
+		Description: "If true, the creation of a scan whose execution exceeds the limits fails the apply too. Terraform then taints the scan, and the next apply replaces it, deleting its executions and issues. " +
+			"If false, that creation only warns, and the limits fail the apply on rescans, which keep the scan. Defaults to false.",
+	}
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Description: "Limits on the issues found by the execution the apply waits for, e.g. `max_high = 0`, turning the apply into a security gate: it fails when the execution of a rescan exceeds them, keeping the scan and its new execution in the state. " +
+			"The creation of a scan only fails with taint_on_create. Only used with wait_for_completion.",
+		Elem: &schema.Resource{Schema: thresholds},
+	}
+}
+
+// scanThresholdError is returned when an execution exceeds the limits of
+// fail_on.
+type scanThresholdError struct {
+	execution, scan string
+	exceeded        []string
+}
+
+func (e *scanThresholdError) Error() string {
+	return fmt.Sprintf("execution %s of scan %s exceeds fail_on: it found %s", e.execution, e.scan, strings.Join(e.exceeded, ", "))
+}
+
+// scanCreateWarning returns err as a warning when it is the failure of the
+// fail_on limits of a new scan without taint_on_create, since a failed
+// creation taints the scan. It returns nil for the other errors.
+func scanCreateWarning(d *schema.ResourceData, err error) error {
+	var thresholdErr *scanThresholdError
+	if !errors.As(err, &thresholdErr) || d.Get("fail_on.0.taint_on_create").(bool) {
+		return nil
+	}
+	return warning{err}
+}
+
+// checkScanThresholds fails with a *scanThresholdError when the execution
+// exec of the scan id exceeds the limits of fail_on. Each check is recorded
+// in the run summary, passed or not.
+func checkScanThresholds(client *AppScanClient, d *schema.ResourceData, technology, id string, exec *appScanExecution) error {
+	v := d.Get("fail_on").([]interface{})
+	if len(v) == 0 || v[0] == nil {
+		return nil
+	}
+	limits := v[0].(map[string]interface{})
+
+	var exceeded []string
+	for _, t := range scanThresholds {
+		limit, ok := limits["max_"+t.name].(int)
+		if !ok || limit < 0 {
+			continue
+		}
+		if found := t.found(exec); found > limit {
+			exceeded = append(exceeded, fmt.Sprintf("%d %s issues, more than %d", found, t.name, limit))
+		}
+	}
+	resourceType := "appscan_" + strings.ToLower(technology) + "_scan"
+	if len(exceeded) == 0 {
+		client.Summary.record("scan_threshold_passed", resourceType, id, map[string]string{
+			"execution_id": exec.Id,
+		})
+		return nil
+	}
+	// The failed apply keeps what it set, e.g. for outputs of the gate.
+	d.Set("latest_execution_id", exec.Id)
+	d.Set("status", exec.Status)
+	d.Set("issue_counts", executionIssueCounts(exec))
+	client.Summary.record("scan_threshold_exceeded", resourceType, id, map[string]string{
+		"execution_id": exec.Id,
+		"exceeded":     strings.Join(exceeded, ", "),
+	})
+	return &scanThresholdError{execution: exec.Id, scan: id, exceeded: exceeded}
+}
+
+// executionIssueCounts returns the issue_counts attribute of an execution.
+func executionIssueCounts(exec *appScanExecution) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"total":         exec.NIssuesFound,
+			"critical":      exec.NCriticalIssues,
+			"high":          exec.NHighIssues,
+			"medium":        exec.NMediumIssues,
+			"low":           exec.NLowIssues,
+			"informational": exec.NInfoIssues,
+		},
+	}
+}
+
+// getScan fetches a scan through the technology-specific endpoint
+// (e.g. /api/v4/Scans/Dast/{id}), returning nil when it does not exist.
+func getScan(ctx context.Context, client *AppScanClient, technology, id string) (*appScanScan, error) {
+	var scan appScanScan
+	found, err := getEntity(ctx, client, "scan", fmt.Sprintf("Scans/%s/%s", technology, url.PathEscape(id)), &scan)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &scan, nil
+}
+
+// waitForScan polls the scan until its latest execution is Ready, failing
+// if the execution fails or is paused.
+func waitForScan(ctx context.Context, client *AppScanClient, technology, id string, settings waitSettings) (*appScanScan, error) {
+	pending := []string{"", "InQueue", "Running", "Stopping", "Pausing"}
+	raw, err := client.waitFor(ctx, "scan "+id, settings, pending, []string{"Ready"},
+		func() (interface{}, string, int, error) {
+			scan, err := getScan(ctx, client, technology, id)
+			if err != nil {
+				return nil, "", -1, err
+			}
+			if scan == nil {
+				return nil, "", -1, fmt.Errorf("scan %s disappeared while running", id)
+			}
+			exec := scan.LatestExecution
+			if exec == nil {
+				return scan, "", -1, nil
+			}
+			switch status := exec.Status; status {
+			case "Failed", "Paused":
+				return scan, status, exec.Progress, &scanExecutionError{execution: exec}
+			default:
+				return scan, status, exec.Progress, nil
+			}
+		})
+	if err != nil {
+		return nil, err
+	}
+	return raw.(*appScanScan), nil
+}
+
+// scanExecutionError reports an execution that failed or was paused.
+type scanExecutionError struct {
+	execution *appScanExecution
+}
+
+func (e *scanExecutionError) Error() string {
+	return fmt.Sprintf("scan execution %s: %s", e.execution.Status, e.execution.UserMessage)
+}
+
+// waitForScanWithRetries waits for the scan like waitForScan, running it
+// again when its execution fails because of the infrastructure, up to
+// execution_retries times, and checks the fail_on limits of the execution.
+// The retries share the wait settings' max wait.
+// execute is the body of the new executions, e.g. the file of a SAST scan.
+func waitForScanWithRetries(ctx context.Context, client *AppScanClient, d *schema.ResourceData, technology, id string, execute appScanExecutionRequest) error {
+	settings := client.waitSettingsFor(d, scanPollInterval)
+	deadline := time.Now().Add(settings.maxWait)
+	retries := d.Get("execution_retries").(int)
+	// The pattern passed validation.StringIsValidRegExp.
+	pattern := regexp.MustCompile(d.Get("retry_failure_pattern").(string))
+
+	for attempt := 0; ; attempt++ {
+		scan, err := waitForScan(ctx, client, technology, id, settings)
+		if err == nil {
+			return checkScanThresholds(client, d, technology, id, scan.LatestExecution)
+		}
+		var execErr *scanExecutionError
+		if attempt >= retries || !errors.As(err, &execErr) || execErr.execution.Status != "Failed" {
+			return err
+		}
+		exec := execErr.execution
+		if !pattern.MatchString(exec.UserMessage) && !pattern.MatchString(exec.PredefinedMessageKey) {
+			return err
+		}
+		log.Printf("[WARN] execution %s of scan %s failed because of the infrastructure (%s), running it again (%d/%d)", exec.Id, id, exec.UserMessage, attempt+1, retries)
+		if _, err := executeScan(ctx, client, id, execute); err != nil {
+			return err
+		}
+		client.Summary.record("scan_execution_retried", "appscan_"+strings.ToLower(technology)+"_scan", id, map[string]string{
+			"failed_execution_id": exec.Id,
+			"attempt":             strconv.Itoa(attempt + 1),
+		})
+		settings.maxWait = time.Until(deadline)
+		if settings.maxWait <= 0 {
+			return fmt.Errorf("timeout while waiting for scan %s to be run again", id)
+		}
+	}
+}
+
+// customizeDiffRescan plans new execution attributes when rescan_triggers
+// change, since the update runs the scan again.
+func customizeDiffRescan(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if d.Id() == "" || !d.HasChange("rescan_triggers") {
+		return nil
+	}
+	for _, k := range []string{"latest_execution_id", "status", "issue_counts"} {
+		if err := d.SetNewComputed(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// errPublishNotPersonal is returned when publish is set on a scan that is
+// not personal.
+var errPublishNotPersonal = errors.New("publish requires personal: the issues of other scans are added to the application already")
+
+// customizeDiffPublish checks that only personal scans are published, and
+// plans the publication of the latest execution of a published scan when it
+// has not been published yet, which the update then does. When publish or
+// personal is unknown until apply, e.g. derived from another resource, the
+// check is left to checkPublish and the publication is planned as unknown.
+func customizeDiffPublish(_ context.Context, d *schema.ResourceDiff, _ interface{}) error {
+	if !d.NewValueKnown("publish") || !d.NewValueKnown("personal") {
+		if d.Id() == "" {
+			return nil
+		}
+		return d.SetNewComputed("published_execution_id")
+	}
+	if !d.Get("publish").(bool) {
+		return nil
+	}
+	if !d.Get("personal").(bool) {
+		return errPublishNotPersonal
+	}
+	if d.Id() == "" {
+		return nil
+	}
+	latest := d.Get("latest_execution_id").(string)
+	if d.HasChange("rescan_triggers") || (d.Get("status").(string) == "Ready" && latest != d.Get("published_execution_id").(string)) {
+		return d.SetNewComputed("published_execution_id")
+	}
+	return nil
+}
+
+// checkPublish checks that only personal scans are published, before the
+// scan is created or updated, for the values customizeDiffPublish could not
+// check at plan time.
+func checkPublish(d *schema.ResourceData) error {
+	if d.Get("publish").(bool) && !d.Get("personal").(bool) {
+		return errPublishNotPersonal
+	}
+	return nil
+}
+
+// publishScan promotes the issues of the latest execution of a personal scan
+// to the application when publish is set, the execution is Ready and it was
+// not published before. Executions still running are published by a later
+// apply, planned by customizeDiffPublish.
+func publishScan(ctx context.Context, client *AppScanClient, d *schema.ResourceData, technology string) error {
+	if !d.Get("publish").(bool) || !d.Get("personal").(bool) {
+		return nil
+	}
+	scan, err := getScan(ctx, client, technology, d.Id())
+	if err != nil {
+		return err
+	}
+	if scan == nil {
+		return fmt.Errorf("scan %s disappeared before being published", d.Id())
+	}
+	exec := scan.LatestExecution
+	if exec == nil || exec.Status != "Ready" || exec.Id == d.Get("published_execution_id").(string) {
+		if exec != nil && exec.Status != "Ready" {
+			log.Printf("[INFO] scan %s is not published yet: its latest execution is %s", d.Id(), exec.Status)
+		}
+		return nil
+	}
+
+	urlStr := fmt.Sprintf("%s/Scans/%s/PromoteIssues", client.ApiBase, url.PathEscape(d.Id()))
+	req, err := client.newRequest(ctx, "POST", urlStr, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return appscan.NewAPIError("publish scan", resp)
+	}
+	d.Set("published_execution_id", exec.Id)
+	client.Summary.record("scan_published", "appscan_"+strings.ToLower(technology)+"_scan", d.Id(), map[string]string{
+		"execution_id": exec.Id,
+	})
+	return nil
+}
+
+// rescan runs the scan again when its rescan_triggers changed. execute is the
+// body of the new execution, as for waitForScanWithRetries.
+func rescan(ctx context.Context, client *AppScanClient, d *schema.ResourceData, technology string, execute appScanExecutionRequest) error {
+	if !d.HasChange("rescan_triggers") {
+		return nil
+	}
+	if _, err := executeScan(ctx, client, d.Id(), execute); err != nil {
+		return err
+	}
+	client.Summary.record("scan_rescanned", "appscan_"+strings.ToLower(technology)+"_scan", d.Id(), nil)
+	if d.Get("wait_for_completion").(bool) {
+		return waitForScanWithRetries(ctx, client, d, technology, d.Id(), execute)
+	}
+	return nil
+}
+
+// executeScan starts a new execution of a scan and returns it.
+func executeScan(ctx context.Context, client *AppScanClient, id string, execute appScanExecutionRequest) (*appScanExecution, error) {
+	body, err := json.Marshal(execute)
+	if err != nil {
+		return nil, err
+	}
+	urlStr := fmt.Sprintf("%s/Scans/%s/Executions", client.ApiBase, url.PathEscape(id))
+	req, err := client.newRequest(ctx, "POST", urlStr, bytes.NewBuffer(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return nil, appscan.NewAPIError("execute scan", resp)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var execution appScanExecution
+	if err := appscan.DecodeJSON(resp, respBody, &execution); err != nil {
+		return nil, err
+	}
+	return &execution, nil
+}
+
+// importScan returns the importer of the scan resources, which accepts
+// scan_id or application_id:scan_id.
+func importScan(technology string) schema.StateContextFunc {
+	return func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+		parts, err := parseImportID(d.Id(), "application_id:scan_id", 1)
+		if err != nil {
+			return nil, err
+		}
+		appID, scanID := parts[0], parts[1]
+		if appID != "" {
+			if err := validateImportGUID("application_id", appID); err != nil {
+				return nil, err
+			}
+		}
+		if err := validateImportGUID("scan_id", scanID); err != nil {
+			return nil, err
+		}
+
+		scan, err := getScan(ctx, m.(*AppScanClient), technology, scanID)
+		if err != nil {
+			return nil, err
+		}
+		if scan == nil {
+			return nil, fmt.Errorf("no %s scan found with id: %s", technology, scanID)
+		}
+		if err := checkImportParent("scan", scanID, "application", appID, scan.AppId); err != nil {
+			return nil, err
+		}
+		d.SetId(scanID)
+		d.Set("wait_for_completion", false)
+		d.Set("execution_retries", 0)
+		d.Set("retry_failure_pattern", defaultRetryFailurePattern)
+		d.Set("publish", false)
+		d.Set("deletion_protection", false)
+		d.Set("final_report_file_type", "Pdf")
+		return []*schema.ResourceData{d}, nil
+	}
+}
+
+// setScanExecutionAttributes sets the attributes of scanExecutionSchema.
+func setScanExecutionAttributes(client *AppScanClient, d *schema.ResourceData, scan *appScanScan) error {
+	exec := scan.LatestExecution
+	if exec == nil {
+		exec = &appScanExecution{}
+	}
+	if err := diagnosticsError(client.checkEnum("scan "+scan.Id, "Status", exec.Status, scanExecutionStatuses)); err != nil {
+		return err
+	}
+	d.Set("latest_execution_id", exec.Id)
+	d.Set("status", exec.Status)
+	return d.Set("issue_counts", executionIssueCounts(exec))
+}
+
+// scanDestroySchema returns the arguments of the scan resources controlling
+// what destroying them does, enforced by prepareScanDelete. They are not
+// sent to the API.
+func scanDestroySchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"deletion_protection": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "If true, destroying the scan, or replacing it, fails: set it to false and apply before destroying. Deleting a scan deletes its executions and their issues. Defaults to false.",
+		},
+		"final_report_directory": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "If set, destroying the scan, or replacing it, first exports a security report of the scan to this directory, as `final-report-<scan ID>.<format>`, to keep its results as audit evidence when the environment is torn down. The scan is only deleted once the report is saved; scans never executed have no report.",
+		},
+		"final_report_file_type": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "Pdf",
+			Description:  "The format of the final report. Allowed values: Pdf, Html, Xml, Csv, Sarif. Defaults to Pdf.",
+			ValidateFunc: validation.StringInSlice(reportFileTypes, false),
+		},
+	}
+}
+
+// prepareScanDelete enforces deletion_protection and exports the final
+// report of the scan before it is destroyed.
+func prepareScanDelete(ctx context.Context, client *AppScanClient, d *schema.ResourceData, resourceType string) error {
+	if d.Get("deletion_protection").(bool) {
+		return fmt.Errorf("cannot destroy scan %s: deletion_protection is set, set it to false and apply first", d.Id())
+	}
+	dir := d.Get("final_report_directory").(string)
+	if dir == "" {
+		return nil
+	}
+	if d.Get("latest_execution_id").(string) == "" {
+		log.Printf("[INFO] scan %s was never executed, no final report to export", d.Id())
+		return nil
+	}
+
+	fileType := d.Get("final_report_file_type").(string)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	report, err := generateReport(ctx, client, "Scan", d.Id(), "", reportConfiguration(fileType), client.waitSettingsFor(d, reportPollInterval))
+	if report != nil {
+		// The report is saved to dir: it is not left in the tenant.
+		defer func() {
+			if err := deleteReport(ctx, client, report.Id); err != nil {
+				log.Printf("[WARN] unable to delete the final report %s of scan %s: %s", report.Id, d.Id(), err)
+			}
+		}()
+	}
+	if err != nil {
+		return fmt.Errorf("cannot export the final report of scan %s: %w", d.Id(), err)
+	}
+	path := filepath.Join(dir, fmt.Sprintf("final-report-%s.%s", d.Id(), strings.ToLower(fileType)))
+	if err := downloadReport(ctx, client, report.Id, path); err != nil {
+		return fmt.Errorf("cannot export the final report of scan %s: %w", d.Id(), err)
+	}
+	client.Summary.record("scan_final_report_exported", resourceType, d.Id(), map[string]string{
+		"report_file": path,
+	})
+	return nil
+}
+
+// deleteScan deletes a scan and all its executions.
+func deleteScan(ctx context.Context, client *AppScanClient, id string) error {
+	urlStr := fmt.Sprintf("%s/Scans/%s", client.ApiBase, url.PathEscape(id))
+	req, err := client.newRequest(ctx, "DELETE", urlStr, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return appscan.NewAPIError("delete scan", resp)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ----------------------------------------------------------------
+// Data Source: appscan_scan (the latest scan of an application, e.g. its latest DAST scan)
+// ----------------------------------------------------------------
+
+func dataSourceScan() *schema.Resource {
+	execution := scanExecutionSchema()
+	return &schema.Resource{
+		ReadWithoutTimeout: withContext(dataSourceScanRead),
+		Schema: map[string]*schema.Schema{
+			"application_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The ID of the application.",
+				ValidateFunc: validateGUID,
+			},
+			"technology": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The technology of the scan. If set, only the scans of this technology are considered. Allowed values: DynamicAnalyzer, StaticAnalyzer, ScaAnalyzer, IASTAnalyzer, DastAutomation, IFA.",
+				ValidateFunc: validation.StringInSlice([]string{"DynamicAnalyzer", "StaticAnalyzer", "ScaAnalyzer", "IASTAnalyzer", "DastAutomation", "IFA"}, false),
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the scan.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the latest execution of the scan was requested.",
+			},
+			"latest_execution_id": execution["latest_execution_id"],
+			"status":              execution["status"],
+			"issue_counts":        execution["issue_counts"],
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the scan.",
+			},
+		},
+	}
+}
+
+func dataSourceScanRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	appID := d.Get("application_id").(string)
+
+	filterQuery, err := client.odataEqID(ctx, "AppId", appID)
+	if err != nil {
+		return fmt.Errorf("invalid application_id: %w", err)
+	}
+	if technology := d.Get("technology").(string); technology != "" {
+		technologyFilter, err := appscan.ODataEqString("Technology", technology)
+		if err != nil {
+			return err
+		}
+		filterQuery = appscan.ODataAnd(filterQuery, technologyFilter)
+	}
+	query := url.Values{}
+	query.Set("$filter", filterQuery)
+	query.Set("$orderby", "LatestExecution/CreatedAt desc")
+	query.Set("$top", "1")
+
+	var result struct {
+		Items []appScanScan `json:"Items"`
+	}
+	if err := getODataPage(ctx, client, "Scans", query, &result); err != nil {
+		return err
+	}
+	if len(result.Items) == 0 {
+		return fmt.Errorf("no scan found for application %s", appID)
+	}
+	scan := &result.Items[0]
+
+	d.Set("name", scan.Name)
+	d.Set("technology", scan.Technology)
+	createdAt := ""
+	if scan.LatestExecution != nil {
+		createdAt = scan.LatestExecution.CreatedAt
+	}
+	d.Set("created_at", createdAt)
+	if err := setScanExecutionAttributes(client, d, scan); err != nil {
+		return err
+	}
+
+	d.SetId(scan.Id)
+	return nil
+}
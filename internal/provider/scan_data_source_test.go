@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccScanDataSource(t *testing.T) {
+	m := newMockServer(t)
+	irx := filepath.Join(t.TempDir(), "app.irx")
+	if err := os.WriteFile(irx, []byte("mock irx content"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	scans := fmt.Sprintf(`
+resource "appscan_dast_scan" "test" {
+  application_id = %[1]q
+  name           = "nightly"
+  starting_url   = "https://example.com/"
+}
+
+resource "appscan_sast_scan" "test" {
+  application_id = %[1]q
+  name           = "commit"
+  irx_file       = %[2]q
+
+  # Run after the DAST scan, so it is the latest.
+  depends_on = [appscan_dast_scan.test]
+}
+`, mockApplicationID, irx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + fmt.Sprintf(`
+data "appscan_scan" "test" {
+  application_id = %q
+}
+`, mockApplicationID),
+				ExpectError: regexp.MustCompile("no scan found for application"),
+			},
+			{
+				Config: testAccProviderConfig(m) + scans,
+			},
+			{
+				Config: testAccProviderConfig(m) + scans + fmt.Sprintf(`
+data "appscan_scan" "latest" {
+  application_id = %[1]q
+}
+
+data "appscan_scan" "dast" {
+  application_id = %[1]q
+  technology     = "DynamicAnalyzer"
+}
+`, mockApplicationID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.appscan_scan.latest", "id", "appscan_sast_scan.test", "id"),
+					resource.TestCheckResourceAttr("data.appscan_scan.latest", "technology", "StaticAnalyzer"),
+					resource.TestCheckResourceAttrPair("data.appscan_scan.dast", "id", "appscan_dast_scan.test", "id"),
+					resource.TestCheckResourceAttr("data.appscan_scan.dast", "name", "nightly"),
+					resource.TestCheckResourceAttr("data.appscan_scan.dast", "status", "Ready"),
+					resource.TestCheckResourceAttr("data.appscan_scan.dast", "issue_counts.0.high", "1"),
+					resource.TestCheckResourceAttrPair("data.appscan_scan.dast", "latest_execution_id", "appscan_dast_scan.test", "latest_execution_id"),
+					resource.TestCheckResourceAttrSet("data.appscan_scan.dast", "created_at"),
+				),
+			},
+		},
+	})
+}
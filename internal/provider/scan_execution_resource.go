@@ -0,0 +1,256 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ----------------------------------------------------------------
+// Resource: appscan_scan_execution (one execution of an existing scan)
+// ----------------------------------------------------------------
+
+// The scan resources keep their latest execution only: rescan_triggers runs
+// them again in place. appscan_scan_execution runs a scan once per resource
+// instead, e.g. one per release through for_each, so that the executions
+// stay in the state. Destroying it keeps the execution in AppScan unless
+// delete_on_destroy is set.
+
+func resourceAppScanScanExecution() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"scan_id": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			Description:  "The ID of the scan to run.",
+			ValidateFunc: validateGUID,
+		},
+		"file": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "The path of a file to upload and scan, required by the scans that analyze a file, such as the IRX file of a SAST scan. The other scans run their existing configuration.",
+		},
+		"comment": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ForceNew:     true,
+			Description:  "A comment recorded with the execution, e.g. the version being scanned.",
+			ValidateFunc: validation.StringLenBetween(0, 2048),
+		},
+		"triggers": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			ForceNew:    true,
+			Description: "Arbitrary values, such as the version being released, whose change runs the scan again as a new execution.",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"wait_for_completion": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "If true, apply blocks until the execution finishes (bounded by the create timeout) and fails if the execution fails.",
+		},
+		"delete_on_destroy": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "If true, destroying the resource deletes the execution from AppScan. Defaults to false, the execution being kept in the history of the scan.",
+		},
+		"status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The status of the execution.",
+		},
+		"user_message": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The message of the execution, e.g. why it failed.",
+		},
+		"created_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The date the execution was requested.",
+		},
+		"started_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The date the execution started, once out of the queue.",
+		},
+		"ended_at": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The date the execution ended. Empty while it runs.",
+		},
+		"duration_seconds": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "How long the execution ran, in seconds, queue excluded.",
+		},
+		"issue_counts": issueCountsSchema("The number of issues found by the execution, per severity."),
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The ID of the execution.",
+		},
+	}
+	for k, v := range waitSchema() {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		CreateWithoutTimeout: withContext(resourceAppScanScanExecutionCreate),
+		ReadWithoutTimeout:   withContext(resourceAppScanScanExecutionRead),
+		UpdateWithoutTimeout: withContext(resourceAppScanScanExecutionUpdate),
+		DeleteWithoutTimeout: withContext(resourceAppScanScanExecutionDelete),
+		Importer: &schema.ResourceImporter{
+			State: resourceAppScanScanExecutionImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(2 * time.Hour),
+		},
+		Schema: s,
+	}
+}
+
+func resourceAppScanScanExecutionCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	scanID := d.Get("scan_id").(string)
+
+	var fileID string
+	if path, ok := d.GetOk("file"); ok {
+		var err error
+		if fileID, _, err = uploadFile(ctx, client, path.(string), ""); err != nil {
+			return err
+		}
+	}
+
+	execution, err := executeScan(ctx, client, scanID, scanExecution(d, fileID))
+	if err != nil {
+		return err
+	}
+	d.SetId(execution.Id)
+	client.Summary.record("scan_executed", "appscan_scan_execution", execution.Id, map[string]string{
+		"scan_id": scanID,
+	})
+
+	if d.Get("wait_for_completion").(bool) {
+		if _, err := waitForExecution(ctx, client, execution.Id, client.waitSettingsFor(d, scanPollInterval)); err != nil {
+			return err
+		}
+	}
+	return resourceAppScanScanExecutionRead(ctx, d, m)
+}
+
+func resourceAppScanScanExecutionRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	execution, err := getScanExecution(ctx, client, d.Id())
+	if err != nil {
+		return err
+	}
+	if execution == nil {
+		d.SetId("")
+		return nil
+	}
+	if err := diagnosticsError(client.checkEnum("scan execution "+execution.Id, "Status", execution.Status, scanExecutionStatuses)); err != nil {
+		return err
+	}
+	d.Set("scan_id", execution.ScanId)
+	d.Set("status", execution.Status)
+	d.Set("user_message", execution.UserMessage)
+	d.Set("created_at", execution.CreatedAt)
+	d.Set("started_at", execution.ExecutedAt)
+	d.Set("ended_at", execution.ScanEndTime)
+	d.Set("duration_seconds", execution.ExecutionDurationSec)
+	return d.Set("issue_counts", executionIssueCounts(execution))
+}
+
+// resourceAppScanScanExecutionUpdate only stores the arguments that do not
+// affect the execution, such as wait_for_completion and delete_on_destroy.
+func resourceAppScanScanExecutionUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	return resourceAppScanScanExecutionRead(ctx, d, m)
+}
+
+// resourceAppScanScanExecutionDelete keeps the execution in the history of
+// the scan unless delete_on_destroy is set.
+func resourceAppScanScanExecutionDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	if !d.Get("delete_on_destroy").(bool) {
+		d.SetId("")
+		return nil
+	}
+
+	urlStr := fmt.Sprintf("%s/Scans/Execution/%s", client.ApiBase, url.PathEscape(d.Id()))
+	req, err := client.newRequest(ctx, "DELETE", urlStr, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return appscan.NewAPIError("delete scan execution", resp)
+	}
+	client.Summary.record("scan_execution_deleted", "appscan_scan_execution", d.Id(), nil)
+	d.SetId("")
+	return nil
+}
+
+// resourceAppScanScanExecutionImport imports an execution by its ID. The
+// file and comment it was run with cannot be read back.
+func resourceAppScanScanExecutionImport(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	if err := validateImportGUID("ID", d.Id()); err != nil {
+		return nil, err
+	}
+	d.Set("wait_for_completion", false)
+	d.Set("delete_on_destroy", false)
+	return []*schema.ResourceData{d}, nil
+}
+
+// getScanExecution fetches a scan execution, returning nil when it does not
+// exist.
+func getScanExecution(ctx context.Context, client *AppScanClient, id string) (*appScanExecution, error) {
+	var execution appScanExecution
+	found, err := getEntity(ctx, client, "scan execution", "Scans/Execution/"+url.PathEscape(id), &execution)
+	if err != nil || !found {
+		return nil, err
+	}
+	return &execution, nil
+}
+
+// waitForExecution polls an execution until it is Ready, failing if it
+// fails or is paused, like waitForScan.
+func waitForExecution(ctx context.Context, client *AppScanClient, id string, settings waitSettings) (*appScanExecution, error) {
+	pending := []string{"", "InQueue", "Running", "Stopping", "Pausing"}
+	raw, err := client.waitFor(ctx, "scan execution "+id, settings, pending, []string{"Ready"},
+		func() (interface{}, string, int, error) {
+			execution, err := getScanExecution(ctx, client, id)
+			if err != nil {
+				return nil, "", -1, err
+			}
+			if execution == nil {
+				return nil, "", -1, fmt.Errorf("scan execution %s disappeared while running", id)
+			}
+			switch status := execution.Status; status {
+			case "Failed", "Paused":
+				return execution, status, execution.Progress, &scanExecutionError{execution: execution}
+			default:
+				return execution, status, execution.Progress, nil
+			}
+		})
+	if err != nil {
+		return nil, err
+	}
+	return raw.(*appScanExecution), nil
+}
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const mockScanID = "aaaaaaaa-aaaa-aaaa-aaaa-aaaaaaaaaaaa"
+
+func TestAccScanExecutionResource(t *testing.T) {
+	m := newMockServer(t)
+	m.add("Scans", mockEntity{"Id": mockScanID, "Name": "nightly", "AppId": mockApplicationID, "Technology": "DynamicAnalyzer"})
+	var id string
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckMockExecutions(m, 1),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccScanExecutionConfig(m, "1.0", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_scan_execution.test", "scan_id", mockScanID),
+					resource.TestCheckResourceAttr("appscan_scan_execution.test", "status", "Ready"),
+					resource.TestCheckResourceAttr("appscan_scan_execution.test", "duration_seconds", "1800"),
+					resource.TestCheckResourceAttr("appscan_scan_execution.test", "issue_counts.0.high", "1"),
+					resource.TestCheckResourceAttrSet("appscan_scan_execution.test", "started_at"),
+					resource.TestCheckResourceAttrSet("appscan_scan_execution.test", "ended_at"),
+					testAccSaveID("appscan_scan_execution.test", &id),
+					testAccCheckMockExecutionComment(m, &id, "release 1.0"),
+				),
+			},
+			{
+				// A new release runs a new execution and keeps the previous one.
+				Config: testAccScanExecutionConfig(m, "1.1", false),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckID("appscan_scan_execution.test", &id, false),
+					testAccSaveID("appscan_scan_execution.test", &id),
+					testAccCheckMockExecutions(m, 2),
+				),
+			},
+			{
+				Config: testAccScanExecutionConfig(m, "1.1", true),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckID("appscan_scan_execution.test", &id, true),
+					testAccCheckMockExecutions(m, 2),
+				),
+			},
+			{
+				ResourceName:            "appscan_scan_execution.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"comment", "triggers", "wait_for_completion", "delete_on_destroy", "poll_interval"},
+			},
+		},
+	})
+}
+
+func TestAccScanExecutionResource_failed(t *testing.T) {
+	m := newMockServer(t)
+	m.add("Scans", mockEntity{"Id": mockScanID, "Name": "nightly", "AppId": mockApplicationID, "Technology": "DynamicAnalyzer"})
+	m.executionFailures = []string{"The starting URL is unreachable"}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccScanExecutionConfig(m, "1.0", false),
+				ExpectError: regexp.MustCompile(`scan execution Failed: The starting URL is unreachable`),
+			},
+		},
+	})
+}
+
+func testAccScanExecutionConfig(m *mockServer, release string, deleteOnDestroy bool) string {
+	return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_scan_execution" "test" {
+  scan_id             = %q
+  comment             = "release %[2]s"
+  wait_for_completion = true
+  delete_on_destroy   = %[3]t
+  poll_interval       = "1s"
+  triggers = {
+    release = %[2]q
+  }
+}
+`, mockScanID, release, deleteOnDestroy)
+}
+
+// testAccCheckMockExecutionComment checks the comment the execution id was
+// run with.
+func testAccCheckMockExecutionComment(m *mockServer, id *string, want string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		execution := m.find("Executions", *id)
+		if execution == nil {
+			return fmt.Errorf("execution %s not found", *id)
+		}
+		if got := execution["Comment"]; got != want {
+			return fmt.Errorf("execution %s has comment %v, want %q", *id, got, want)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// ----------------------------------------------------------------
+// Data Source: appscan_scan_issue_export (issues as SARIF or CSV, e.g. for GitHub Code Scanning)
+// ----------------------------------------------------------------
+
+// The export is a security report generated for the occasion, downloaded,
+// then deleted so that each read does not leave a report behind.
+
+func dataSourceScanIssueExport() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"scope": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "The scope of the export. Allowed values: Application, Scan, ScanExecution.",
+			ValidateFunc: validation.StringInSlice(reportScopes, false),
+		},
+		"scope_id": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "The ID of the application, scan or scan execution whose issues are exported.",
+			ValidateFunc: validateGUID,
+		},
+		"format": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "Sarif",
+			Description:  "The format of the export. Allowed values: Sarif, Csv. Defaults to Sarif.",
+			ValidateFunc: validation.StringInSlice([]string{"Sarif", "Csv"}, false),
+		},
+		"filter": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "An OData $filter expression selecting the issues exported, e.g. `Status eq 'Open'`. By default, all the issues of the scope.",
+		},
+		"output_path": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "If provided, the export is written to this local path instead of the content attribute, e.g. for large exports uploaded by a later pipeline step.",
+		},
+		"content": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The export, when output_path is not set.",
+		},
+		"sha256": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The SHA256 of the export.",
+		},
+	}
+	for k, v := range waitSchema() {
+		s[k] = v
+	}
+
+	return &schema.Resource{
+		ReadWithoutTimeout: withContext(dataSourceScanIssueExportRead),
+		Schema:             s,
+	}
+}
+
+func dataSourceScanIssueExportRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	configuration := reportConfiguration(d.Get("format").(string))
+	report, err := generateReport(ctx, client, d.Get("scope").(string), d.Get("scope_id").(string), d.Get("filter").(string), configuration, client.waitSettingsFor(d, reportPollInterval))
+	if report != nil {
+		defer func() {
+			if err := deleteReport(ctx, client, report.Id); err != nil {
+				log.Printf("[WARN] unable to delete report %s generated for the export: %s", report.Id, err)
+			}
+		}()
+	}
+	if err != nil {
+		return err
+	}
+
+	var content bytes.Buffer
+	hash := sha256.New()
+	path := d.Get("output_path").(string)
+	if path == "" {
+		if err := copyReport(ctx, client, report.Id, io.MultiWriter(&content, hash)); err != nil {
+			return err
+		}
+	} else {
+		if err := downloadReport(ctx, client, report.Id, path); err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		if _, err := io.Copy(hash, f); err != nil {
+			return err
+		}
+	}
+
+	sum := hex.EncodeToString(hash.Sum(nil))
+	d.Set("content", content.String())
+	d.Set("sha256", sum)
+	d.SetId(sum)
+	return nil
+}
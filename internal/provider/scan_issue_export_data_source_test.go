@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccScanIssueExportDataSource(t *testing.T) {
+	m := newMockServer(t)
+	path := filepath.Join(t.TempDir(), "issues.csv")
+	checkNoReportLeft := func(*terraform.State) error {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if n := len(m.collections["Reports"]); n != 0 {
+			return fmt.Errorf("%d reports left behind", n)
+		}
+		return nil
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + fmt.Sprintf(`
+data "appscan_scan_issue_export" "test" {
+  scope    = "Application"
+  scope_id = %q
+  filter   = "Status eq 'Open'"
+}
+`, mockApplicationID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr("data.appscan_scan_issue_export.test", "content", regexp.MustCompile(`"version":"2.1.0"`)),
+					resource.TestMatchResourceAttr("data.appscan_scan_issue_export.test", "content", regexp.MustCompile(`"filter":"Status eq 'Open'"`)),
+					resource.TestMatchResourceAttr("data.appscan_scan_issue_export.test", "sha256", regexp.MustCompile(`^[0-9a-f]{64}$`)),
+					checkNoReportLeft,
+				),
+			},
+			{
+				Config: testAccProviderConfig(m) + fmt.Sprintf(`
+data "appscan_scan_issue_export" "test" {
+  scope       = "Application"
+  scope_id    = %q
+  format      = "Csv"
+  output_path = %q
+}
+`, mockApplicationID, path),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_scan_issue_export.test", "content", ""),
+					func(*terraform.State) error {
+						content, err := os.ReadFile(path)
+						if err != nil {
+							return err
+						}
+						if !strings.HasPrefix(string(content), "Id,Severity") {
+							return fmt.Errorf("unexpected export: %q", content)
+						}
+						return nil
+					},
+					checkNoReportLeft,
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ----------------------------------------------------------------
+// Data Source: appscan_scan_queue (queued and running scans, for capacity-aware scheduling)
+// ----------------------------------------------------------------
+
+// runningScanStatuses are the statuses of the executions holding a slot of
+// the concurrent scans of the tenant.
+var runningScanStatuses = []string{"Running", "Stopping", "Pausing"}
+
+func dataSourceScanQueue() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: withContext(dataSourceScanQueueRead),
+		Description: "Reads how many scans of the tenant are queued and running, per technology, and how many more may run, " +
+			"so that modules can stagger the creation of their scans, e.g. through time_sleep, rather than flood the queue. " +
+			"The counts are read at each refresh: they are a snapshot, not a reservation.",
+		Schema: map[string]*schema.Schema{
+			"technologies": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The queued and running scans per technology, by technology name. DynamicAnalyzer, StaticAnalyzer, ScaAnalyzer and IASTAnalyzer are always listed.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"technology": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The technology of the scans, e.g. DynamicAnalyzer.",
+						},
+						"queued": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of scans whose latest execution is InQueue.",
+						},
+						"running": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of scans whose latest execution is Running, Stopping or Pausing.",
+						},
+					},
+				},
+			},
+			"queued": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of queued scans, all technologies included.",
+			},
+			"running": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of running scans, all technologies included.",
+			},
+			"max_concurrent_scans": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of scans the unexpired subscriptions of the tenant let run at the same time, 0 when they set no limit.",
+			},
+			"available_slots": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "How many more scans may start without queuing: max_concurrent_scans minus the running and queued scans, at least 0. -1 when the subscriptions set no limit.",
+			},
+		},
+	}
+}
+
+func dataSourceScanQueueRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	var statuses []string
+	for _, status := range append([]string{"InQueue"}, runningScanStatuses...) {
+		expr, err := appscan.ODataEqString("LatestExecution/Status", status)
+		if err != nil {
+			return err
+		}
+		statuses = append(statuses, expr)
+	}
+	query := url.Values{}
+	query.Set("$filter", appscan.ODataOr(statuses))
+	scans, err := listODataPages[appScanScan](ctx, client, "Scans", query)
+	if err != nil {
+		return err
+	}
+
+	type counts struct{ queued, running int }
+	byTechnology := map[string]*counts{}
+	for technology := range scanTechnologies {
+		byTechnology[technology] = &counts{}
+	}
+	queued, running := 0, 0
+	for _, scan := range scans {
+		if scan.LatestExecution == nil {
+			continue
+		}
+		c, ok := byTechnology[scan.Technology]
+		if !ok {
+			c = &counts{}
+			byTechnology[scan.Technology] = c
+		}
+		if scan.LatestExecution.Status == "InQueue" {
+			c.queued++
+			queued++
+		} else {
+			c.running++
+			running++
+		}
+	}
+	technologies := make([]string, 0, len(byTechnology))
+	for technology := range byTechnology {
+		technologies = append(technologies, technology)
+	}
+	sort.Strings(technologies)
+	list := make([]interface{}, 0, len(technologies))
+	for _, technology := range technologies {
+		list = append(list, map[string]interface{}{
+			"technology": technology,
+			"queued":     byTechnology[technology].queued,
+			"running":    byTechnology[technology].running,
+		})
+	}
+	if err := d.Set("technologies", list); err != nil {
+		return err
+	}
+
+	tenant, _, message := checkTenant(ctx, client)
+	if tenant == nil {
+		return errors.New(message)
+	}
+	now := time.Now().UTC()
+	maxConcurrent := 0
+	for _, s := range tenant.Subscriptions {
+		if t, err := time.Parse(time.RFC3339, s.ExpirationDate); err == nil && !t.After(now) {
+			continue
+		}
+		maxConcurrent += s.MaxConcurrentScans
+	}
+
+	d.Set("queued", queued)
+	d.Set("running", running)
+	d.Set("max_concurrent_scans", maxConcurrent)
+	if maxConcurrent == 0 {
+		d.Set("available_slots", -1)
+	} else {
+		d.Set("available_slots", max(maxConcurrent-running-queued, 0))
+	}
+	d.SetId(client.ApiEndpoint)
+	return nil
+}
@@ -0,0 +1,47 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccScanQueueDataSource(t *testing.T) {
+	m := newMockServer(t)
+	for technology, statuses := range map[string][]string{
+		"DynamicAnalyzer": {"Running", "InQueue", "InQueue"},
+		"StaticAnalyzer":  {"Pausing", "Ready", "Failed"},
+		"IFA":             {"InQueue"},
+	} {
+		for _, status := range statuses {
+			m.add("Scans", mockEntity{"Name": status, "AppId": mockApplicationID, "Technology": technology, "LatestExecution": mockEntity{"Status": status}})
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + `
+data "appscan_scan_queue" "test" {}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_scan_queue.test", "queued", "3"),
+					resource.TestCheckResourceAttr("data.appscan_scan_queue.test", "running", "2"),
+					resource.TestCheckResourceAttr("data.appscan_scan_queue.test", "technologies.#", "5"),
+					resource.TestCheckResourceAttr("data.appscan_scan_queue.test", "technologies.0.technology", "DynamicAnalyzer"),
+					resource.TestCheckResourceAttr("data.appscan_scan_queue.test", "technologies.0.queued", "2"),
+					resource.TestCheckResourceAttr("data.appscan_scan_queue.test", "technologies.0.running", "1"),
+					resource.TestCheckResourceAttr("data.appscan_scan_queue.test", "technologies.1.technology", "IASTAnalyzer"),
+					resource.TestCheckResourceAttr("data.appscan_scan_queue.test", "technologies.1.queued", "0"),
+					resource.TestCheckResourceAttr("data.appscan_scan_queue.test", "technologies.2.technology", "IFA"),
+					resource.TestCheckResourceAttr("data.appscan_scan_queue.test", "technologies.4.technology", "StaticAnalyzer"),
+					resource.TestCheckResourceAttr("data.appscan_scan_queue.test", "technologies.4.running", "1"),
+					// Only the unexpired subscription of the mock sets a limit.
+					resource.TestCheckResourceAttr("data.appscan_scan_queue.test", "max_concurrent_scans", "5"),
+					resource.TestCheckResourceAttr("data.appscan_scan_queue.test", "available_slots", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,216 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ----------------------------------------------------------------
+// Data Source: appscan_scan_statistics (coverage statistics of the latest execution of a scan)
+// ----------------------------------------------------------------
+
+// scanExecutionEndpoints maps scan technologies to the endpoint returning
+// the detailed model of their executions. Other technologies fall back to
+// the basic model of /api/v4/Scans/Execution.
+var scanExecutionEndpoints = map[string]string{
+	"DynamicAnalyzer": "DastExecution",
+	"StaticAnalyzer":  "SastExecution",
+	"ScaAnalyzer":     "ScaExecution",
+}
+
+func dataSourceScanStatistics() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: withContext(dataSourceScanStatisticsRead),
+		Schema: map[string]*schema.Schema{
+			"scan_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The ID of the scan.",
+				ValidateFunc: validateGUID,
+			},
+			"technology": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The technology of the scan, e.g. DynamicAnalyzer or StaticAnalyzer.",
+			},
+			"execution_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the latest execution of the scan.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the execution.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the execution was requested.",
+			},
+			"executed_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the execution started, empty until it does.",
+			},
+			"scan_end_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date the execution ended, empty until it does.",
+			},
+			"duration_seconds": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "How long the execution ran, in seconds.",
+			},
+			"queued_seconds": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "How long the execution waited in the queue, in seconds.",
+			},
+			"issues_found": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of issues the execution found.",
+			},
+			"visited_pages": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "DAST only: the number of pages explored.",
+			},
+			"unvisited_pages": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "DAST only: the number of pages found but not explored.",
+			},
+			"entities": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "DAST only: the number of entities (URLs, parameters, cookies) found.",
+			},
+			"tested_entities": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "DAST only: the number of entities tested.",
+			},
+			"requests_sent": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "DAST only: the number of test requests sent.",
+			},
+			"total_lines": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "SAST only: the number of lines of code scanned.",
+			},
+			"languages": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "SAST only: the languages scanned.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+// appScanExecutionStatistics holds the statistics fields of the execution
+// models. Those of other technologies are left at zero.
+type appScanExecutionStatistics struct {
+	Id                   string   `json:"Id"`
+	Status               string   `json:"Status"`
+	CreatedAt            string   `json:"CreatedAt"`
+	ExecutedAt           string   `json:"ExecutedAt"`
+	ScanEndTime          string   `json:"ScanEndTime"`
+	ExecutionDurationSec int      `json:"ExecutionDurationSec"`
+	QueuedDurationSec    int      `json:"QueuedDurationSec"`
+	NIssuesFound         int      `json:"NIssuesFound"`
+	NVisitedPages        int      `json:"NVisitedPages"`
+	NUnvisitedPages      int      `json:"NUnvisitedPages"`
+	NEntities            int      `json:"NEntities"`
+	NTestedEntities      int      `json:"NTestedEntities"`
+	NRequestsSent        int      `json:"NRequestsSent"`
+	NTotalLines          int      `json:"NTotalLines"`
+	Languages            []string `json:"Languages"`
+}
+
+func dataSourceScanStatisticsRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	scanID := d.Get("scan_id").(string)
+
+	filterQuery, err := client.odataEqID(ctx, "Id", scanID)
+	if err != nil {
+		return fmt.Errorf("invalid scan_id: %w", err)
+	}
+	query := url.Values{}
+	query.Set("$filter", filterQuery)
+	var result struct {
+		Items []appScanScan `json:"Items"`
+	}
+	if err := getODataPage(ctx, client, "Scans", query, &result); err != nil {
+		return err
+	}
+	if len(result.Items) == 0 {
+		return fmt.Errorf("no scan found with id: %s", scanID)
+	}
+	scan := result.Items[0]
+	if scan.LatestExecution == nil {
+		return fmt.Errorf("scan %s has no execution", scanID)
+	}
+
+	endpoint, ok := scanExecutionEndpoints[scan.Technology]
+	if !ok {
+		endpoint = "Execution"
+	}
+	urlStr := fmt.Sprintf("%s/Scans/%s/%s", client.ApiBase, endpoint, url.PathEscape(scan.LatestExecution.Id))
+	req, err := client.newRequest(ctx, "GET", urlStr, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return appscan.NewAPIError("read scan execution", resp)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	var execution appScanExecutionStatistics
+	if err := appscan.DecodeJSON(resp, respBody, &execution); err != nil {
+		return err
+	}
+
+	d.Set("technology", scan.Technology)
+	d.Set("execution_id", execution.Id)
+	d.Set("status", execution.Status)
+	d.Set("created_at", execution.CreatedAt)
+	d.Set("executed_at", execution.ExecutedAt)
+	d.Set("scan_end_time", execution.ScanEndTime)
+	d.Set("duration_seconds", execution.ExecutionDurationSec)
+	d.Set("queued_seconds", execution.QueuedDurationSec)
+	d.Set("issues_found", execution.NIssuesFound)
+	d.Set("visited_pages", execution.NVisitedPages)
+	d.Set("unvisited_pages", execution.NUnvisitedPages)
+	d.Set("entities", execution.NEntities)
+	d.Set("tested_entities", execution.NTestedEntities)
+	d.Set("requests_sent", execution.NRequestsSent)
+	d.Set("total_lines", execution.NTotalLines)
+	if err := d.Set("languages", execution.Languages); err != nil {
+		return err
+	}
+
+	d.SetId(execution.Id)
+	return nil
+}
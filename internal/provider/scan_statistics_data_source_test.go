@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccScanStatisticsDataSource(t *testing.T) {
+	m := newMockServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + `
+data "appscan_scan_statistics" "test" {
+  scan_id = "33333333-3333-3333-3333-333333333333"
+}
+`,
+				ExpectError: regexp.MustCompile("no scan found"),
+			},
+			{
+				Config: testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_dast_scan" "test" {
+  application_id = %q
+  name           = "nightly"
+  starting_url   = "https://example.com/"
+}
+
+data "appscan_scan_statistics" "test" {
+  scan_id = appscan_dast_scan.test.id
+}
+`, mockApplicationID),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_scan_statistics.test", "technology", "DynamicAnalyzer"),
+					resource.TestCheckResourceAttrPair("data.appscan_scan_statistics.test", "execution_id", "appscan_dast_scan.test", "latest_execution_id"),
+					resource.TestCheckResourceAttr("data.appscan_scan_statistics.test", "status", "Ready"),
+					resource.TestCheckResourceAttr("data.appscan_scan_statistics.test", "duration_seconds", "1800"),
+					resource.TestCheckResourceAttr("data.appscan_scan_statistics.test", "visited_pages", "120"),
+					resource.TestCheckResourceAttr("data.appscan_scan_statistics.test", "requests_sent", "9000"),
+					resource.TestCheckResourceAttr("data.appscan_scan_statistics.test", "total_lines", "0"),
+					resource.TestCheckResourceAttr("data.appscan_scan_statistics.test", "languages.#", "0"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,42 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// secretCommandTimeout bounds how long key_secret_command may run, e.g.
+// waiting for the user to unlock a password manager.
+const secretCommandTimeout = 2 * time.Minute
+
+// runSecretCommand runs argv, without a shell, and returns its standard
+// output with surrounding whitespace removed.
+func runSecretCommand(ctx context.Context, argv []string) (string, error) {
+	if len(argv) == 0 || argv[0] == "" {
+		return "", fmt.Errorf("key_secret_command: no program given")
+	}
+	ctx, cancel := context.WithTimeout(ctx, secretCommandTimeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, argv[0], argv[1:]...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		// The secret never reaches stderr; its content helps to diagnose
+		// e.g. an expired password manager session.
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("key_secret_command %s: %w: %s", argv[0], err, msg)
+		}
+		return "", fmt.Errorf("key_secret_command %s: %w", argv[0], err)
+	}
+	secret := strings.TrimSpace(stdout.String())
+	if secret == "" {
+		return "", fmt.Errorf("key_secret_command %s printed no secret", argv[0])
+	}
+	return secret, nil
+}
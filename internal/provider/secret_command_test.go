@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRunSecretCommand(t *testing.T) {
+	secret, err := runSecretCommand(context.Background(), []string{"echo", "  s3cret  "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if secret != "s3cret" {
+		t.Errorf("secret = %q, want s3cret", secret)
+	}
+
+	cases := []struct {
+		argv    []string
+		wantErr string
+	}{
+		{nil, "no program given"},
+		{[]string{"true"}, "printed no secret"},
+		{[]string{"sh", "-c", "echo session expired >&2; exit 1"}, "exit status 1: session expired"},
+		{[]string{"/nonexistent/secret-helper"}, "no such file or directory"},
+	}
+	for _, c := range cases {
+		_, err := runSecretCommand(context.Background(), c.argv)
+		if err == nil || !strings.Contains(err.Error(), c.wantErr) {
+			t.Errorf("runSecretCommand(%q): error %v, want %q", c.argv, err, c.wantErr)
+		}
+	}
+}
@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ----------------------------------------------------------------
+// Data Source: appscan_subscription (license entitlements and expiry)
+// ----------------------------------------------------------------
+
+// The API reports what each subscription entitles to and how many seats are
+// taken, not how many scans of a given technology remain; preconditions
+// compare the purchased and taken amounts instead.
+
+func dataSourceSubscription() *schema.Resource {
+	return &schema.Resource{
+		ReadWithoutTimeout: withContext(dataSourceSubscriptionRead),
+		Schema: map[string]*schema.Schema{
+			"number_of_apps": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of applications of the tenant.",
+			},
+			"max_scans_per_app": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The maximum number of scans an application may have.",
+			},
+			"max_users": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The maximum number of users of the tenant.",
+			},
+			"earliest_expiration_date": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The earliest expiration date of the subscriptions that have not expired yet, empty when there are none.",
+			},
+			"days_until_expiration": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of whole days until earliest_expiration_date, -1 when every subscription has expired.",
+			},
+			"subscriptions": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The subscriptions of the tenant.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the subscription.",
+						},
+						"offering_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The offering of the subscription, e.g. ScanExecution, Applications or Trial.",
+						},
+						"state": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The state of the subscription.",
+						},
+						"purchase_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date the subscription was purchased.",
+						},
+						"renewal_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date the subscription renews.",
+						},
+						"expiration_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date the subscription expires.",
+						},
+						"expired": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the subscription has expired.",
+						},
+						"seats": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of units purchased, whose kind depends on the offering type.",
+						},
+						"taken_seats": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of units used.",
+						},
+						"available_seats": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of units left, never below 0.",
+						},
+						"executions": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of scan executions the subscription entitles to.",
+						},
+						"applications": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The number of applications the subscription entitles to.",
+						},
+						"max_concurrent_scans": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The maximum number of scans that may run at the same time.",
+						},
+						"overage_support": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether usage beyond the entitlement is allowed.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// appScanSubscription holds the SCXSubscription fields the provider relies on.
+type appScanSubscription struct {
+	SubscriptionId     int64  `json:"SubscriptionId"`
+	OfferingType       string `json:"OfferingType"`
+	State              string `json:"State"`
+	PurchaseDate       string `json:"PurchaseDate"`
+	RenewalDate        string `json:"RenewalDate"`
+	ExpirationDate     string `json:"ExpirationDate"`
+	NSeats             int    `json:"NSeats"`
+	NTakenSeats        int    `json:"NTakenSeats"`
+	NExecutions        int    `json:"NExecutions"`
+	NApps              int    `json:"NApps"`
+	MaxConcurrentScans int    `json:"MaxConcurrentScans"`
+	OverageSupport     bool   `json:"OverageSupport"`
+}
+
+func dataSourceSubscriptionRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	tenant, _, message := checkTenant(ctx, client)
+	if tenant == nil {
+		return errors.New(message)
+	}
+	now := time.Now().UTC()
+
+	var earliest time.Time
+	subscriptions := make([]interface{}, 0, len(tenant.Subscriptions))
+	for _, s := range tenant.Subscriptions {
+		expired := false
+		if s.ExpirationDate != "" {
+			if t, err := time.Parse(time.RFC3339, s.ExpirationDate); err == nil {
+				expired = !t.After(now)
+				if !expired && (earliest.IsZero() || t.Before(earliest)) {
+					earliest = t
+				}
+			}
+		}
+		subscriptions = append(subscriptions, map[string]interface{}{
+			"id":                   strconv.FormatInt(s.SubscriptionId, 10),
+			"offering_type":        s.OfferingType,
+			"state":                s.State,
+			"purchase_date":        s.PurchaseDate,
+			"renewal_date":         s.RenewalDate,
+			"expiration_date":      s.ExpirationDate,
+			"expired":              expired,
+			"seats":                s.NSeats,
+			"taken_seats":          s.NTakenSeats,
+			"available_seats":      max(s.NSeats-s.NTakenSeats, 0),
+			"executions":           s.NExecutions,
+			"applications":         s.NApps,
+			"max_concurrent_scans": s.MaxConcurrentScans,
+			"overage_support":      s.OverageSupport,
+		})
+	}
+	if err := d.Set("subscriptions", subscriptions); err != nil {
+		return err
+	}
+
+	d.Set("number_of_apps", tenant.NumberOfApps)
+	d.Set("max_scans_per_app", tenant.MaxScansPerApp)
+	d.Set("max_users", tenant.MaxUsers)
+	if earliest.IsZero() {
+		d.Set("earliest_expiration_date", "")
+		d.Set("days_until_expiration", -1)
+	} else {
+		d.Set("earliest_expiration_date", earliest.Format(time.RFC3339))
+		d.Set("days_until_expiration", int(math.Floor(earliest.Sub(now).Hours()/24)))
+	}
+
+	d.SetId(tenant.TenantId)
+	return nil
+}
@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccSubscriptionDataSource(t *testing.T) {
+	m := newMockServer(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + `
+data "appscan_subscription" "test" {}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_subscription.test", "id", mockTenantID),
+					resource.TestCheckResourceAttr("data.appscan_subscription.test", "max_scans_per_app", "100"),
+					resource.TestCheckResourceAttr("data.appscan_subscription.test", "subscriptions.#", "2"),
+					resource.TestCheckResourceAttr("data.appscan_subscription.test", "subscriptions.0.id", "1001"),
+					resource.TestCheckResourceAttr("data.appscan_subscription.test", "subscriptions.0.expired", "true"),
+					resource.TestCheckResourceAttr("data.appscan_subscription.test", "subscriptions.0.available_seats", "0"),
+					resource.TestCheckResourceAttr("data.appscan_subscription.test", "subscriptions.1.expired", "false"),
+					resource.TestCheckResourceAttr("data.appscan_subscription.test", "subscriptions.1.available_seats", "60"),
+					resource.TestCheckResourceAttr("data.appscan_subscription.test", "subscriptions.1.max_concurrent_scans", "5"),
+					resource.TestCheckResourceAttr("data.appscan_subscription.test", "earliest_expiration_date", "2999-01-01T00:00:00Z"),
+				),
+			},
+		},
+	})
+}
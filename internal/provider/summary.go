@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// runSummary records the outcome of the operations performed during a run
+// in a JSON file, so a run task or a later pipeline stage can parse them
+// without scraping logs.
+//
+// Terraform gives providers no "end of apply" hook and runs every provider
+// configuration, and the plan and the apply, in a process of its own. Each
+// event is therefore merged into the file, under a lock, as it is recorded:
+// once the run is over the file holds the events of all the processes. The
+// file belongs to a single run, identified by runID: the first event of the
+// next run replaces the events of the previous one.
+type runSummary struct {
+	mu    sync.Mutex
+	path  string
+	runID string
+}
+
+// summaryDocument is the content of the summary file.
+type summaryDocument struct {
+	RunID     string         `json:"run_id"`
+	StartedAt time.Time      `json:"started_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	Counts    map[string]int `json:"counts"`
+	Events    []summaryEvent `json:"events"`
+}
+
+// summaryEvent is a single entry of the run summary.
+type summaryEvent struct {
+	Time     time.Time         `json:"time"`
+	Kind     string            `json:"kind"`
+	Resource string            `json:"resource"`
+	ID       string            `json:"id,omitempty"`
+	Details  map[string]string `json:"details,omitempty"`
+}
+
+// newRunSummary returns a summary of the run runID writing to path, or nil
+// when path is empty.
+func newRunSummary(path, runID string) *runSummary {
+	if path == "" {
+		return nil
+	}
+	return &runSummary{path: path, runID: runID}
+}
+
+// defaultSummaryRunID identifies the Terraform command running the provider,
+// which starts all its provider processes, or the Terraform Cloud run, whose
+// plan and apply are separate commands.
+func defaultSummaryRunID() string {
+	if id := os.Getenv("TFC_RUN_ID"); id != "" {
+		return id
+	}
+	ppid := os.Getppid()
+	id := strconv.Itoa(ppid)
+	// PIDs are reused, e.g. by the commands of successive containers: where
+	// /proc has it, the start time of the command tells them apart. It is
+	// the 22nd field of stat, counted from the command name, which may hold
+	// spaces.
+	if stat, err := os.ReadFile(fmt.Sprintf("/proc/%d/stat", ppid)); err == nil {
+		if i := bytes.LastIndexByte(stat, ')'); i >= 0 {
+			if fields := strings.Fields(string(stat[i+1:])); len(fields) > 19 {
+				id += "-" + fields[19]
+			}
+		}
+	}
+	return id
+}
+
+// record adds an event to the summary file.
+// It is a no-op on a nil summary so callers don't need to check whether the
+// feature is enabled. A failure to write the file is logged rather than
+// returned: the API operation it describes has already happened.
+func (s *runSummary) record(kind, resource, id string, details map[string]string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().UTC()
+	event := summaryEvent{
+		Time:     now,
+		Kind:     kind,
+		Resource: resource,
+		ID:       id,
+		Details:  details,
+	}
+	if err := s.merge(event); err != nil {
+		log.Printf("[WARN] unable to write run summary to %s: %s", s.path, err)
+	}
+}
+
+// merge adds event to the summary file, under a lock so that the events
+// recorded concurrently by other processes are kept. A file that is not a
+// summary, or the summary of another run, is replaced.
+func (s *runSummary) merge(event summaryEvent) error {
+	unlock, err := lockFile(s.path + ".lock")
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	doc := summaryDocument{RunID: s.runID, StartedAt: event.Time}
+	body, err := os.ReadFile(s.path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if err == nil {
+		var previous summaryDocument
+		if err := json.Unmarshal(body, &previous); err != nil {
+			log.Printf("[WARN] replacing %s, which is not a run summary: %s", s.path, err)
+		} else if previous.RunID != s.runID {
+			log.Printf("[INFO] replacing the summary of run %q in %s by the summary of run %q", previous.RunID, s.path, s.runID)
+		} else {
+			doc = previous
+		}
+	}
+	if doc.Counts == nil {
+		doc.Counts = map[string]int{}
+	}
+	doc.UpdatedAt = event.Time
+	doc.Counts[event.Kind]++
+	doc.Events = append(doc.Events, event)
+
+	body, err = json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.path, body)
+}
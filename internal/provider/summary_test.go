@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRunSummary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summary.json")
+	read := func() summaryDocument {
+		t.Helper()
+		body, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var doc summaryDocument
+		if err := json.Unmarshal(body, &doc); err != nil {
+			t.Fatal(err)
+		}
+		return doc
+	}
+
+	// A summary per provider process, as with aliases or a plan and its
+	// apply.
+	plan, apply := newRunSummary(path, "run-1"), newRunSummary(path, "run-1")
+	plan.record("drift_detected", "appscan_application", "app-1", map[string]string{"name": `"a" -> "b"`})
+	started := read().StartedAt
+	apply.record("application_updated", "appscan_application", "app-1", nil)
+	plan.record("drift_detected", "appscan_application", "app-2", nil)
+
+	doc := read()
+	if len(doc.Events) != 3 || doc.Events[0].ID != "app-1" || doc.Events[1].Kind != "application_updated" || doc.Events[2].ID != "app-2" {
+		t.Errorf("events = %+v", doc.Events)
+	}
+	if doc.Counts["drift_detected"] != 2 || doc.Counts["application_updated"] != 1 {
+		t.Errorf("counts = %v", doc.Counts)
+	}
+	if !doc.StartedAt.Equal(started) || doc.UpdatedAt.Before(started) {
+		t.Errorf("started_at = %s, updated_at = %s, want started_at %s", doc.StartedAt, doc.UpdatedAt, started)
+	}
+
+	// Files that are not summaries are replaced.
+	if err := os.WriteFile(path, []byte("not json"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	apply.record("application_deleted", "appscan_application", "app-1", nil)
+	if doc := read(); len(doc.Events) != 1 || doc.Counts["application_deleted"] != 1 {
+		t.Errorf("summary = %+v", doc)
+	}
+	// The first event of the next run replaces the summary of the previous
+	// one.
+	next := newRunSummary(path, "run-2")
+	next.record("application_created", "appscan_application", "app-3", nil)
+	if doc := read(); doc.RunID != "run-2" || len(doc.Events) != 1 || doc.Events[0].ID != "app-3" || !doc.StartedAt.Equal(doc.Events[0].Time) {
+		t.Errorf("summary = %+v, want the event of run-2 only", doc)
+	}
+	apply.record("application_deleted", "appscan_application", "app-1", nil)
+	if doc := read(); doc.RunID != "run-1" || len(doc.Events) != 1 {
+		t.Errorf("summary = %+v, want the event of run-1 only", doc)
+	}
+
+	if _, err := os.Stat(path + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("the lock was not removed: %v", err)
+	}
+
+	// A nil summary, when summary_file is not set, records nothing.
+	newRunSummary("", "run-1").record("application_deleted", "appscan_application", "app-1", nil)
+}
+
+func TestDefaultSummaryRunID(t *testing.T) {
+	t.Setenv("TFC_RUN_ID", "")
+	id := defaultSummaryRunID()
+	if id == "" || id != defaultSummaryRunID() {
+		t.Errorf("got run IDs %q and %q, want the same non-empty ID", id, defaultSummaryRunID())
+	}
+	t.Setenv("TFC_RUN_ID", "run-CZcmD7eagjhyX0vN")
+	if id := defaultSummaryRunID(); id != "run-CZcmD7eagjhyX0vN" {
+		t.Errorf("got run ID %q, want TFC_RUN_ID", id)
+	}
+}
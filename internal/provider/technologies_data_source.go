@@ -0,0 +1,171 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// ----------------------------------------------------------------
+// Data Source: appscan_technologies (scan technologies and engines available to the tenant)
+// ----------------------------------------------------------------
+
+// Unlike appscan_health, which reports failures through its attributes,
+// appscan_technologies fails when the API cannot tell, so that modules never
+// skip scans because of a transient error.
+
+func dataSourceTechnologies() *schema.Resource {
+	s := map[string]*schema.Schema{
+		"technologies": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The technologies the tenant is entitled to: dast, sast, sca and iast.",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"presence_allowed": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the tenant may use AppScan Presence to scan private sites.",
+		},
+		"presences": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The AppScan Presences of the tenant, which run DAST scans of private sites.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The ID of the presence.",
+					},
+					"name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The name of the presence.",
+					},
+					"host_name": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The host the presence runs on.",
+					},
+					"status": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "The status of the presence, e.g. Active, Inactive or KeyExpired. Only active presences can run scans.",
+					},
+				},
+			},
+		},
+		"active_presence_ids": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "The IDs of the presences whose status is Active.",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+		"region": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The AppScan on Cloud region of api_endpoint, us or eu, empty for other endpoints.",
+		},
+	}
+	for _, t := range []string{"dast", "sast", "sca", "iast"} {
+		s[t] = &schema.Schema{
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: fmt.Sprintf("Whether the tenant is entitled to %s scans.", t),
+		}
+	}
+
+	return &schema.Resource{
+		ReadWithoutTimeout: withContext(dataSourceTechnologiesRead),
+		Description: "Lists the scan technologies and engines the tenant can use, so that modules create scan resources conditionally, " +
+			"e.g. with `count = data.appscan_technologies.this.sast ? 1 : 0`.",
+		Schema: s,
+	}
+}
+
+// appScanPresence holds the Presence fields the provider relies on.
+type appScanPresence struct {
+	Id           string `json:"Id"`
+	PresenceName string `json:"PresenceName"`
+	HostName     string `json:"HostName"`
+	Status       string `json:"Status"`
+}
+
+func dataSourceTechnologiesRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	tenant, _, message := checkTenant(ctx, client)
+	if tenant == nil {
+		return fmt.Errorf("cannot read the entitlements of the tenant: %s", message)
+	}
+	technologies := parseTechnologies(tenant.ActiveTechnologies)
+	if err := d.Set("technologies", technologies); err != nil {
+		return err
+	}
+	for _, t := range []string{"dast", "sast", "sca", "iast"} {
+		d.Set(t, slices.Contains(technologies, t))
+	}
+	d.Set("presence_allowed", tenant.AllowPresence)
+
+	presences, err := listPresences(ctx, client)
+	if err != nil {
+		return err
+	}
+	list := make([]interface{}, len(presences))
+	active := []string{}
+	for i, p := range presences {
+		list[i] = map[string]interface{}{
+			"id":        p.Id,
+			"name":      p.PresenceName,
+			"host_name": p.HostName,
+			"status":    p.Status,
+		}
+		if p.Status == "Active" {
+			active = append(active, p.Id)
+		}
+	}
+	if err := d.Set("presences", list); err != nil {
+		return err
+	}
+	if err := d.Set("active_presence_ids", active); err != nil {
+		return err
+	}
+
+	region := ""
+	for name, endpoint := range appscan.RegionEndpoints {
+		if endpoint == client.ApiEndpoint {
+			region = name
+		}
+	}
+	d.Set("region", region)
+	d.SetId(tenant.TenantId)
+	return nil
+}
+
+// listPresences lists the presences of the tenant, sorted by name.
+func listPresences(ctx context.Context, client *AppScanClient) ([]appScanPresence, error) {
+	var presences []appScanPresence
+	query := url.Values{}
+	query.Set("$orderby", "PresenceName")
+	for skip := 0; ; skip += catalogPageSize {
+		query.Set("$top", strconv.Itoa(catalogPageSize))
+		query.Set("$skip", strconv.Itoa(skip))
+
+		var result struct {
+			Items []appScanPresence `json:"Items"`
+		}
+		if err := getODataPage(ctx, client, "Presences", query, &result); err != nil {
+			return nil, err
+		}
+		presences = append(presences, result.Items...)
+		if len(result.Items) < catalogPageSize {
+			return presences, nil
+		}
+	}
+}
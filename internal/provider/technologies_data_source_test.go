@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTechnologiesDataSource(t *testing.T) {
+	m := newMockServer(t)
+	m.add("Presences", mockEntity{"Id": mockPresenceID, "PresenceName": "dmz", "HostName": "scanner.internal", "Status": "Active"})
+	m.add("Presences", mockEntity{"Id": "77777777-7777-7777-7777-777777777778", "PresenceName": "lab", "Status": "KeyExpired"})
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig(m) + `
+data "appscan_technologies" "test" {}
+`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_technologies.test", "id", mockTenantID),
+					resource.TestCheckResourceAttr("data.appscan_technologies.test", "technologies.#", "2"),
+					resource.TestCheckResourceAttr("data.appscan_technologies.test", "dast", "true"),
+					resource.TestCheckResourceAttr("data.appscan_technologies.test", "sast", "true"),
+					resource.TestCheckResourceAttr("data.appscan_technologies.test", "sca", "false"),
+					resource.TestCheckResourceAttr("data.appscan_technologies.test", "iast", "false"),
+					resource.TestCheckResourceAttr("data.appscan_technologies.test", "presence_allowed", "true"),
+					resource.TestCheckResourceAttr("data.appscan_technologies.test", "presences.#", "2"),
+					resource.TestCheckResourceAttr("data.appscan_technologies.test", "presences.0.name", "dmz"),
+					resource.TestCheckResourceAttr("data.appscan_technologies.test", "presences.0.host_name", "scanner.internal"),
+					resource.TestCheckResourceAttr("data.appscan_technologies.test", "presences.1.status", "KeyExpired"),
+					resource.TestCheckResourceAttr("data.appscan_technologies.test", "active_presence_ids.#", "1"),
+					resource.TestCheckResourceAttr("data.appscan_technologies.test", "active_presence_ids.0", mockPresenceID),
+					resource.TestCheckResourceAttr("data.appscan_technologies.test", "region", ""),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Terraform runs every provider configuration, and the plan and the apply,
+// in a plugin process of its own. When token_cache_dir is set, the token of
+// an ApiKeyLogin is stored in a file of that directory, so that the aliases
+// configured with the same API key, and the apply after the plan, reuse it
+// rather than logging in each, which counts against the token quota of the
+// key.
+
+// tokenReuseMargin is how long before its expiry a cached token stops being
+// handed out, so that the operations of the run do not start with a token
+// about to expire.
+const tokenReuseMargin = 10 * time.Minute
+
+// tokenCache stores access tokens in files of dir, by API base and API key.
+type tokenCache struct {
+	dir string
+}
+
+// cachedToken is the content of a token cache file.
+type cachedToken struct {
+	Token  string `json:"token"`
+	Expiry string `json:"expiry"`
+}
+
+// get returns the token of the API key at apiBase, calling login when none
+// is cached or the cached one expires within tokenReuseMargin. The cache
+// file is locked during the login, so that the processes configured
+// concurrently wait for it rather than logging in too. Failed logins are
+// not cached. The secret is part of the key, hashed, so that a wrong secret
+// never gets the token of the right one.
+func (c *tokenCache) get(apiBase, keyID, keySecret string, login func() (string, string, error)) (string, string, error) {
+	secret := sha256.Sum256([]byte(keySecret))
+	key := sha256.Sum256([]byte(apiBase + "\x00" + keyID + "\x00" + hex.EncodeToString(secret[:])))
+	path := filepath.Join(c.dir, hex.EncodeToString(key[:])+".json")
+
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return "", "", fmt.Errorf("create token_cache_dir: %w", err)
+	}
+	unlock, err := lockFile(path + ".lock")
+	if err != nil {
+		return "", "", err
+	}
+	defer unlock()
+
+	if body, err := os.ReadFile(path); err == nil {
+		var cached cachedToken
+		if json.Unmarshal(body, &cached) == nil && cached.Token != "" && tokenReusable(cached.Expiry, time.Now()) {
+			return cached.Token, cached.Expiry, nil
+		}
+	}
+	token, expiry, err := login()
+	if err != nil {
+		return "", "", err
+	}
+	body, err := json.Marshal(cachedToken{Token: token, Expiry: expiry})
+	if err != nil {
+		return "", "", err
+	}
+	if err := writeFileAtomic(path, body); err != nil {
+		return "", "", fmt.Errorf("write token cache: %w", err)
+	}
+	return token, expiry, nil
+}
+
+// tokenReusable tells whether a token expiring at expiry, an RFC 3339 date,
+// is valid for tokenReuseMargin after now. Tokens of unknown expiry are
+// not reused.
+func tokenReusable(expiry string, now time.Time) bool {
+	t, err := time.Parse(time.RFC3339, expiry)
+	return err == nil && t.After(now.Add(tokenReuseMargin))
+}
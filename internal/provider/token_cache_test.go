@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccProvider_tokenCache(t *testing.T) {
+	m := newMockServer(t)
+	dir := t.TempDir()
+	provider := func(alias, cacheDir string) string {
+		return fmt.Sprintf(`
+provider "appscan" {
+  alias           = %q
+  api_endpoint    = %q
+  key_id          = %q
+  key_secret      = %q
+  token_cache_dir = %q
+}
+
+data "appscan_health" %q {
+  provider = appscan.%s
+}
+`, alias, m.URL, mockKeyID, mockKeySecret, cacheDir, alias, alias)
+	}
+	// Each step configures the providers several times: for the plan, the
+	// apply and the refreshes.
+	checkLogins := func(shared bool) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			logins := m.requests["POST /api/v4/Account/ApiKeyLogin"]
+			if shared && logins != 1 {
+				return fmt.Errorf("the aliases logged in %d times, want 1", logins)
+			}
+			if !shared && logins < 2 {
+				return fmt.Errorf("the aliases logged in %d times, want one login per configuration", logins)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: provider("a", dir) + provider("b", dir),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_health.a", "authenticated", "true"),
+					resource.TestCheckResourceAttr("data.appscan_health.b", "authenticated", "true"),
+					checkLogins(true),
+				),
+			},
+			{
+				PreConfig: func() {
+					m.mu.Lock()
+					defer m.mu.Unlock()
+					m.requests = map[string]int{}
+				},
+				Config: provider("a", "") + provider("b", ""),
+				Check:  checkLogins(false),
+			},
+		},
+	})
+}
+
+func TestTokenCache(t *testing.T) {
+	dir := t.TempDir()
+	logins := 0
+	expiry := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	login := func() (string, string, error) {
+		logins++
+		return fmt.Sprintf("token-%d", logins), expiry, nil
+	}
+	get := func(keyID, keySecret, expected string) {
+		t.Helper()
+		// A cache per call, as each provider process has its own.
+		cache := &tokenCache{dir: dir}
+		token, _, err := cache.get("https://cloud.appscan.com/api/v4", keyID, keySecret, login)
+		if err != nil || token != expected {
+			t.Errorf("get(%q, %q) = %q, %v, want %q", keyID, keySecret, token, err, expected)
+		}
+	}
+
+	get("key", "secret", "token-1")
+	get("key", "secret", "token-1")
+	get("key", "other-secret", "token-2")
+	get("other-key", "secret", "token-3")
+
+	// Tokens about to expire are not reused.
+	expiry = time.Now().Add(tokenReuseMargin / 2).UTC().Format(time.RFC3339)
+	get("key", "secret", "token-1")
+	get("new-key", "secret", "token-4")
+	get("new-key", "secret", "token-5")
+
+	// Failed logins are not cached.
+	failed := errors.New("invalid API key")
+	cache := &tokenCache{dir: dir}
+	if _, _, err := cache.get("https://cloud.appscan.com/api/v4", "bad", "secret", func() (string, string, error) {
+		return "", "", failed
+	}); !errors.Is(err, failed) {
+		t.Errorf("get() = %v, want %v", err, failed)
+	}
+	get("bad", "secret", "token-6")
+
+	// The files hold no key ID and are readable by their owner only.
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if strings.Contains(e.Name(), "key") || strings.HasSuffix(e.Name(), ".lock") || info.Mode().Perm() != 0o600 {
+			t.Errorf("unexpected cache file %s (%s)", e.Name(), info.Mode().Perm())
+		}
+	}
+}
+
+func TestTokenReusable(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	for expiry, expected := range map[string]bool{
+		"2026-01-01T13:00:00Z":          true,
+		"2026-01-01T13:00:00.123+01:00": false,
+		"2026-01-01T12:05:00Z":          false,
+		"2025-12-31T12:00:00Z":          false,
+		"":                              false,
+		"tomorrow":                      false,
+	} {
+		if actual := tokenReusable(expiry, now); actual != expected {
+			t.Errorf("tokenReusable(%q) = %t, want %t", expiry, actual, expected)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// AppScan on Cloud only authenticates API keys: it has no federation with
+// the identity providers of CI systems. A token exchange service run by the
+// organization, which holds the API key, can trade the OIDC token of a CI
+// job for an AppScan access token (OAuth 2.0 Token Exchange, RFC 8693), so
+// that pipelines carry no long-lived secret. The service decides which jobs,
+// e.g. which repositories and branches, get a token.
+
+const (
+	tokenExchangeGrantType = "urn:ietf:params:oauth:grant-type:token-exchange"
+	jwtTokenType           = "urn:ietf:params:oauth:token-type:jwt"
+)
+
+// ciOIDCToken returns the OIDC token of the CI job: oidcToken when set, e.g.
+// from an id_tokens variable of GitLab CI, else the token GitHub Actions
+// issues for audience.
+func ciOIDCToken(client *http.Client, oidcToken, audience string) (string, error) {
+	if oidcToken != "" {
+		return oidcToken, nil
+	}
+	requestURL, requestToken := os.Getenv("ACTIONS_ID_TOKEN_REQUEST_URL"), os.Getenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN")
+	if requestURL == "" || requestToken == "" {
+		return "", fmt.Errorf("token_exchange_url requires oidc_token, or a GitHub Actions job with the id-token: write permission")
+	}
+
+	u, err := url.Parse(requestURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid ACTIONS_ID_TOKEN_REQUEST_URL: %w", err)
+	}
+	query := u.Query()
+	query.Set("audience", audience)
+	u.RawQuery = query.Encode()
+	req, err := http.NewRequest("GET", u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+requestToken)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get the OIDC token of the GitHub Actions job: %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	var result struct {
+		Value string `json:"value"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil || result.Value == "" {
+		return "", fmt.Errorf("failed to get the OIDC token of the GitHub Actions job: unexpected response")
+	}
+	return result.Value, nil
+}
+
+// exchangeToken trades an OIDC token for an AppScan access token at a token
+// exchange endpoint, and returns the access token with its expiry date,
+// empty when the endpoint does not tell.
+func exchangeToken(client *http.Client, exchangeURL, subjectToken string) (string, string, error) {
+	form := url.Values{}
+	form.Set("grant_type", tokenExchangeGrantType)
+	form.Set("subject_token", subjectToken)
+	form.Set("subject_token_type", jwtTokenType)
+	req, err := http.NewRequest("POST", exchangeURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", "", err
+	}
+	var result struct {
+		AccessToken      string `json:"access_token"`
+		ExpiresIn        int    `json:"expires_in"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	jsonErr := json.Unmarshal(respBody, &result)
+	if resp.StatusCode != http.StatusOK {
+		if jsonErr == nil && result.Error != "" {
+			return "", "", fmt.Errorf("token exchange refused (%s): %s: %s", resp.Status, result.Error, result.ErrorDescription)
+		}
+		return "", "", fmt.Errorf("token exchange failed: %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	if jsonErr != nil || result.AccessToken == "" {
+		return "", "", fmt.Errorf("failed to obtain an access token from the token exchange response")
+	}
+
+	expiry := ""
+	if result.ExpiresIn > 0 {
+		expiry = time.Now().UTC().Add(time.Duration(result.ExpiresIn) * time.Second).Format(time.RFC3339)
+	}
+	return result.AccessToken, expiry, nil
+}
@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const mockOIDCToken = "mock-oidc-token"
+
+// newMockTokenExchange serves a token exchange endpoint trading
+// mockOIDCToken for mockToken.
+func newMockTokenExchange(t *testing.T) *httptest.Server {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("grant_type") != tokenExchangeGrantType || r.FormValue("subject_token_type") != jwtTokenType {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+			return
+		}
+		if r.FormValue("subject_token") != mockOIDCToken {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant", "error_description": "the repository is not allowed"})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"access_token":      mockToken,
+			"issued_token_type": "urn:ietf:params:oauth:token-type:access_token",
+			"token_type":        "Bearer",
+			"expires_in":        3600,
+		})
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestExchangeToken(t *testing.T) {
+	s := newMockTokenExchange(t)
+
+	token, expiry, err := exchangeToken(s.Client(), s.URL, mockOIDCToken)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != mockToken || expiry == "" {
+		t.Errorf("got token %q expiring at %q, want %q with an expiry", token, expiry, mockToken)
+	}
+
+	_, _, err = exchangeToken(s.Client(), s.URL, "other-token")
+	if want := "token exchange refused (400 Bad Request): invalid_grant: the repository is not allowed"; err == nil || err.Error() != want {
+		t.Errorf("got error %v, want %q", err, want)
+	}
+}
+
+func TestCIOIDCToken(t *testing.T) {
+	t.Setenv("ACTIONS_ID_TOKEN_REQUEST_URL", "")
+	t.Setenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN", "")
+	if token, err := ciOIDCToken(http.DefaultClient, "configured", "appscan"); err != nil || token != "configured" {
+		t.Errorf("got %q, %v, want the configured token", token, err)
+	}
+	if _, err := ciOIDCToken(http.DefaultClient, "", "appscan"); err == nil || !strings.Contains(err.Error(), "id-token: write") {
+		t.Errorf("got error %v outside GitHub Actions, want one asking for oidc_token", err)
+	}
+
+	github := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer request-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"value": "github-" + r.URL.Query().Get("audience")})
+	}))
+	defer github.Close()
+	t.Setenv("ACTIONS_ID_TOKEN_REQUEST_URL", github.URL+"/token?api-version=2.0")
+	t.Setenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN", "request-token")
+	if token, err := ciOIDCToken(github.Client(), "", "appscan"); err != nil || token != "github-appscan" {
+		t.Errorf("got %q, %v, want the token of GitHub Actions", token, err)
+	}
+}
+
+func TestAccProvider_tokenExchange(t *testing.T) {
+	m := newMockServer(t)
+	exchange := newMockTokenExchange(t)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+provider "appscan" {
+  api_endpoint       = %q
+  token_exchange_url = %q
+  oidc_token         = %q
+}
+
+data "appscan_auth_token" "test" {}
+
+data "appscan_asset_group" "default" {
+  name = "Default Asset Group"
+}
+`, m.URL, exchange.URL, mockOIDCToken),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.appscan_auth_token.test", "token", mockToken),
+					resource.TestCheckResourceAttrSet("data.appscan_auth_token.test", "expires_at"),
+					resource.TestCheckResourceAttr("data.appscan_asset_group.default", "id", mockAssetGroupID),
+				),
+			},
+		},
+	})
+}
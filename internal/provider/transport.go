@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	// maxIdleConnsPerHost keeps enough idle connections to the API for
+	// Terraform to refresh resources in parallel (10 at a time by default)
+	// without opening new ones.
+	maxIdleConnsPerHost = 16
+
+	// responseHeaderTimeout bounds the wait for the API, or a proxy, to
+	// answer a request. The upload of big files is not bounded: the timeout
+	// starts once the request is written.
+	responseHeaderTimeout = 5 * time.Minute
+)
+
+// newHTTPTransport builds the transport shared by every API call from the
+// proxy and TLS provider arguments. Its connections are pooled across the
+// calls of all resources.
+func newHTTPTransport(d *schema.ResourceData) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConnsPerHost = maxIdleConnsPerHost
+	transport.ResponseHeaderTimeout = responseHeaderTimeout
+
+	if proxy, ok := d.GetOk("proxy_url"); ok {
+		proxyURL, err := url.Parse(proxy.(string))
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy_url: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	tlsConfig := &tls.Config{}
+	if d.Get("insecure_skip_verify").(bool) {
+		tlsConfig.InsecureSkipVerify = true
+	}
+
+	pem := d.Get("ca_cert_pem").(string)
+	if file, ok := d.GetOk("ca_cert_file"); ok {
+		content, err := os.ReadFile(file.(string))
+		if err != nil {
+			return nil, fmt.Errorf("unable to read ca_cert_file: %w", err)
+		}
+		pem = string(content)
+	}
+	if pem != "" {
+		// Trust the custom CA on top of the system roots.
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(pem)) {
+			return nil, fmt.Errorf("no valid certificate found in the provided CA bundle")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
+}
+
+// apiTransport adds the headers every API call shares: the User-Agent, the
+// Accept-Language, the extra_headers and, once known, the bearer token. The
+// token and the extra headers are only sent to the host of the API, never to
+// the hosts of links the API returns. The token is set before the client is
+// shared, so the transport is safe for concurrent use.
+type apiTransport struct {
+	transport      http.RoundTripper
+	host           string
+	token          string
+	userAgent      string
+	acceptLanguage string
+	headers        map[string]string
+}
+
+func (t *apiTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// A RoundTripper must not modify the request it is given.
+	req = req.Clone(req.Context())
+	req.Header.Set("User-Agent", t.userAgent)
+	if t.acceptLanguage != "" {
+		req.Header.Set("Accept-Language", t.acceptLanguage)
+	}
+	if req.URL.Host == t.host {
+		for k, v := range t.headers {
+			req.Header.Set(k, v)
+		}
+		if t.token != "" {
+			req.Header.Set("Authorization", "Bearer "+t.token)
+		}
+	}
+	return t.transport.RoundTrip(req)
+}
+
+// headerNameRegexp matches the names of HTTP headers (RFC 9110 tokens).
+var headerNameRegexp = regexp.MustCompile("^[A-Za-z0-9!#$%&'*+.^_`|~-]+$")
+
+// reservedHeaders are the headers the provider sets itself, which
+// extra_headers may not override.
+var reservedHeaders = []string{"Authorization", "User-Agent", "Accept-Language", "Accept", "Content-Type", "Content-Length", "Host"}
+
+// validateExtraHeaders checks that the keys of extra_headers are header
+// names the provider does not set itself.
+func validateExtraHeaders(v interface{}, k string) ([]string, []error) {
+	var errs []error
+	for name := range v.(map[string]interface{}) {
+		if !headerNameRegexp.MatchString(name) {
+			errs = append(errs, fmt.Errorf("%s: %q is not a valid header name", k, name))
+			continue
+		}
+		for _, reserved := range reservedHeaders {
+			if strings.EqualFold(name, reserved) {
+				errs = append(errs, fmt.Errorf("%s: %s is set by the provider and cannot be overridden", k, reserved))
+			}
+		}
+	}
+	return nil, errs
+}
@@ -0,0 +1,79 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+func TestAPITransport(t *testing.T) {
+	var headers []http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Clone())
+	}))
+	defer server.Close()
+	// Another host, such as the one of a pre-signed download link.
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = append(headers, r.Header.Clone())
+	}))
+	defer other.Close()
+
+	base, _ := url.Parse(server.URL)
+	client := &http.Client{Transport: &apiTransport{
+		transport:      http.DefaultTransport,
+		host:           base.Host,
+		token:          "secret-token",
+		userAgent:      "terraform-provider-appscan/1.2.3",
+		acceptLanguage: "fr-FR",
+		headers:        map[string]string{"X-Gateway-Key": "gateway-secret"},
+	}}
+	for _, u := range []string{server.URL, other.URL} {
+		req, _ := http.NewRequest("GET", u, nil)
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if len(req.Header) != 0 {
+			t.Errorf("the request given to the transport was modified: %v", req.Header)
+		}
+	}
+
+	if got := headers[0].Get("Authorization"); got != "Bearer secret-token" {
+		t.Errorf("Authorization = %q, want the bearer token", got)
+	}
+	if got := headers[1].Get("Authorization"); got != "" {
+		t.Errorf("Authorization = %q sent to another host", got)
+	}
+	if got := headers[0].Get("X-Gateway-Key"); got != "gateway-secret" {
+		t.Errorf("X-Gateway-Key = %q, want the extra header", got)
+	}
+	if got := headers[1].Get("X-Gateway-Key"); got != "" {
+		t.Errorf("X-Gateway-Key = %q sent to another host", got)
+	}
+	for _, h := range headers {
+		if got := h.Get("User-Agent"); got != "terraform-provider-appscan/1.2.3" {
+			t.Errorf("User-Agent = %q", got)
+		}
+		if got := h.Get("Accept-Language"); got != "fr-FR" {
+			t.Errorf("Accept-Language = %q", got)
+		}
+	}
+}
+
+func TestValidateExtraHeaders(t *testing.T) {
+	for name, valid := range map[string]bool{
+		"X-Gateway-Key":    true,
+		"x-request-source": true,
+		"Bad Header":       false,
+		"":                 false,
+		"authorization":    false,
+		"User-Agent":       false,
+	} {
+		_, errs := validateExtraHeaders(map[string]interface{}{name: "value"}, "extra_headers")
+		if (len(errs) == 0) != valid {
+			t.Errorf("validateExtraHeaders(%q) = %v, want valid %t", name, errs, valid)
+		}
+	}
+}
@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// The API assigns asset groups to users, not users to asset groups: updating
+// a user replaces the whole list of its asset groups, and no endpoint returns
+// the members of an asset group, nor the asset groups of a user. Membership is
+// therefore managed per user, authoritatively, and asset_group_ids is kept as
+// configured since it cannot be read back.
+
+func resourceAppScanUserAssetGroups() *schema.Resource {
+	return &schema.Resource{
+		CreateWithoutTimeout: withContext(resourceAppScanUserAssetGroupsUpdate),
+		ReadWithoutTimeout:   withContext(resourceAppScanUserAssetGroupsRead),
+		UpdateWithoutTimeout: withContext(resourceAppScanUserAssetGroupsUpdate),
+		DeleteWithoutTimeout: withContext(resourceAppScanUserAssetGroupsDelete),
+		Schema: map[string]*schema.Schema{
+			"user_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The ID of the user.",
+				ValidateFunc: validateGUID,
+			},
+			"asset_group_ids": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "The IDs of the asset groups the user is assigned to. They replace the user's previous asset groups. Since the API does not return them, changes made outside Terraform are not detected.",
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validateGUID},
+				Set:         hashEqualFold,
+			},
+			"role_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "The ID of the role of the user. If omitted, the role is left unchanged.",
+				ValidateFunc: validation.Any(validation.StringIsEmpty, validateGUID),
+			},
+			"user_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The user name of the user.",
+			},
+			"role_name": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The name of the role of the user.",
+			},
+		},
+	}
+}
+
+// appScanUser holds the UserModel fields the provider relies on.
+type appScanUser struct {
+	Id       string `json:"Id"`
+	UserName string `json:"UserName"`
+	Email    string `json:"Email"`
+	RoleId   string `json:"RoleId"`
+	RoleName string `json:"RoleName"`
+}
+
+func resourceAppScanUserAssetGroupsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+	userID := d.Get("user_id").(string)
+
+	ids := []string{}
+	for _, id := range d.Get("asset_group_ids").(*schema.Set).List() {
+		ids = append(ids, id.(string))
+	}
+	payload := appScanUserUpdate{AssetGroupIds: ids}
+	if d.HasChange("role_id") {
+		payload.RoleId = d.Get("role_id").(string)
+	}
+	if err := updateUser(ctx, client, userID, payload); err != nil {
+		return err
+	}
+
+	d.SetId(userID)
+	client.Summary.record("user_asset_groups_updated", "appscan_user_asset_groups", userID, map[string]string{
+		"asset_groups": fmt.Sprint(len(ids)),
+	})
+	return resourceAppScanUserAssetGroupsRead(ctx, d, m)
+}
+
+func resourceAppScanUserAssetGroupsRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	user, err := getUser(ctx, client, d.Id())
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		d.SetId("")
+		return nil
+	}
+	d.Set("user_id", user.Id)
+	d.Set("user_name", user.UserName)
+	d.Set("role_id", user.RoleId)
+	d.Set("role_name", user.RoleName)
+	return nil
+}
+
+func resourceAppScanUserAssetGroupsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	// A user removed from the organization has no asset groups left.
+	err := updateUser(ctx, client, d.Id(), appScanUserUpdate{AssetGroupIds: []string{}})
+	var apiErr *appscan.APIError
+	if err != nil && !(errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound) {
+		return err
+	}
+	client.Summary.record("user_asset_groups_removed", "appscan_user_asset_groups", d.Id(), nil)
+	return nil
+}
+
+// appScanUserUpdate is the UpdateUserModel payload. The role is left
+// unchanged when empty.
+type appScanUserUpdate struct {
+	AssetGroupIds []string `json:"AssetGroupIds"`
+	RoleId        string   `json:"RoleId,omitempty"`
+}
+
+// updateUser sends an UpdateUserModel for a user.
+func updateUser(ctx context.Context, client *AppScanClient, id string, payload appScanUserUpdate) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	urlStr := fmt.Sprintf("%s/User/%s", client.ApiBase, url.PathEscape(id))
+	req, err := client.newRequest(ctx, "PUT", urlStr, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		return appscan.NewAPIError("update user", resp)
+	}
+	return nil
+}
+
+// getUser looks a user of the organization up by ID. It returns nil when
+// the user does not exist.
+func getUser(ctx context.Context, client *AppScanClient, id string) (*appScanUser, error) {
+	filterQuery, err := client.odataEqID(ctx, "Id", id)
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	query.Set("$filter", filterQuery)
+
+	var result struct {
+		Items []appScanUser `json:"Items"`
+	}
+	if err := getODataPage(ctx, client, "User", query, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Items) == 0 {
+		return nil, nil
+	}
+	return &result.Items[0], nil
+}
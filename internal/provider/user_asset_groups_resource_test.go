@@ -0,0 +1,69 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+const mockRoleID = "88888888-8888-8888-8888-888888888888"
+
+func TestAccUserAssetGroupsResource(t *testing.T) {
+	m := newMockServer(t)
+	user := m.add("Users", mockEntity{"UserName": "alice@example.com", "RoleId": mockRoleID, "RoleName": "Developer"})
+	userID := user["Id"].(string)
+	otherGroup := "22222222-2222-2222-2222-222222222222"
+
+	assetGroups := func(expected ...string) resource.TestCheckFunc {
+		return func(*terraform.State) error {
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			actual := fmt.Sprint(m.find("Users", userID)["AssetGroupIds"])
+			if want := fmt.Sprint(expected); actual != want {
+				return fmt.Errorf("expected asset groups %s, got %s", want, actual)
+			}
+			return nil
+		}
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      assetGroups(),
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccUserAssetGroupsConfig(m, "not-a-guid", fmt.Sprintf("%q", mockAssetGroupID)),
+				ExpectError: regexp.MustCompile(`"user_id" must be a GUID`),
+			},
+			{
+				Config: testAccUserAssetGroupsConfig(m, userID, fmt.Sprintf("%q", mockAssetGroupID)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_user_asset_groups.test", "id", userID),
+					resource.TestCheckResourceAttr("appscan_user_asset_groups.test", "user_name", "alice@example.com"),
+					resource.TestCheckResourceAttr("appscan_user_asset_groups.test", "role_id", mockRoleID),
+					resource.TestCheckResourceAttr("appscan_user_asset_groups.test", "role_name", "Developer"),
+					assetGroups(mockAssetGroupID),
+				),
+			},
+			{
+				Config: testAccUserAssetGroupsConfig(m, userID, fmt.Sprintf("%q", otherGroup)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_user_asset_groups.test", "asset_group_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttr("appscan_user_asset_groups.test", "asset_group_ids.*", otherGroup),
+					assetGroups(otherGroup),
+				),
+			},
+		},
+	})
+}
+
+func testAccUserAssetGroupsConfig(m *mockServer, userID, assetGroupIDs string) string {
+	return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_user_asset_groups" "test" {
+  user_id         = %q
+  asset_group_ids = [%s]
+}
+`, userID, assetGroupIDs)
+}
@@ -0,0 +1,170 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Every asynchronous operation (scan executions, including the analysis of
+// IRX files, report generation...) is polled through waitFor, with settings
+// resolved by waitSettingsFor from the resource's overrides, the provider
+// defaults and the operation defaults. waitFor reports the progress of the
+// operation through tflog as it changes.
+
+// waitSettings controls how an asynchronous operation is polled.
+type waitSettings struct {
+	pollInterval time.Duration
+	maxWait      time.Duration
+}
+
+// waitSchema returns the per-resource overrides of the provider's
+// poll_interval and max_wait.
+func waitSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"poll_interval": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validatePositiveDuration,
+			Description:  "How often the asynchronous operation of the resource is polled, as a duration such as `30s`. Overrides the provider's poll_interval.",
+		},
+		"max_wait": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validatePositiveDuration,
+			Description:  "How long to wait for the asynchronous operation of the resource, as a duration such as `2h`. Overrides the provider's max_wait and the create timeout.",
+		},
+	}
+}
+
+// waitSettingsFor resolves the settings of an operation of resource d: its
+// poll_interval/max_wait arguments first, then the provider's, then
+// defaultPollInterval and the create timeout.
+func (c *AppScanClient) waitSettingsFor(d *schema.ResourceData, defaultPollInterval time.Duration) waitSettings {
+	s := waitSettings{pollInterval: c.PollInterval, maxWait: c.MaxWait}
+	if s.pollInterval == 0 {
+		s.pollInterval = defaultPollInterval
+	}
+	if s.maxWait == 0 {
+		s.maxWait = d.Timeout(schema.TimeoutCreate)
+	}
+	// Both arguments passed validatePositiveDuration.
+	if v, ok := d.GetOk("poll_interval"); ok {
+		s.pollInterval, _ = time.ParseDuration(v.(string))
+	}
+	if v, ok := d.GetOk("max_wait"); ok {
+		s.maxWait, _ = time.ParseDuration(v.(string))
+	}
+	return s
+}
+
+// pollFunc reports the current state of an asynchronous operation and its
+// progress as a percentage, or -1 when the API does not report it.
+type pollFunc func() (result interface{}, state string, progress int, err error)
+
+// waitFor polls poll every settings.pollInterval until it reports one of the
+// target states, give or take a tenth of it, drawn for each operation so that
+// the runs polling together do not hit the API in lockstep. It fails when
+// poll returns an error, reports a state that is neither pending nor target,
+// or when settings.maxWait elapses. Polls throttled by the API once the
+// transport has given up retrying them are skipped rather than failing. Each
+// change of state or progress is logged at INFO level.
+func (c *AppScanClient) waitFor(ctx context.Context, what string, settings waitSettings, pending, target []string, poll pollFunc) (interface{}, error) {
+	start := time.Now()
+	lastState, lastProgress := "", -1
+	var lastResult interface{}
+	interval := settings.pollInterval - settings.pollInterval/10 + jitter(settings.pollInterval)
+	refresh := func() (interface{}, string, error) {
+		result, state, progress, err := poll()
+		// A poll still throttled once its retries are exhausted is not a
+		// failure of the operation: it is polled again at the next interval.
+		var apiErr *appscan.APIError
+		if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests && lastResult != nil {
+			log.Printf("[WARN] polling %s was throttled by the API, polling again in %s", what, interval)
+			return lastResult, lastState, nil
+		}
+		if err == nil {
+			lastResult = result
+		}
+		if err == nil && (state != lastState || progress != lastProgress) {
+			fields := map[string]interface{}{
+				"operation": what,
+				"state":     state,
+				"elapsed":   time.Since(start).Round(time.Second).String(),
+			}
+			if progress >= 0 {
+				fields["progress_percent"] = progress
+			}
+			tflog.Info(ctx, "AppScan operation progress", fields)
+			lastState, lastProgress = state, progress
+		}
+		return result, state, err
+	}
+
+	stateConf := &retry.StateChangeConf{
+		Pending:      pending,
+		Target:       target,
+		Refresh:      refresh,
+		Timeout:      settings.maxWait,
+		Delay:        interval,
+		PollInterval: interval,
+	}
+	raw, err := stateConf.WaitForStateContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error waiting for %s: %w", what, err)
+	}
+	return raw, nil
+}
+
+// The list endpoints may not return an entity for a few seconds after its
+// creation. The reads following a create would then take it for deleted and
+// remove it from the state, failing the apply, so the resources whose reads
+// list their entity by ID wait for it to be listed first.
+const (
+	listingPollInterval = time.Second
+	listingTimeout      = 30 * time.Second
+)
+
+// awaitListed waits up to listingTimeout for an entity just created, what,
+// to be listed. listed reports whether the list endpoint returns it.
+func (c *AppScanClient) awaitListed(ctx context.Context, what string, listed func() (bool, error)) error {
+	ok, err := listed()
+	if err != nil || ok {
+		return err
+	}
+	log.Printf("[DEBUG] %s is not listed yet, waiting for it", what)
+	settings := waitSettings{pollInterval: listingPollInterval, maxWait: listingTimeout}
+	_, err = c.waitFor(ctx, what+" to be listed", settings, []string{"unlisted"}, []string{"listed"},
+		func() (interface{}, string, int, error) {
+			ok, err := listed()
+			if err != nil {
+				return nil, "", -1, err
+			}
+			if !ok {
+				return false, "unlisted", -1, nil
+			}
+			return true, "listed", -1, nil
+		})
+	return err
+}
+
+// validatePositiveDuration checks that a string argument is a positive
+// duration such as "30s" or "2h".
+func validatePositiveDuration(v interface{}, k string) ([]string, []error) {
+	d, err := time.ParseDuration(v.(string))
+	if err != nil {
+		return nil, []error{fmt.Errorf("%q must be a duration such as 30s or 2h: %s", k, err)}
+	}
+	if d <= 0 {
+		return nil, []error{fmt.Errorf("%q must be positive, got %s", k, v)}
+	}
+	return nil, nil
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-log/tflogtest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestWaitSettingsFor(t *testing.T) {
+	resource := resourceAppScanReport()
+	// Without a timeouts block, the create timeout is the SDK default of 20
+	// minutes.
+	cases := []struct {
+		name         string
+		client       *AppScanClient
+		raw          map[string]interface{}
+		pollInterval time.Duration
+		maxWait      time.Duration
+	}{
+		{"defaults", &AppScanClient{}, nil, reportPollInterval, 20 * time.Minute},
+		{"provider", &AppScanClient{PollInterval: time.Minute, MaxWait: time.Hour}, nil, time.Minute, time.Hour},
+		{
+			"resource",
+			&AppScanClient{PollInterval: time.Minute, MaxWait: time.Hour},
+			map[string]interface{}{"poll_interval": "2s", "max_wait": "90m"},
+			2 * time.Second,
+			90 * time.Minute,
+		},
+	}
+	for _, c := range cases {
+		d := schema.TestResourceDataRaw(t, resource.Schema, c.raw)
+		s := c.client.waitSettingsFor(d, reportPollInterval)
+		if s.pollInterval != c.pollInterval || s.maxWait != c.maxWait {
+			t.Errorf("%s: got poll interval %s and max wait %s, want %s and %s", c.name, s.pollInterval, s.maxWait, c.pollInterval, c.maxWait)
+		}
+	}
+}
+
+func TestWaitForProgress(t *testing.T) {
+	var logs bytes.Buffer
+	ctx := tflogtest.RootLogger(context.Background(), &logs)
+	client := &AppScanClient{}
+	polls := []struct {
+		state    string
+		progress int
+	}{
+		{"Running", 10}, {"Running", 10}, {"Running", 50}, {"Running", 50}, {"Ready", 100},
+	}
+	settings := waitSettings{pollInterval: time.Millisecond, maxWait: time.Minute}
+	raw, err := client.waitFor(ctx, "report 1", settings, []string{"Running"}, []string{"Ready"},
+		func() (interface{}, string, int, error) {
+			p := polls[0]
+			if len(polls) > 1 {
+				polls = polls[1:]
+			}
+			return p.progress, p.state, p.progress, nil
+		})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raw.(int) != 100 {
+		t.Errorf("got %v, want the result of the last poll", raw)
+	}
+
+	entries, err := tflogtest.MultilineJSONDecode(&logs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var progress []interface{}
+	for _, e := range entries {
+		if e["@message"] == "AppScan operation progress" && e["operation"] == "report 1" {
+			progress = append(progress, e["progress_percent"])
+		}
+	}
+	if len(progress) != 3 || progress[0] != 10.0 || progress[1] != 50.0 || progress[2] != 100.0 {
+		t.Errorf("logged progress %v, want each change once", progress)
+	}
+}
+
+func TestWaitForThrottled(t *testing.T) {
+	ctx := context.Background()
+	client := &AppScanClient{}
+	polls := 0
+	settings := waitSettings{pollInterval: time.Millisecond, maxWait: time.Minute}
+	poll := func() (interface{}, string, int, error) {
+		polls++
+		switch polls {
+		case 1:
+			return "running", "Running", -1, nil
+		case 2, 3:
+			return nil, "", -1, &appscan.APIError{Operation: "read report", StatusCode: http.StatusTooManyRequests}
+		default:
+			return "ready", "Ready", -1, nil
+		}
+	}
+	raw, err := client.waitFor(ctx, "report 1", settings, []string{"Running"}, []string{"Ready"}, poll)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if raw != "ready" || polls != 4 {
+		t.Errorf("got %v after %d polls, want ready after 4", raw, polls)
+	}
+
+	// The first poll must succeed: there is no state to keep yet.
+	polls = 1
+	if _, err := client.waitFor(ctx, "report 1", settings, []string{"Running"}, []string{"Ready"}, poll); err == nil {
+		t.Error("got no error for a throttled first poll")
+	}
+}
+
+func TestWaitForCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	client := &AppScanClient{}
+	settings := waitSettings{pollInterval: time.Hour, maxWait: 2 * time.Hour}
+	time.AfterFunc(10*time.Millisecond, cancel)
+	start := time.Now()
+	_, err := client.waitFor(ctx, "report 1", settings, []string{"Running"}, []string{"Ready"},
+		func() (interface{}, string, int, error) {
+			return "running", "Running", -1, nil
+		})
+	if err == nil {
+		t.Fatal("got no error once the context was cancelled")
+	}
+	if elapsed := time.Since(start); elapsed > time.Minute {
+		t.Errorf("waited %s once the context was cancelled", elapsed)
+	}
+}
+
+func TestValidatePositiveDuration(t *testing.T) {
+	for v, valid := range map[string]bool{"30s": true, "2h": true, "0s": false, "-1m": false, "10": false} {
+		if _, errs := validatePositiveDuration(v, "poll_interval"); (len(errs) == 0) != valid {
+			t.Errorf("validatePositiveDuration(%q): %v", v, errs)
+		}
+	}
+}
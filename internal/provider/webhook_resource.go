@@ -0,0 +1,401 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/131/terraform-provider-appscan/pkg/appscan"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// Webhooks are called through an AppScan Presence, which must be able to
+// reach the URL. A webhook notifies a single event, either for every
+// application of its scope (global) or for the applications and asset groups
+// it is associated with.
+
+// webhookEvents are the events a webhook can notify.
+var webhookEvents = []string{"ScanExecutionCompleted", "ApplicationUpdated"}
+
+func resourceAppScanWebhook() *schema.Resource {
+	return &schema.Resource{
+		CreateWithoutTimeout: withContext(resourceAppScanWebhookCreate),
+		ReadWithoutTimeout:   withContext(resourceAppScanWebhookRead),
+		UpdateWithoutTimeout: withContext(resourceAppScanWebhookUpdate),
+		DeleteWithoutTimeout: withContext(resourceAppScanWebhookDelete),
+		Importer: &schema.ResourceImporter{
+			State: importGUID,
+		},
+		Schema: map[string]*schema.Schema{
+			"url": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Sensitive:    true,
+				Description:  "The URL called when the event occurs. It is sensitive, since webhook URLs often embed the token of the receiving service.",
+				ValidateFunc: validation.All(validation.IsURLWithHTTPorHTTPS, validation.StringLenBetween(1, 2048)),
+			},
+			"event": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "The event notified. Allowed values: ScanExecutionCompleted, ApplicationUpdated.",
+				ValidateFunc: validation.StringInSlice(webhookEvents, false),
+			},
+			"presence_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "The ID of the AppScan Presence the webhook is called through.",
+				ValidateFunc: validateGUID,
+			},
+			"asset_group_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Description:  "The ID of the asset group the webhook belongs to. If omitted, the webhook belongs to the organization, which requires access to all asset groups.",
+				ValidateFunc: validation.Any(validation.StringIsEmpty, validateGUID),
+			},
+			"global": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"application_ids", "asset_group_ids"},
+				Description:   "If true, the event is notified for every application of the webhook's asset group, or of the organization.",
+			},
+			"application_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The IDs of the applications the event is notified for, when the webhook is not global.",
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validateGUID},
+				Set:         hashEqualFold,
+			},
+			"asset_group_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The IDs of the asset groups whose applications the event is notified for, when the webhook is not global.",
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validateGUID},
+				Set:         hashEqualFold,
+			},
+			"id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The unique identifier of the webhook.",
+			},
+		},
+	}
+}
+
+// appScanWebhook holds the WebhookModel fields the provider relies on.
+type appScanWebhook struct {
+	Id         string       `json:"Id"`
+	Uri        string       `json:"Uri"`
+	Event      string       `json:"Event"`
+	Global     bool         `json:"Global"`
+	Presence   *namedEntity `json:"Presence"`
+	AssetGroup *namedEntity `json:"AssetGroup"`
+}
+
+// appScanWebhookRequest is the WebhookModel payload of the create and
+// update requests. The event and the asset group are only sent on creation.
+type appScanWebhookRequest struct {
+	Uri          string `json:"Uri"`
+	Event        string `json:"Event,omitempty"`
+	PresenceId   string `json:"PresenceId"`
+	Global       bool   `json:"Global"`
+	AssetGroupId string `json:"AssetGroupId,omitempty"`
+}
+
+// webhookScopes maps the association arguments to the scopes of the API.
+var webhookScopes = []struct{ attr, scope string }{
+	{"application_ids", "Application"},
+	{"asset_group_ids", "AssetGroup"},
+}
+
+func resourceAppScanWebhookCreate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	body, err := json.Marshal(appScanWebhookRequest{
+		Uri:          d.Get("url").(string),
+		Event:        d.Get("event").(string),
+		PresenceId:   d.Get("presence_id").(string),
+		Global:       d.Get("global").(bool),
+		AssetGroupId: d.Get("asset_group_id").(string),
+	})
+	if err != nil {
+		return err
+	}
+	urlStr := fmt.Sprintf("%s/Webhooks", client.ApiBase)
+	req, err := client.newRequest(ctx, "POST", urlStr, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return appscan.NewAPIError("create webhook", resp)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	var webhook appScanWebhook
+	if err := appscan.DecodeJSON(resp, respBody, &webhook); err != nil {
+		return err
+	}
+	if webhook.Id == "" {
+		return fmt.Errorf("failed to retrieve webhook ID from API response")
+	}
+	d.SetId(webhook.Id)
+	if err := client.awaitListed(ctx, "webhook "+webhook.Id, func() (bool, error) {
+		listed, err := getWebhook(ctx, client, webhook.Id)
+		return listed != nil, err
+	}); err != nil {
+		return err
+	}
+	client.Summary.record("webhook_created", "appscan_webhook", webhook.Id, map[string]string{
+		"event": webhook.Event,
+	})
+
+	for _, s := range webhookScopes {
+		for _, id := range d.Get(s.attr).(*schema.Set).List() {
+			if err := addWebhookAssociation(ctx, client, d.Id(), s.scope, id.(string)); err != nil {
+				return err
+			}
+		}
+	}
+	return resourceAppScanWebhookRead(ctx, d, m)
+}
+
+func resourceAppScanWebhookRead(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	webhook, err := getWebhook(ctx, client, d.Id())
+	if err != nil {
+		return err
+	}
+	if webhook == nil {
+		d.SetId("")
+		return nil
+	}
+	d.Set("url", webhook.Uri)
+	d.Set("event", webhook.Event)
+	d.Set("global", webhook.Global)
+	presenceID, assetGroupID := "", ""
+	if webhook.Presence != nil {
+		presenceID = webhook.Presence.Id
+	}
+	if webhook.AssetGroup != nil {
+		assetGroupID = webhook.AssetGroup.Id
+	}
+	d.Set("presence_id", presenceID)
+	d.Set("asset_group_id", assetGroupID)
+
+	// A global webhook is associated with the organization only.
+	associations := map[string][]interface{}{}
+	if !webhook.Global {
+		list, err := getWebhookAssociations(ctx, client, d.Id())
+		if err != nil {
+			return err
+		}
+		for _, a := range list {
+			associations[a.Scope] = append(associations[a.Scope], a.ScopeId)
+		}
+	}
+	for _, s := range webhookScopes {
+		if err := d.Set(s.attr, associations[s.scope]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func resourceAppScanWebhookUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	if d.HasChanges("url", "presence_id", "global") {
+		body, err := json.Marshal(appScanWebhookRequest{
+			Uri:        d.Get("url").(string),
+			PresenceId: d.Get("presence_id").(string),
+			Global:     d.Get("global").(bool),
+		})
+		if err != nil {
+			return err
+		}
+		urlStr := fmt.Sprintf("%s/Webhooks/%s", client.ApiBase, url.PathEscape(d.Id()))
+		req, err := client.newRequest(ctx, "PUT", urlStr, bytes.NewBuffer(body))
+		if err != nil {
+			return err
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return appscan.NewAPIError("update webhook", resp)
+		}
+	}
+
+	for _, s := range webhookScopes {
+		if !d.HasChange(s.attr) {
+			continue
+		}
+		o, n := d.GetChange(s.attr)
+		for _, id := range o.(*schema.Set).Difference(n.(*schema.Set)).List() {
+			if err := removeWebhookAssociation(ctx, client, d.Id(), s.scope, id.(string)); err != nil {
+				return err
+			}
+		}
+		for _, id := range n.(*schema.Set).Difference(o.(*schema.Set)).List() {
+			if err := addWebhookAssociation(ctx, client, d.Id(), s.scope, id.(string)); err != nil {
+				return err
+			}
+		}
+	}
+	return resourceAppScanWebhookRead(ctx, d, m)
+}
+
+func resourceAppScanWebhookDelete(ctx context.Context, d *schema.ResourceData, m interface{}) error {
+	client := m.(*AppScanClient)
+
+	urlStr := fmt.Sprintf("%s/Webhooks/%s", client.ApiBase, url.PathEscape(d.Id()))
+	req, err := client.newRequest(ctx, "DELETE", urlStr, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return appscan.NewAPIError("delete webhook", resp)
+	}
+	client.Summary.record("webhook_deleted", "appscan_webhook", d.Id(), nil)
+	d.SetId("")
+	return nil
+}
+
+// getWebhook fetches a webhook, returning nil when it does not exist.
+func getWebhook(ctx context.Context, client *AppScanClient, id string) (*appScanWebhook, error) {
+	filterQuery, err := client.odataEqID(ctx, "Id", id)
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{}
+	query.Set("$filter", filterQuery)
+
+	var result struct {
+		Items []appScanWebhook `json:"Items"`
+	}
+	if err := getODataPage(ctx, client, "Webhooks", query, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Items) == 0 {
+		return nil, nil
+	}
+	return &result.Items[0], nil
+}
+
+// appScanWebhookAssociation mirrors the WebhookAssociation model.
+type appScanWebhookAssociation struct {
+	Scope   string `json:"Scope"`
+	ScopeId string `json:"ScopeId"`
+}
+
+// getWebhookAssociations lists the applications and asset groups a webhook
+// is associated with.
+func getWebhookAssociations(ctx context.Context, client *AppScanClient, id string) ([]appScanWebhookAssociation, error) {
+	urlStr := fmt.Sprintf("%s/Webhooks/Associations/%s", client.ApiBase, url.PathEscape(id))
+	req, err := client.newRequest(ctx, "GET", urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, appscan.NewAPIError("read webhook associations", resp)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	var associations []appScanWebhookAssociation
+	if err := appscan.DecodeJSON(resp, respBody, &associations); err != nil {
+		return nil, err
+	}
+	return associations, nil
+}
+
+// addWebhookAssociation associates a webhook with an application or an
+// asset group.
+func addWebhookAssociation(ctx context.Context, client *AppScanClient, id, scope, scopeID string) error {
+	body, err := json.Marshal(appScanWebhookAssociation{Scope: scope, ScopeId: scopeID})
+	if err != nil {
+		return err
+	}
+	urlStr := fmt.Sprintf("%s/Webhooks/Associations/%s", client.ApiBase, url.PathEscape(id))
+	req, err := client.newRequest(ctx, "POST", urlStr, bytes.NewBuffer(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return appscan.NewAPIError(fmt.Sprintf("associate webhook with %s %s", scope, scopeID), resp)
+	}
+	return nil
+}
+
+// removeWebhookAssociation removes the association of a webhook with an
+// application or an asset group.
+func removeWebhookAssociation(ctx context.Context, client *AppScanClient, id, scope, scopeID string) error {
+	query := url.Values{}
+	query.Set("scope", scope)
+	query.Set("scopeId", scopeID)
+	urlStr := fmt.Sprintf("%s/Webhooks/Associations/%s?%s", client.ApiBase, url.PathEscape(id), query.Encode())
+	req, err := client.newRequest(ctx, "DELETE", urlStr, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return appscan.NewAPIError(fmt.Sprintf("dissociate webhook from %s %s", scope, scopeID), resp)
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+const mockPresenceID = "77777777-7777-7777-7777-777777777777"
+
+func TestAccWebhookResource(t *testing.T) {
+	m := newMockServer(t)
+	otherGroup := "22222222-2222-2222-2222-222222222222"
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccWebhookConfig(m, "ftp://hooks.example.com/", ""),
+				ExpectError: regexp.MustCompile(`expected "url" to have a url with schema of: "http,https"`),
+			},
+			{
+				Config: testAccWebhookConfig(m, "https://hooks.example.com/appscan", fmt.Sprintf(`
+  application_ids = [%q]
+`, mockApplicationID)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_webhook.test", "event", "ScanExecutionCompleted"),
+					resource.TestCheckResourceAttr("appscan_webhook.test", "presence_id", mockPresenceID),
+					resource.TestCheckResourceAttr("appscan_webhook.test", "asset_group_id", mockAssetGroupID),
+					resource.TestCheckResourceAttr("appscan_webhook.test", "application_ids.#", "1"),
+					resource.TestCheckTypeSetElemAttr("appscan_webhook.test", "application_ids.*", mockApplicationID),
+				),
+			},
+			{
+				Config: testAccWebhookConfig(m, "https://hooks.example.com/v2/appscan", fmt.Sprintf(`
+  asset_group_ids = [%q]
+`, otherGroup)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_webhook.test", "url", "https://hooks.example.com/v2/appscan"),
+					resource.TestCheckResourceAttr("appscan_webhook.test", "application_ids.#", "0"),
+					resource.TestCheckTypeSetElemAttr("appscan_webhook.test", "asset_group_ids.*", otherGroup),
+				),
+			},
+			{
+				Config: testAccWebhookConfig(m, "https://hooks.example.com/v2/appscan", `
+  global = true
+`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("appscan_webhook.test", "global", "true"),
+					resource.TestCheckResourceAttr("appscan_webhook.test", "asset_group_ids.#", "0"),
+				),
+			},
+			{
+				ResourceName:      "appscan_webhook.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAccWebhookConfig(m *mockServer, url, extra string) string {
+	return testAccProviderConfig(m) + fmt.Sprintf(`
+resource "appscan_webhook" "test" {
+  url            = %q
+  event          = "ScanExecutionCompleted"
+  presence_id    = %q
+  asset_group_id = %q
+%s}
+`, url, mockPresenceID, mockAssetGroupID, extra)
+}
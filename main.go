@@ -7,10 +7,17 @@ import (
 	"github.com/131/terraform-provider-appscan/internal/provider"
 )
 
+// version is set at build time, e.g. with -ldflags "-X main.version=1.2.0".
+var version = "dev"
+
 func main() {
+	provider.Version = version
+	p := provider.Provider()
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: func() *schema.Provider {
-			return provider.Provider()
+			return p
 		},
 	})
+	// Serve returns once Terraform is done with the provider.
+	provider.ReportMetrics(p)
 }
@@ -0,0 +1,59 @@
+package appscan
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// APIKeyLogin exchanges an API key for an access token, and returns it with
+// its expiry date. apiBase is the URL API paths are relative to.
+func APIKeyLogin(ctx context.Context, client *http.Client, apiBase, keyID, keySecret string) (string, string, error) {
+	// Construct payload for API key login.
+	payload := map[string]string{
+		"KeyId":     keyID,
+		"KeySecret": keySecret,
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", "", err
+	}
+
+	loginURL := fmt.Sprintf("%s/Account/ApiKeyLogin", apiBase)
+	req, err := http.NewRequestWithContext(ctx, "POST", loginURL, bytes.NewBuffer(body))
+	if err != nil {
+		return "", "", err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", "", NewAPIError("authenticate via API key", resp)
+	}
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", "", err
+	}
+
+	// The login endpoint now returns a "Token" field.
+	var authResp struct {
+		Token  string `json:"Token"`
+		Expire string `json:"Expire"`
+	}
+	if err := DecodeJSON(resp, respBody, &authResp); err != nil {
+		return "", "", err
+	}
+	if authResp.Token == "" {
+		return "", "", fmt.Errorf("failed to obtain token from API key login response")
+	}
+	return authResp.Token, authResp.Expire, nil
+}
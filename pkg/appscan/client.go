@@ -0,0 +1,229 @@
+package appscan
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultEndpoint is the endpoint of AppScan on Cloud, its us region.
+	DefaultEndpoint = "https://cloud.appscan.com"
+	// DefaultAPIPath is the path of the API under the endpoint, followed by
+	// the API version.
+	DefaultAPIPath = "/api/v4"
+	// PageSize is the number of entries List requests per page ($top).
+	PageSize = 500
+)
+
+// Config configures NewClient. Either KeyID and KeySecret, or Token, must be
+// set.
+type Config struct {
+	// Endpoint is the URL of AppScan, or the name of an AppScan on Cloud
+	// region. Defaults to DefaultEndpoint.
+	Endpoint string
+	// APIPath is the path of the API under Endpoint. Defaults to
+	// DefaultAPIPath.
+	APIPath string
+	// KeyID and KeySecret are an API key, exchanged for a token by
+	// NewClient.
+	KeyID     string
+	KeySecret string
+	// Token is a bearer token obtained beforehand.
+	Token string
+	// HTTPClient sends the requests, http.DefaultClient when nil. Its
+	// transport is wrapped to authenticate them.
+	HTTPClient *http.Client
+	// UserAgent is sent with every request, when set.
+	UserAgent string
+}
+
+// Client calls the AppScan API. It is safe for concurrent use.
+type Client struct {
+	// BaseURL is the URL API paths are relative to, e.g.
+	// https://cloud.appscan.com/api/v4.
+	BaseURL string
+	// HTTPClient sends the requests. It must authenticate them: NewClient
+	// sets it so.
+	HTTPClient *http.Client
+	// Token is the bearer token of the client, and TokenExpiry its expiry
+	// date when NewClient obtained it from an API key.
+	Token       string
+	TokenExpiry string
+}
+
+// NewClient returns a client of the API configured by cfg, logging in with
+// the API key of cfg when it has no token.
+func NewClient(ctx context.Context, cfg Config) (*Client, error) {
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = DefaultEndpoint
+	}
+	endpoint, err := NormalizeEndpoint(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid endpoint: %w", err)
+	}
+	apiPath := cfg.APIPath
+	if apiPath == "" {
+		apiPath = DefaultAPIPath
+	}
+	baseURL := endpoint + "/" + strings.Trim(apiPath, "/")
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	base := httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	transport := &bearerTransport{transport: base, userAgent: cfg.UserAgent}
+	c := &Client{BaseURL: baseURL, Token: cfg.Token}
+	authenticated := *httpClient
+	authenticated.Transport = transport
+	c.HTTPClient = &authenticated
+
+	if c.Token == "" {
+		if cfg.KeyID == "" || cfg.KeySecret == "" {
+			return nil, fmt.Errorf("either KeyID and KeySecret, or Token, must be set")
+		}
+		// The transport has no token yet.
+		c.Token, c.TokenExpiry, err = APIKeyLogin(ctx, c.HTTPClient, baseURL, cfg.KeyID, cfg.KeySecret)
+		if err != nil {
+			return nil, err
+		}
+	}
+	transport.token = c.Token
+	return c, nil
+}
+
+// bearerTransport sets the User-Agent and, once known, the bearer token of
+// the requests. The token is set before the client is shared.
+type bearerTransport struct {
+	transport http.RoundTripper
+	token     string
+	userAgent string
+}
+
+func (t *bearerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	if t.token != "" {
+		req.Header.Set("Authorization", "Bearer "+t.token)
+	}
+	if t.userAgent != "" {
+		req.Header.Set("User-Agent", t.userAgent)
+	}
+	return t.transport.RoundTrip(req)
+}
+
+// NewRequest builds a request of path, relative to BaseURL. The body, when
+// not nil, is sent as JSON.
+func (c *Client) NewRequest(ctx context.Context, method, path string, body io.Reader) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+"/"+strings.TrimLeft(path, "/"), body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	return req, nil
+}
+
+// Do sends req and returns the body of its response, or an *APIError naming
+// operation when the status is not 200.
+func (c *Client) Do(req *http.Request, operation string) ([]byte, error) {
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, APIErrorFromBody(operation, resp, respBody)
+	}
+	return respBody, nil
+}
+
+// GetEntity reads the entity at path, relative to BaseURL, into result. It
+// reports false when the entity does not exist, and returns the other
+// errors, naming the entity what in the message.
+func (c *Client) GetEntity(ctx context.Context, what, path string, result interface{}) (bool, error) {
+	req, err := c.NewRequest(ctx, "GET", path, nil)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return false, APIErrorFromBody("read "+what, resp, respBody)
+	}
+	return true, DecodeJSON(resp, respBody, result)
+}
+
+// GetODataPage fetches a page of an OData collection, such as "Apps", into
+// result, which holds the entries in Items and their number in Count; see
+// DecodeODataPage.
+func (c *Client) GetODataPage(ctx context.Context, collection string, query url.Values, result interface{}) error {
+	req, err := c.NewRequest(ctx, "GET", collection+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return APIErrorFromBody("list "+collection, resp, respBody)
+	}
+	return DecodeODataPage(resp, respBody, result)
+}
+
+// List returns every entry of an OData collection matching query, reading
+// it PageSize entries at a time. The $top and $skip of query are replaced.
+func List[T any](ctx context.Context, c *Client, collection string, query url.Values) ([]T, error) {
+	q := url.Values{}
+	for k, v := range query {
+		q[k] = v
+	}
+	var items []T
+	for skip := 0; ; skip += PageSize {
+		q.Set("$top", strconv.Itoa(PageSize))
+		q.Set("$skip", strconv.Itoa(skip))
+		var page Page[T]
+		if err := c.GetODataPage(ctx, collection, q, &page); err != nil {
+			return nil, err
+		}
+		items = append(items, page.Items...)
+		if len(page.Items) < PageSize {
+			return items, nil
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package appscan
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+)
+
+// testServer serves an API key login and n applications, requiring the
+// token of the login.
+func testServer(t *testing.T, n int) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v4/Account/ApiKeyLogin", func(w http.ResponseWriter, r *http.Request) {
+		var key struct{ KeyId, KeySecret string }
+		if err := json.NewDecoder(r.Body).Decode(&key); err != nil || key.KeyId != "id" || key.KeySecret != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"Key":"INVALID_CREDENTIALS","Message":"Invalid key"}`)
+			return
+		}
+		fmt.Fprint(w, `{"Token":"token","Expire":"2030-01-01T00:00:00Z"}`)
+	})
+	mux.HandleFunc("/api/v4/", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v4/Apps":
+			top, _ := strconv.Atoi(r.URL.Query().Get("$top"))
+			skip, _ := strconv.Atoi(r.URL.Query().Get("$skip"))
+			items := []Application{}
+			for i := skip; i < n && i < skip+top; i++ {
+				items = append(items, Application{Id: strconv.Itoa(i), Name: r.URL.Query().Get("$filter")})
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"Items": items, "Count": n})
+		case "/api/v4/Scans/1":
+			fmt.Fprint(w, `{"Id":"1","Name":"main","LatestExecution":{"Status":"Ready"}}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestNewClient(t *testing.T) {
+	server := testServer(t, 0)
+	ctx := context.Background()
+
+	c, err := NewClient(ctx, Config{Endpoint: server.URL + "/", KeyID: "id", KeySecret: "secret"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.BaseURL != server.URL+"/api/v4" || c.Token != "token" || c.TokenExpiry == "" {
+		t.Errorf("NewClient = %+v", c)
+	}
+
+	_, err = NewClient(ctx, Config{Endpoint: server.URL, KeyID: "id", KeySecret: "wrong"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusUnauthorized || apiErr.Key != "INVALID_CREDENTIALS" {
+		t.Errorf("NewClient with a wrong key = %v", err)
+	}
+
+	if _, err := NewClient(ctx, Config{Endpoint: server.URL}); err == nil {
+		t.Error("NewClient without credentials should fail")
+	}
+}
+
+func TestList(t *testing.T) {
+	server := testServer(t, PageSize+1)
+	ctx := context.Background()
+	c, err := NewClient(ctx, Config{Endpoint: server.URL, Token: "token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filter, err := ODataEqString("Name", "O'Brien's app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	apps, err := List[Application](ctx, c, "Apps", url.Values{"$filter": {filter}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(apps) != PageSize+1 || apps[PageSize].Id != strconv.Itoa(PageSize) || apps[0].Name != "Name eq 'O''Brien''s app'" {
+		t.Errorf("List returned %d applications, the first %+v", len(apps), apps[0])
+	}
+
+	_, err = List[Application](ctx, c, "Missing", nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound || apiErr.Operation != "list Missing" {
+		t.Errorf("List of a missing collection = %v", err)
+	}
+}
+
+func TestGetEntity(t *testing.T) {
+	server := testServer(t, 0)
+	ctx := context.Background()
+	c, err := NewClient(ctx, Config{Endpoint: server.URL, Token: "token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var scan Scan
+	if found, err := c.GetEntity(ctx, "scan", "Scans/1", &scan); err != nil || !found {
+		t.Fatalf("GetEntity = %v, %v", found, err)
+	}
+	if scan.Name != "main" || scan.LatestExecution == nil || scan.LatestExecution.Status != "Ready" {
+		t.Errorf("GetEntity read %+v", scan)
+	}
+	if found, err := c.GetEntity(ctx, "scan", "Scans/2", &scan); err != nil || found {
+		t.Errorf("GetEntity of a missing scan = %v, %v", found, err)
+	}
+}
@@ -0,0 +1,25 @@
+// Package appscan is a Go client of the AppScan on Cloud REST API, the one
+// the Terraform provider is built on, for the tools that need the same
+// authenticated client without the provider: controllers, CLI wrappers,
+// scripts.
+//
+// A Client is created from an API key or a bearer token:
+//
+//	c, err := appscan.NewClient(ctx, appscan.Config{
+//		Endpoint:  "eu",
+//		KeyID:     os.Getenv("APPSCAN_KEY_ID"),
+//		KeySecret: os.Getenv("APPSCAN_KEY_SECRET"),
+//	})
+//
+// List reads a whole OData collection, page by page, and the OData* functions
+// build the filters of its query, escaping their values:
+//
+//	filter, err := appscan.ODataEqString("Name", "O'Brien's app")
+//	apps, err := appscan.List[appscan.Application](ctx, c, "Apps", url.Values{"$filter": {filter}})
+//
+// Failures of the API are returned as *APIError, which carries the status
+// and the error key of the response.
+//
+// The exported API of the package is stable: it only grows, in backward
+// compatible ways, within a major version of the provider.
+package appscan
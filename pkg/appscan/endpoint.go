@@ -0,0 +1,54 @@
+package appscan
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// RegionEndpoints are the endpoints of the AppScan on Cloud data centers,
+// by region name. The API key login is served by the API host of each.
+var RegionEndpoints = map[string]string{
+	"us": "https://cloud.appscan.com",
+	"eu": "https://eu.cloud.appscan.com",
+}
+
+// NormalizeEndpoint resolves a region name to its endpoint and checks that
+// an endpoint is an absolute http or https URL. The result has no trailing
+// slash, so that API paths can be appended to it.
+func NormalizeEndpoint(endpoint string) (string, error) {
+	endpoint = strings.TrimSpace(endpoint)
+	if region, ok := RegionEndpoints[strings.ToLower(endpoint)]; ok {
+		return region, nil
+	}
+
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	if u.Scheme != "https" && u.Scheme != "http" {
+		return "", fmt.Errorf("%q must be an http or https URL, or one of the regions %s", endpoint, strings.Join(RegionNames(), ", "))
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("%q has no host", endpoint)
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return "", fmt.Errorf("%q must not have a query or a fragment", endpoint)
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	u.Path = strings.TrimRight(u.Path, "/")
+	u.RawPath = ""
+	return u.String(), nil
+}
+
+// RegionNames returns the sorted names of RegionEndpoints.
+func RegionNames() []string {
+	names := make([]string, 0, len(RegionEndpoints))
+	for name := range RegionEndpoints {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,34 @@
+package appscan
+
+import "testing"
+
+func TestNormalizeEndpoint(t *testing.T) {
+	for endpoint, expected := range map[string]string{
+		"https://cloud.appscan.com/":       "https://cloud.appscan.com",
+		"https://cloud.appscan.com//":      "https://cloud.appscan.com",
+		"https://cloud.appscan.com":        "https://cloud.appscan.com",
+		"HTTPS://Cloud.AppScan.com/":       "https://cloud.appscan.com",
+		" http://localhost:8080/appscan/ ": "http://localhost:8080/appscan",
+		"us":                               "https://cloud.appscan.com",
+		"EU":                               "https://eu.cloud.appscan.com",
+	} {
+		actual, err := NormalizeEndpoint(endpoint)
+		if err != nil {
+			t.Errorf("NormalizeEndpoint(%q): %s", endpoint, err)
+		} else if actual != expected {
+			t.Errorf("NormalizeEndpoint(%q) = %q, expected %q", endpoint, actual, expected)
+		}
+	}
+
+	for _, endpoint := range []string{
+		"cloud.appscan.com",
+		"ftp://cloud.appscan.com",
+		"https://",
+		"https://cloud.appscan.com/?tenant=1",
+		"asia",
+	} {
+		if _, err := NormalizeEndpoint(endpoint); err == nil {
+			t.Errorf("NormalizeEndpoint(%q) should fail", endpoint)
+		}
+	}
+}
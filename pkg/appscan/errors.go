@@ -0,0 +1,159 @@
+package appscan
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// maxErrorBodySize bounds how much of an error response is read.
+const maxErrorBodySize = 64 << 10
+
+// APIError is returned when the API answers with an unexpected status. It
+// carries the decoded error body so callers can inspect what was rejected.
+type APIError struct {
+	// Operation describes what the client was doing, e.g. "create application".
+	Operation  string
+	StatusCode int
+	Status     string
+
+	// Key, Message and FormatParams come from the API ErrorMessage model.
+	Key          string
+	Message      string
+	FormatParams []string
+
+	// FieldErrors maps rejected request fields to their validation messages,
+	// from ProblemDetails-style validation responses.
+	FieldErrors map[string][]string
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "failed to %s, status: %s", e.Operation, e.Status)
+	if e.Message != "" {
+		fmt.Fprintf(&b, ": %s", e.Message)
+	}
+	if e.Key != "" {
+		fmt.Fprintf(&b, "\n\nError key: %s", e.Key)
+		if len(e.FormatParams) > 0 {
+			fmt.Fprintf(&b, " (%s)", strings.Join(e.FormatParams, ", "))
+		}
+	}
+	if len(e.FieldErrors) > 0 {
+		fields := make([]string, 0, len(e.FieldErrors))
+		for field := range e.FieldErrors {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		b.WriteString("\n\nRejected fields:")
+		for _, field := range fields {
+			fmt.Fprintf(&b, "\n  - %s: %s", field, strings.Join(e.FieldErrors[field], " "))
+		}
+	}
+	return b.String()
+}
+
+// NewAPIError builds an APIError from a response, reading its body.
+func NewAPIError(operation string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	return APIErrorFromBody(operation, resp, body)
+}
+
+// APIErrorFromBody builds an APIError from a response whose body was
+// already read.
+func APIErrorFromBody(operation string, resp *http.Response, body []byte) error {
+	apiErr := &APIError{
+		Operation:  operation,
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+	}
+
+	var payload struct {
+		// ErrorMessage model.
+		Key          string   `json:"Key"`
+		Message      string   `json:"Message"`
+		FormatParams []string `json:"FormatParams"`
+		// ProblemDetails model.
+		Title  string              `json:"title"`
+		Detail string              `json:"detail"`
+		Errors map[string][]string `json:"errors"`
+	}
+	if json.Unmarshal(body, &payload) != nil {
+		// Typically the HTML error page of a proxy or gateway.
+		if len(bytes.TrimSpace(body)) > 0 {
+			apiErr.Message = describeBody(resp, body)
+		}
+		return apiErr
+	}
+	apiErr.Key = payload.Key
+	apiErr.Message = payload.Message
+	apiErr.FormatParams = payload.FormatParams
+	if apiErr.Message == "" {
+		apiErr.Message = strings.TrimSpace(strings.Join([]string{payload.Title, payload.Detail}, " "))
+	}
+	if len(payload.Errors) > 0 {
+		apiErr.FieldErrors = payload.Errors
+	}
+	return apiErr
+}
+
+// maxSnippetSize bounds how much of an unexpected body is quoted in errors.
+const maxSnippetSize = 200
+
+var (
+	htmlTitleRegexp = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	htmlTagRegexp   = regexp.MustCompile(`(?s)<[^>]*>`)
+)
+
+// DecodeJSON unmarshals a response body into v. A body that is not JSON, such
+// as the HTML page of a proxy or gateway answering in place of the API, is
+// reported with the status, content type and beginning of the body rather
+// than as a bare syntax error.
+func DecodeJSON(resp *http.Response, body []byte, v interface{}) error {
+	err := json.Unmarshal(body, v)
+	if err == nil {
+		return nil
+	}
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		return err
+	}
+	target := "the API"
+	if resp.Request != nil {
+		target = fmt.Sprintf("%s %s", resp.Request.Method, resp.Request.URL.Path)
+	}
+	return fmt.Errorf("unexpected response to %s, status: %s: %s\n\nThe response may come from a proxy or gateway rather than the API.", target, resp.Status, describeBody(resp, body))
+}
+
+// describeBody summarizes a body that is not JSON: its content type and a
+// snippet, the title and text of HTML pages.
+func describeBody(resp *http.Response, body []byte) string {
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "no content type"
+	}
+	text := string(body)
+	if strings.Contains(strings.ToLower(contentType), "html") || htmlTagRegexp.MatchString(text) {
+		if m := htmlTitleRegexp.FindStringSubmatch(text); m != nil {
+			text = m[1]
+		} else {
+			text = htmlTagRegexp.ReplaceAllString(text, " ")
+		}
+		text = html.UnescapeString(text)
+	}
+	text = strings.Join(strings.Fields(text), " ")
+	if len(text) > maxSnippetSize {
+		text = strings.ToValidUTF8(text[:maxSnippetSize], "") + "..."
+	}
+	if text == "" {
+		return fmt.Sprintf("non-JSON body (%s)", contentType)
+	}
+	return fmt.Sprintf("non-JSON body (%s): %s", contentType, text)
+}
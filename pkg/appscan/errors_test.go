@@ -0,0 +1,67 @@
+package appscan
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const gatewayErrorPage = `<!DOCTYPE html>
+<html><head><title>502 Bad Gateway</title></head>
+<body><h1>Bad Gateway</h1><p>The proxy server received an invalid response.</p></body></html>`
+
+func testResponse(status int, contentType string) *http.Response {
+	req := httptest.NewRequest("GET", "https://cloud.appscan.com/api/v4/Apps", nil)
+	resp := &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Header:     http.Header{},
+		Request:    req,
+	}
+	if contentType != "" {
+		resp.Header.Set("Content-Type", contentType)
+	}
+	return resp
+}
+
+func TestApiErrorFromBodyHTML(t *testing.T) {
+	err := APIErrorFromBody("read application", testResponse(http.StatusBadGateway, "text/html"), []byte(gatewayErrorPage))
+	got := err.Error()
+	for _, want := range []string{"read application", "text/html", "502 Bad Gateway"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("error %q does not contain %q", got, want)
+		}
+	}
+	if strings.Contains(got, "<") {
+		t.Errorf("error %q contains markup", got)
+	}
+}
+
+func TestDecodeJSON(t *testing.T) {
+	var v struct {
+		Id string `json:"Id"`
+	}
+	if err := DecodeJSON(testResponse(http.StatusOK, "application/json"), []byte(`{"Id":"a"}`), &v); err != nil || v.Id != "a" {
+		t.Fatalf("DecodeJSON = %v, Id = %q", err, v.Id)
+	}
+
+	err := DecodeJSON(testResponse(http.StatusOK, "text/html; charset=utf-8"), []byte(gatewayErrorPage), &v)
+	if err == nil {
+		t.Fatal("decodeJSON accepted an HTML page")
+	}
+	for _, want := range []string{"GET /api/v4/Apps", "OK", "text/html; charset=utf-8", "502 Bad Gateway", "proxy"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not contain %q", err, want)
+		}
+	}
+
+	// Bodies without markup are quoted, truncated.
+	err = DecodeJSON(testResponse(http.StatusOK, ""), []byte(strings.Repeat("x", 1000)), &v)
+	if err == nil || !strings.Contains(err.Error(), "no content type") || !strings.Contains(err.Error(), "...") {
+		t.Errorf("error %q", err)
+	}
+	if len(err.Error()) > 500 {
+		t.Errorf("error is %d bytes long", len(err.Error()))
+	}
+}
@@ -0,0 +1,69 @@
+package appscan
+
+// The models hold the fields of the API entities the provider relies on.
+// The API returns more: decode them into your own types to read those.
+
+// NamedEntity is the Id and Name of an entity, such as an asset group or a
+// business unit.
+type NamedEntity struct {
+	Id   string `json:"Id"`
+	Name string `json:"Name"`
+}
+
+// Application is an application, as listed by Apps.
+type Application struct {
+	Id             string `json:"Id"`
+	Name           string `json:"Name"`
+	Description    string `json:"Description"`
+	AssetGroupId   string `json:"AssetGroupId"`
+	BusinessUnitId string `json:"BusinessUnitId"`
+	BusinessImpact string `json:"BusinessImpact"`
+}
+
+// Scan is a scan, as listed by Scans.
+type Scan struct {
+	Id              string     `json:"Id"`
+	Name            string     `json:"Name"`
+	AppId           string     `json:"AppId"`
+	Technology      string     `json:"Technology"`
+	IsPersonal      bool       `json:"IsPersonal"`
+	LatestExecution *Execution `json:"LatestExecution"`
+	// EnableMailNotification is read from Scans/Dast and Scans/Sast: the
+	// listing of scans of any technology and their updates name it
+	// EnableMailNotifications.
+	EnableMailNotification bool `json:"EnableMailNotification"`
+
+	// DAST scans only.
+	ScanConfiguration      *DastConfiguration `json:"ScanConfiguration"`
+	Presence               *NamedEntity       `json:"Presence"`
+	LoginConfigurationType string             `json:"LoginConfigurationType"`
+}
+
+// DastConfiguration is the configuration of a DAST scan.
+type DastConfiguration struct {
+	StartingUrl string `json:"StartingUrl"`
+	LoginUser   string `json:"LoginUser"`
+}
+
+// Execution is an execution of a scan.
+type Execution struct {
+	Id                string `json:"Id"`
+	ScanId            string `json:"ScanId"`
+	Status            string `json:"Status"`
+	ExecutionProgress string `json:"ExecutionProgress"`
+	Progress          int    `json:"Progress"`
+	UserMessage       string `json:"UserMessage"`
+	CreatedAt         string `json:"CreatedAt"`
+	ExecutedAt        string `json:"ExecutedAt"`
+	ScanEndTime       string `json:"ScanEndTime"`
+	// ExecutionDurationSec is how long the execution ran, queue excluded.
+	ExecutionDurationSec int `json:"ExecutionDurationSec"`
+	// PredefinedMessageKey identifies the message of failed executions.
+	PredefinedMessageKey string `json:"PredefinedMessageKey"`
+	NIssuesFound         int    `json:"NIssuesFound"`
+	NCriticalIssues      int    `json:"NCriticalIssues"`
+	NHighIssues          int    `json:"NHighIssues"`
+	NMediumIssues        int    `json:"NMediumIssues"`
+	NLowIssues           int    `json:"NLowIssues"`
+	NInfoIssues          int    `json:"NInfoIssues"`
+}
@@ -0,0 +1,108 @@
+package appscan
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	odataFieldRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_/]*$`)
+	guidRegexp       = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+)
+
+// ODataQuote returns value as an OData string literal. Single quotes are
+// escaped by doubling them, as required by the OData ABNF.
+func ODataQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", "''") + "'"
+}
+
+// ODataEqString builds a `field eq 'value'` filter expression.
+// The value is escaped so names such as "O'Brien's Apps" neither break the
+// query nor inject extra filter clauses.
+func ODataEqString(field, value string) (string, error) {
+	if err := validateODataField(field); err != nil {
+		return "", err
+	}
+	if value == "" {
+		return "", fmt.Errorf("empty value for OData filter on %s", field)
+	}
+	if strings.ContainsFunc(value, isControlRune) {
+		return "", fmt.Errorf("value for OData filter on %s contains control characters", field)
+	}
+	return fmt.Sprintf("%s eq %s", field, ODataQuote(value)), nil
+}
+
+// ODataStringFunc builds a `function(field,'value')` filter expression for
+// the string functions of OData, such as contains and startswith.
+func ODataStringFunc(function, field, value string) (string, error) {
+	if err := validateODataField(field); err != nil {
+		return "", err
+	}
+	if value == "" {
+		return "", fmt.Errorf("empty value for OData %s on %s", function, field)
+	}
+	if strings.ContainsFunc(value, isControlRune) {
+		return "", fmt.Errorf("value for OData %s on %s contains control characters", function, field)
+	}
+	return fmt.Sprintf("%s(%s,%s)", function, field, ODataQuote(value)), nil
+}
+
+// ODataEqGUID builds a `field eq <guid>` filter expression. GUID literals are
+// not quoted in OData, so the value must be a well-formed GUID.
+func ODataEqGUID(field, value string) (string, error) {
+	if err := validateODataField(field); err != nil {
+		return "", err
+	}
+	if !guidRegexp.MatchString(value) {
+		return "", fmt.Errorf("invalid GUID for OData filter on %s: %q", field, value)
+	}
+	return fmt.Sprintf("%s eq %s", field, value), nil
+}
+
+// IsGUID reports whether value is a GUID, the format of the IDs of the API.
+func IsGUID(value string) bool {
+	return guidRegexp.MatchString(value)
+}
+
+func validateODataField(field string) error {
+	if !odataFieldRegexp.MatchString(field) {
+		return fmt.Errorf("invalid OData field name: %q", field)
+	}
+	return nil
+}
+
+func isControlRune(r rune) bool {
+	return r < 0x20 || r == 0x7f
+}
+
+// ODataEqInt builds a `field eq <value>` filter expression for a numeric field.
+func ODataEqInt(field string, value int) (string, error) {
+	if err := validateODataField(field); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s eq %d", field, value), nil
+}
+
+// ODataOr joins expressions with `or`, parenthesized so the result can be
+// safely combined with other clauses. It returns "" for no expressions.
+func ODataOr(exprs []string) string {
+	switch len(exprs) {
+	case 0:
+		return ""
+	case 1:
+		return exprs[0]
+	}
+	return "(" + strings.Join(exprs, " or ") + ")"
+}
+
+// ODataAnd joins the non-empty expressions with `and`.
+func ODataAnd(exprs ...string) string {
+	var parts []string
+	for _, e := range exprs {
+		if e != "" {
+			parts = append(parts, e)
+		}
+	}
+	return strings.Join(parts, " and ")
+}
@@ -0,0 +1,85 @@
+package appscan
+
+import "testing"
+
+func TestODataQuote(t *testing.T) {
+	for value, expected := range map[string]string{
+		"":                "''",
+		"app":             "'app'",
+		"O'Brien's Apps":  "'O''Brien''s Apps'",
+		"''":              "''''''",
+		"x' or Name eq '": "'x'' or Name eq '''",
+	} {
+		if actual := ODataQuote(value); actual != expected {
+			t.Errorf("ODataQuote(%q) = %q, expected %q", value, actual, expected)
+		}
+	}
+}
+
+func TestODataEqString(t *testing.T) {
+	for _, c := range []struct {
+		field, value, expected string
+	}{
+		{"Name", "app", "Name eq 'app'"},
+		{"Name", "O'Brien's Apps", "Name eq 'O''Brien''s Apps'"},
+		{"Application/Name", "app", "Application/Name eq 'app'"},
+		{"Name", "é ü", "Name eq 'é ü'"},
+	} {
+		actual, err := ODataEqString(c.field, c.value)
+		if err != nil {
+			t.Errorf("ODataEqString(%q, %q): %s", c.field, c.value, err)
+		} else if actual != c.expected {
+			t.Errorf("ODataEqString(%q, %q) = %q, expected %q", c.field, c.value, actual, c.expected)
+		}
+	}
+
+	for _, c := range []struct {
+		field, value string
+	}{
+		{"Name", ""},
+		{"Name", "app\n"},
+		{"Name", "a\tpp"},
+		{"Name", "app\x00"},
+		{"Name", "app\x7f"},
+		{"", "app"},
+		{"Name eq 'x' or Name", "app"},
+		{"1Name", "app"},
+	} {
+		if _, err := ODataEqString(c.field, c.value); err == nil {
+			t.Errorf("ODataEqString(%q, %q) should fail", c.field, c.value)
+		}
+	}
+}
+
+func TestODataEqGUID(t *testing.T) {
+	for _, c := range []struct {
+		field, value, expected string
+	}{
+		{"Id", "0b8f2f4e-9c3a-4d1e-8f6a-2b7c5d9e1a3f", "Id eq 0b8f2f4e-9c3a-4d1e-8f6a-2b7c5d9e1a3f"},
+		{"AssetGroupId", "0B8F2F4E-9C3A-4D1E-8F6A-2B7C5D9E1A3F", "AssetGroupId eq 0B8F2F4E-9C3A-4D1E-8F6A-2B7C5D9E1A3F"},
+	} {
+		actual, err := ODataEqGUID(c.field, c.value)
+		if err != nil {
+			t.Errorf("ODataEqGUID(%q, %q): %s", c.field, c.value, err)
+		} else if actual != c.expected {
+			t.Errorf("ODataEqGUID(%q, %q) = %q, expected %q", c.field, c.value, actual, c.expected)
+		}
+	}
+
+	for _, c := range []struct {
+		field, value string
+	}{
+		{"Id", ""},
+		{"Id", "app"},
+		{"Id", "{0b8f2f4e-9c3a-4d1e-8f6a-2b7c5d9e1a3f}"},
+		{"Id", "'0b8f2f4e-9c3a-4d1e-8f6a-2b7c5d9e1a3f'"},
+		{"Id", "0b8f2f4e9c3a4d1e8f6a2b7c5d9e1a3f"},
+		{"Id", "0b8f2f4e-9c3a-4d1e-8f6a-2b7c5d9e1a3g"},
+		{"Id", "0b8f2f4e-9c3a-4d1e-8f6a-2b7c5d9e1a3f or true"},
+		{"Id eq 0", "0b8f2f4e-9c3a-4d1e-8f6a-2b7c5d9e1a3f"},
+	} {
+		if _, err := ODataEqGUID(c.field, c.value); err == nil {
+			t.Errorf("ODataEqGUID(%q, %q) should fail", c.field, c.value)
+		}
+	}
+}
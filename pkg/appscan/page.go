@@ -0,0 +1,50 @@
+package appscan
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strconv"
+)
+
+// Page is a page of an OData collection.
+type Page[T any] struct {
+	Items []T `json:"Items"`
+	// Count is the number of entries matching the query, when requested
+	// with $count.
+	Count int `json:"Count"`
+}
+
+// DecodeODataPage unmarshals a page of an OData collection into result,
+// which holds the entries in Items and their number in Count, as v4 returns
+// them. The value and @odata.count fields of standard OData, returned by
+// other API versions, and bare arrays are mapped to them.
+func DecodeODataPage(resp *http.Response, body []byte, result interface{}) error {
+	var page map[string]json.RawMessage
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		var items []json.RawMessage
+		if err := DecodeJSON(resp, trimmed, &items); err != nil {
+			return err
+		}
+		page = map[string]json.RawMessage{
+			"Items": trimmed,
+			"Count": json.RawMessage(strconv.Itoa(len(items))),
+		}
+	} else {
+		if err := DecodeJSON(resp, body, &page); err != nil {
+			return err
+		}
+		if _, ok := page["Items"]; ok {
+			return DecodeJSON(resp, body, result)
+		}
+		page["Items"] = page["value"]
+		if _, ok := page["Count"]; !ok {
+			page["Count"] = page["@odata.count"]
+		}
+	}
+	normalized, err := json.Marshal(page)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(normalized, result)
+}
@@ -0,0 +1,24 @@
+package appscan
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestDecodeODataPage(t *testing.T) {
+	resp := testResponse(http.StatusOK, "application/json")
+	for name, body := range map[string]string{
+		"v4":    `{"Items":[{"Id":"a"},{"Id":"b"}],"Count":2}`,
+		"OData": `{"value":[{"Id":"a"},{"Id":"b"}],"@odata.count":2}`,
+		"array": `[{"Id":"a"},{"Id":"b"}]`,
+	} {
+		var page Page[NamedEntity]
+		if err := DecodeODataPage(resp, []byte(body), &page); err != nil {
+			t.Errorf("%s: %s", name, err)
+			continue
+		}
+		if len(page.Items) != 2 || page.Items[1].Id != "b" || page.Count != 2 {
+			t.Errorf("%s: got %+v", name, page)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package appscan
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+)
+
+// DefaultUploadChunkSize is the size of the reads of the uploaded content,
+// and the granularity of their progress, when an Upload sets none.
+const DefaultUploadChunkSize = 8 << 20
+
+// Upload is a file sent to FileUpload by UploadFile, such as the IRX archive
+// of a SAST scan.
+type Upload struct {
+	// Name is the name of the file, e.g. app.irx.
+	Name string
+	// Content is read once, to its end, while the request is sent. Its
+	// length must be Size.
+	Content io.Reader
+	Size    int64
+	// FileType is the type of the file, empty for the files the API
+	// identifies by itself, such as IRX archives.
+	FileType string
+	// ChunkSize is the maximum size of the reads of Content, and the
+	// granularity of Progress. Defaults to DefaultUploadChunkSize.
+	ChunkSize int64
+	// Progress, when set, is called with the number of bytes of Content
+	// sent every ChunkSize bytes, and once Content is sent.
+	Progress func(sent, total int64)
+}
+
+// UploadFile streams u to FileUpload and returns the ID referencing the
+// file in the payloads creating scans. The content is never buffered in
+// memory: IRX archives of big code bases exceed 1 GB. The multipart
+// envelope is computed up front so the request still carries a
+// Content-Length.
+func (c *Client) UploadFile(ctx context.Context, u Upload) (string, error) {
+	// Render the multipart header and trailer around the content.
+	var head bytes.Buffer
+	writer := multipart.NewWriter(&head)
+	if _, err := writer.CreateFormFile("uploadedFile", u.Name); err != nil {
+		return "", err
+	}
+	headLen := head.Len()
+	if err := writer.Close(); err != nil {
+		return "", err
+	}
+	tail := append([]byte(nil), head.Bytes()[headLen:]...)
+	head.Truncate(headLen)
+
+	chunkSize := u.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultUploadChunkSize
+	}
+	content := &progressReader{
+		reader:    u.Content,
+		total:     u.Size,
+		chunkSize: chunkSize,
+		progress:  u.Progress,
+	}
+	body := io.MultiReader(&head, content, bytes.NewReader(tail))
+
+	query := url.Values{}
+	query.Set("fileName", u.Name)
+	if u.FileType != "" {
+		query.Set("fileType", u.FileType)
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", c.BaseURL+"/FileUpload?"+query.Encode(), body)
+	if err != nil {
+		return "", err
+	}
+	req.ContentLength = int64(head.Len()) + u.Size + int64(len(tail))
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return "", NewAPIError("upload file "+u.Name, resp)
+	}
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	var result struct {
+		FileId string `json:"FileId"`
+	}
+	if err := DecodeJSON(resp, respBody, &result); err != nil {
+		return "", err
+	}
+	if result.FileId == "" {
+		return "", fmt.Errorf("failed to retrieve file ID from upload response")
+	}
+	return result.FileId, nil
+}
+
+// progressReader reads at most chunkSize bytes at a time, and reports the
+// bytes read every chunkSize bytes and at the end of the content.
+type progressReader struct {
+	reader    io.Reader
+	total     int64
+	read      int64
+	reported  int64
+	chunkSize int64
+	progress  func(sent, total int64)
+}
+
+func (r *progressReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > r.chunkSize {
+		p = p[:r.chunkSize]
+	}
+	n, err := r.reader.Read(p)
+	before := r.read
+	r.read += int64(n)
+	if r.progress != nil && (r.read/r.chunkSize != before/r.chunkSize || (err == io.EOF && r.read != r.reported)) {
+		r.reported = r.read
+		r.progress(r.read, r.total)
+	}
+	return n, err
+}
@@ -0,0 +1,56 @@
+package appscan
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUploadFile(t *testing.T) {
+	var received string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/FileUpload" || r.URL.Query().Get("fileName") != "app.irx" || r.ContentLength <= 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		file, _, err := r.FormFile("uploadedFile")
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		content, err := io.ReadAll(file)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		received = string(content)
+		fmt.Fprint(w, `{"FileId":"file-1"}`)
+	}))
+	defer server.Close()
+	c := &Client{BaseURL: server.URL + "/api/v4", HTTPClient: server.Client()}
+
+	content := "0123456789"
+	var progress [][2]int64
+	id, err := c.UploadFile(context.Background(), Upload{
+		Name:      "app.irx",
+		Content:   strings.NewReader(content),
+		Size:      int64(len(content)),
+		ChunkSize: 4,
+		Progress:  func(sent, total int64) { progress = append(progress, [2]int64{sent, total}) },
+	})
+	if err != nil || id != "file-1" {
+		t.Fatalf("UploadFile() = %q, %v, want file-1", id, err)
+	}
+	if received != content {
+		t.Errorf("received %q, want %q", received, content)
+	}
+	if expected := [][2]int64{{4, 10}, {8, 10}, {10, 10}}; !reflect.DeepEqual(progress, expected) {
+		t.Errorf("progress = %v, want %v", progress, expected)
+	}
+}